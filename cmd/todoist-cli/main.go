@@ -2,8 +2,13 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/joeyhipolito/todoist-cli/internal/cmd"
 	"github.com/joeyhipolito/todoist-cli/internal/config"
@@ -12,13 +17,23 @@ import (
 const version = "0.1.0"
 
 func main() {
-	if err := run(); err != nil {
+	// Cancel in-flight requests and backoff sleeps on Ctrl-C or SIGTERM.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx); err != nil {
+		if errors.Is(err, context.Canceled) {
+			fmt.Fprintln(os.Stderr, "Interrupted.")
+			stop()
+			os.Exit(130)
+		}
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		stop()
 		os.Exit(1)
 	}
 }
 
-func run() error {
+func run(ctx context.Context) error {
 	args := os.Args[1:]
 
 	// Handle help and version flags
@@ -36,26 +51,55 @@ func run() error {
 	subcommand := args[0]
 	remainingArgs := args[1:]
 
-	// Check for global --json flag
-	jsonOutput := false
+	// Check for global output and --offline flags
+	var formatName, tmpl, tmplFile string
+	offline := os.Getenv(cmd.EnvOffline) != ""
 	var filteredArgs []string
-	for _, arg := range remainingArgs {
-		if arg == "--json" {
-			jsonOutput = true
-		} else {
+	for i := 0; i < len(remainingArgs); i++ {
+		arg := remainingArgs[i]
+		name, value, hasValue := strings.Cut(arg, "=")
+		switch name {
+		case "--format", "--template", "--template-file":
+			if !hasValue {
+				if i+1 >= len(remainingArgs) {
+					return fmt.Errorf("%s requires a value", name)
+				}
+				i++
+				value = remainingArgs[i]
+			}
+			switch name {
+			case "--format":
+				formatName = value
+			case "--template":
+				tmpl = value
+			case "--template-file":
+				tmplFile = value
+			}
+			continue
+		}
+		switch arg {
+		case "--json":
+			formatName = "json"
+		case "--offline":
+			offline = true
+		default:
 			filteredArgs = append(filteredArgs, arg)
 		}
 	}
+	format, err := cmd.OutputFormat(formatName, tmpl, tmplFile)
+	if err != nil {
+		return err
+	}
 
 	// Commands that don't require authentication
 	switch subcommand {
 	case "configure":
 		if len(filteredArgs) > 0 && filteredArgs[0] == "show" {
-			return cmd.ConfigureShowCmd(jsonOutput)
+			return cmd.ConfigureShowCmd(format)
 		}
 		return cmd.ConfigureCmd()
 	case "doctor":
-		return cmd.DoctorCmd(jsonOutput)
+		return cmd.DoctorCmd(ctx, os.Stdout, format)
 	}
 
 	// Resolve access token: config file > environment variable
@@ -64,22 +108,53 @@ func run() error {
 		return fmt.Errorf("no access token found\n\nRun 'todoist configure' to set up, or set TODOIST_ACCESS_TOKEN")
 	}
 
+	apiClient, err := cmd.NewClient(token)
+	if err != nil {
+		return err
+	}
+	cached, err := cmd.NewCache(apiClient)
+	if err != nil {
+		return err
+	}
+	client := cmd.NewOffline(cached, offline)
+	out := os.Stdout
+
 	// Dispatch to authenticated commands
 	switch subcommand {
 	case "list":
-		return cmd.ListCmd(token, filteredArgs, jsonOutput)
+		return cmd.ListCmd(ctx, client, out, filteredArgs, format)
+	case "show":
+		return cmd.ShowCmd(ctx, client, out, filteredArgs, format)
 	case "add":
-		return cmd.AddCmd(token, filteredArgs, jsonOutput)
+		return cmd.AddCmd(ctx, client, out, filteredArgs, format)
+	case "edit":
+		return cmd.EditCmd(ctx, client, out, filteredArgs, format)
+	case "move":
+		return cmd.MoveCmd(ctx, client, out, filteredArgs, format)
 	case "close":
-		return cmd.CloseCmd(token, filteredArgs, jsonOutput)
+		return cmd.CloseCmd(ctx, client, out, filteredArgs, format)
+	case "reopen":
+		return cmd.ReopenCmd(ctx, client, out, filteredArgs, format)
 	case "delete":
-		return cmd.DeleteCmd(token, filteredArgs, jsonOutput)
+		return cmd.DeleteCmd(ctx, client, out, filteredArgs, format)
 	case "projects":
-		return cmd.ProjectsCmd(token, filteredArgs, jsonOutput)
+		return cmd.ProjectsCmd(ctx, client, out, filteredArgs, format)
+	case "comments":
+		return cmd.CommentsCmd(ctx, client, out, filteredArgs, format)
+	case "sections":
+		return cmd.SectionsCmd(ctx, client, out, filteredArgs, format)
+	case "agenda":
+		return cmd.AgendaCmd(ctx, client, out, filteredArgs, format)
+	case "calendar":
+		return cmd.CalendarCmd(ctx, client, out, filteredArgs, format)
 	case "completed":
-		return cmd.CompletedCmd(token, filteredArgs, jsonOutput)
+		return cmd.CompletedCmd(ctx, client, out, filteredArgs, format)
 	case "labels":
-		return cmd.LabelsCmd(token, filteredArgs, jsonOutput)
+		return cmd.LabelsCmd(ctx, client, out, filteredArgs, format)
+	case "sync":
+		return cmd.SyncCmd(ctx, client, out, filteredArgs, format)
+	case "cache":
+		return cmd.CacheCmd(ctx, cached, out, filteredArgs, format)
 	default:
 		return fmt.Errorf("unknown command: %s\n\nRun 'todoist --help' for usage", subcommand)
 	}
@@ -93,14 +168,27 @@ USAGE:
 
 COMMANDS:
     list                    List tasks (default: today & overdue)
+    show                    Show every detail of a task
+    agenda                  Tasks due in the coming days, grouped by day
+    calendar                Month or week grid of due tasks and deadlines
     completed               List completed tasks
     add                     Add a new task
+    edit                    Update an existing task
+    move                    Move tasks to another project, section, or parent
     close                   Complete a task
+    reopen                  Reopen a completed task
     delete                  Delete a task
-    projects                List all projects
+    comments                List, add, or delete task/project comments
+    projects                List all projects as a tree
     projects add            Create a new project
+    projects edit           Update a project
+    projects archive        Archive or unarchive a project
     projects delete         Delete a project
+    sections                List, add, rename, or delete sections
     labels                  List all labels
+    labels add|edit|rename|delete|shared   Manage labels
+    sync                    Send queued changes and update the local copy
+    cache refresh|clear     Manage the project/section/label lookup cache
     configure               Set up Todoist access token
     configure show          Show current configuration
     doctor                  Validate installation and configuration
@@ -108,6 +196,30 @@ COMMANDS:
 LIST TASKS:
     todoist list [options]
         --filter <query>        Filter (today, overdue, p1, @label, #project)
+        --project <name>        Only tasks in this project
+        --section <name>        Only tasks in this section
+        --tree                  Nest subtasks under their parents (text only)
+        --limit <n>             Max results (default: all)
+        --page-size <n>         Results per API request (max 200)
+
+SHOW TASK:
+    todoist show <task-id>              Description, due, deadline, duration,
+                                        project/section names, subtasks
+
+AGENDA:
+    todoist agenda [options]            Overdue tasks, then each day's tasks
+        --days <n>              Number of days to show (default: 7)
+        --from <YYYY-MM-DD>     First day (default: today)
+        --to <YYYY-MM-DD>       Last day, instead of --days
+        --project <name>        Only tasks in this project
+        --hours <n>             Hours per day before a day is overbooked (default: 8)
+
+CALENDAR:
+    todoist calendar [options]          Grid with tasks due and deadlines per day
+        --month <YYYY-MM>       Month to show (default: this month)
+        --week                  Show one week instead of a month
+        --day <YYYY-MM-DD>      List this day's tasks below the grid
+        --project <name>        Only tasks in this project
 
 COMPLETED TASKS:
     todoist completed [options]
@@ -120,19 +232,96 @@ ADD TASK:
         --date <date>           Due date (today, tomorrow, YYYY-MM-DD)
         --priority <1-4>        Priority (1=urgent, 4=normal)
         --project <name>        Target project
+        --section <name>        Target section (within --project)
+        --parent <task-id>      Create as a subtask
         --labels <l1,l2>        Comma-separated labels
 
-CLOSE/DELETE TASK:
-    todoist close <task-id>
+EDIT TASK:
+    todoist edit <task-id> [options]
+        --content <text>        New task name
+        --description <text>    New description
+        --date <date>           Due date (today, tomorrow, YYYY-MM-DD)
+        --priority <1-4>        Priority (1=urgent, 4=normal)
+        --labels <l1,l2>        Replace all labels
+        --add-label <l1,l2>     Add labels to the existing set
+        --remove-label <l1,l2>  Remove labels from the existing set
+        --deadline <YYYY-MM-DD> Deadline date
+        --duration <n>          Duration (30m, 1h30m, 2d)
+        --assignee <user>       Assign to a collaborator (ID, name or email)
+
+MOVE TASKS:
+    todoist move <task-id...> [options]
+        --to-project <name>     Destination project
+        --section <name>        Destination section (within --to-project)
+        --parent <task-id>      Make the tasks subtasks of this task
+
+CLOSE/REOPEN/DELETE TASK:
+    todoist close <task-id> [--recursive]   --recursive also closes subtasks
+    todoist reopen <task-id>
+    todoist reopen --last <n>           Reopen the n most recently completed
     todoist delete <task-id>
 
+COMMENTS:
+    todoist comments <task-id>                      List comments on a task
+    todoist comments --project <name>               List comments on a project
+    todoist comments add <task-id> "text"           Comment on a task
+        --attach <file>         Upload and attach a file
+    todoist comments delete <comment-id>            Delete a comment
+
 PROJECTS:
-    todoist projects                    List all projects
+    todoist projects [--limit <n>]      List all projects as a tree
     todoist projects add <name>         Create a new project
-    todoist projects delete <id>        Delete a project
+        --parent <name>         Nest under another project
+        --color <color>         Color name (e.g. berry_red, blue, grey)
+        --favorite              Mark as favorite
+        --view <style>          list, board, or calendar
+    todoist projects edit <project>     Update name/color/favorite/view
+    todoist projects archive <project>  Archive a project
+    todoist projects unarchive <project>
+    todoist projects archived           List archived projects
+    todoist projects delete <project>   Delete after confirmation (--yes to skip)
+
+SECTIONS:
+    todoist sections [--project <name>]             List sections
+    todoist sections add <name> --project <name>    Create a section
+    todoist sections rename <section> <new-name>    Rename a section
+    todoist sections delete <section>               Delete a section
+
+LABELS:
+    todoist labels [--limit <n>]                    List all labels
+    todoist labels add <name> [--color <c>] [--favorite]
+    todoist labels edit <label> [--name <new>] [--color <c>] [--favorite|--unfavorite]
+    todoist labels rename <old> <new>               Rename on every task
+    todoist labels delete <label>                   Delete and remove from tasks
+    todoist labels shared [--omit-personal]         All label names in use
+
+SYNC:
+    todoist sync                Send queued changes, then fetch changes since the last sync
+    todoist sync --full         Download everything again
+    todoist sync --pending      List changes queued while offline
+    State file: ~/.todoist/sync.json
+
+OFFLINE:
+    When the API can't be reached (or with --offline / TODOIST_OFFLINE=1),
+    add, edit, move, close and delete are queued in ~/.todoist/journal.jsonl
+    and report "queued"; list, show and name lookups answer from the last
+    sync. Queued changes are sent, in order, by the next 'todoist sync'.
+
+CACHE:
+    todoist cache               Show cached lookups and their age
+    todoist cache refresh       Fetch projects, sections and labels again
+    todoist cache clear         Delete the cache
+    Cache directory: ~/.todoist/cache (cache_ttl / TODOIST_CACHE_TTL, default 1h)
 
 GLOBAL OPTIONS:
-    --json              Output in JSON format
+    --format <format>   Output as text (default), table, csv, tsv, yaml,
+                        jsonl, markdown or json
+    --json              Same as --format json
+    --template <text>   Render each result with a Go template, e.g.
+                        '{{.ID}} {{.Content}} {{due .}}', or the name of a
+                        template.<name> from the config file
+    --template-file <f> Read the template from a file
+    --offline           Queue changes and read from the last sync
     --help, -h          Show this help
     --version, -v       Show version
 
@@ -147,12 +336,23 @@ EXAMPLES:
     todoist list                                # Today's tasks
     todoist list --filter "overdue"             # Overdue tasks
     todoist list --filter "#Work"               # Tasks in Work project
+    todoist agenda --days 14                    # The next two weeks, by day
+    todoist calendar --month 2026-11 --day 2026-11-05
     todoist add "Buy groceries" --date tomorrow --priority 2
     todoist add "Review PR" --project "Work" --labels "dev,urgent"
+    todoist edit 1234567890 --date friday --add-label waiting
+    todoist move 111 222 --to-project "Work" --section "Backlog"
     todoist close 1234567890                    # Complete a task
+    todoist reopen --last 1                     # Undo the last close
+    todoist add "Write spec" --project "Work" --section "Planning"
+    todoist comments add 1234567890 "Blocked on review" --attach log.txt
     todoist projects --json                     # List projects as JSON
+    todoist list --format table                 # Tasks as an aligned table
+    todoist completed --format csv > done.csv   # Completed tasks as CSV
+    todoist list --template '{{priority .}} {{.Content}}{{if overdue .}} !{{end}}'
     todoist projects add "Work"                 # Create a project
-    todoist projects delete 1234567890          # Delete a project
+    todoist projects add "Q4" --parent "Work" --color blue
+    todoist projects delete "Old stuff"         # Delete a project (asks first)
     todoist completed                            # Recently completed tasks
     todoist completed --since 2026-02-01        # Completed after date
     todoist completed --project "Work"           # Completed in project
@@ -0,0 +1,1326 @@
+// Package apitest provides an in-memory implementation of api.TodoistAPI
+// for testing commands without a network or a real Todoist account.
+package apitest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+// DefaultPageSize is the page size the fake uses when none is requested,
+// matching the v1 API default.
+const DefaultPageSize = 50
+
+// Fake is an in-memory Todoist account. It follows the v1 API semantics the
+// CLI relies on: new tasks land in the Inbox, list endpoints are cursor
+// paginated, closed tasks move to the completed list, and deleting a task,
+// section or project removes everything under it. Unknown IDs return a 404
+// *api.TodoistError and invalid payloads a 400.
+//
+// Create one with New. A Fake is safe for concurrent use.
+type Fake struct {
+	// Now is the clock used for added_at, completed_at and relative due
+	// strings. It defaults to time.Now.
+	Now func() time.Time
+
+	// Filter evaluates a filter query against a task for GetTasks and
+	// IterTasks. When nil, any non-empty filter is rejected with a 400.
+	Filter func(query string, t *api.Task) (bool, error)
+
+	// User is the account the fake's token belongs to, as returned by
+	// syncs.
+	User api.User
+
+	mu        sync.Mutex
+	nextID    int
+	inboxID   string
+	tasks     []*api.Task
+	completed []*api.Task
+	projects  []*api.Project
+	archived  map[string]bool
+	sections  []*api.Section
+	labels    []*api.Label
+	shared    []string
+	comments  []*api.Comment
+	collabs   map[string][]*api.Collaborator
+	calls     []string
+	syncs     int
+}
+
+var _ api.TodoistAPI = (*Fake)(nil)
+
+// New returns an empty account containing only the Inbox project.
+func New() *Fake {
+	f := &Fake{
+		Now:      time.Now,
+		User:     api.User{ID: "fake-user", FullName: "Fake User", Email: "fake-user@example.com"},
+		nextID:   1000,
+		archived: map[string]bool{},
+		collabs:  map[string][]*api.Collaborator{},
+	}
+	inbox := &api.Project{ID: f.newID(), Name: "Inbox", IsInboxProject: true, Color: "charcoal", ViewStyle: "list"}
+	f.inboxID = inbox.ID
+	f.projects = append(f.projects, inbox)
+	return f
+}
+
+// InboxID returns the ID of the Inbox project.
+func (f *Fake) InboxID() string {
+	return f.inboxID
+}
+
+// AddProject seeds a project and returns it. An empty ID is generated.
+func (f *Fake) AddProject(p api.Project) *api.Project {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if p.ID == "" {
+		p.ID = f.newID()
+	}
+	if p.ViewStyle == "" {
+		p.ViewStyle = "list"
+	}
+	f.projects = append(f.projects, &p)
+	return &p
+}
+
+// AddSection seeds a section and returns it. An empty ID is generated.
+func (f *Fake) AddSection(s api.Section) *api.Section {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if s.ID == "" {
+		s.ID = f.newID()
+	}
+	f.sections = append(f.sections, &s)
+	return &s
+}
+
+// AddTask seeds an active task and returns it. An empty ID is generated,
+// an empty project defaults to the Inbox and priority defaults to 1.
+func (f *Fake) AddTask(t api.Task) *api.Task {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.seedTask(&t)
+	f.tasks = append(f.tasks, &t)
+	return &t
+}
+
+// AddCompletedTask seeds a completed task and returns it.
+func (f *Fake) AddCompletedTask(t api.Task) *api.Task {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.seedTask(&t)
+	t.IsCompleted = true
+	if t.CompletedAt == "" {
+		t.CompletedAt = f.timestamp()
+	}
+	f.completed = append(f.completed, &t)
+	return &t
+}
+
+// AddLabel seeds a personal label and returns it. An empty ID is generated.
+func (f *Fake) AddLabel(l api.Label) *api.Label {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if l.ID == "" {
+		l.ID = f.newID()
+	}
+	f.labels = append(f.labels, &l)
+	return &l
+}
+
+// AddSharedLabel seeds a label name that only exists on shared tasks.
+func (f *Fake) AddSharedLabel(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.shared = append(f.shared, name)
+}
+
+// AddCollaborator shares a project with a user and marks it shared. An
+// empty ID is generated.
+func (f *Fake) AddCollaborator(projectID string, c api.Collaborator) *api.Collaborator {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if c.ID == "" {
+		c.ID = f.newID()
+	}
+	if p := f.findProject(projectID); p != nil {
+		p.IsShared = true
+	}
+	f.collabs[projectID] = append(f.collabs[projectID], &c)
+	return &c
+}
+
+// Task returns a copy of the active or completed task with the given ID.
+func (f *Fake) Task(id string) (api.Task, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if t := f.findTask(id); t != nil {
+		return *t, true
+	}
+	if t := f.findCompleted(id); t != nil {
+		return *t, true
+	}
+	return api.Task{}, false
+}
+
+// Calls returns the API methods called so far, in order, each followed by
+// its main argument, e.g. "CloseTask 1003".
+func (f *Fake) Calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.calls)
+}
+
+// --- Tasks ---
+
+// GetTasks returns all active tasks matching the filters.
+func (f *Fake) GetTasks(ctx context.Context, filter, projectID, sectionID string) ([]*api.Task, error) {
+	return f.IterTasks(ctx, filter, projectID, sectionID, api.ListOptions{}).All()
+}
+
+// IterTasks returns an iterator over active tasks matching the filters.
+func (f *Fake) IterTasks(ctx context.Context, filter, projectID, sectionID string, opts api.ListOptions) *api.Iterator[*api.Task] {
+	return pages(ctx, f, "GetTasks", opts, func() ([]*api.Task, error) {
+		if filter != "" && f.Filter == nil {
+			return nil, badRequest("filters are not supported by this fake")
+		}
+		out := []*api.Task{}
+		for _, t := range f.tasks {
+			if projectID != "" && t.ProjectID != projectID {
+				continue
+			}
+			if sectionID != "" && t.SectionID != sectionID {
+				continue
+			}
+			if filter != "" {
+				ok, err := f.Filter(filter, t)
+				if err != nil {
+					return nil, badRequest(err.Error())
+				}
+				if !ok {
+					continue
+				}
+			}
+			out = append(out, copyTask(t))
+		}
+		return out, nil
+	})
+}
+
+// CreateTask creates a task in the requested project, or the Inbox.
+func (f *Fake) CreateTask(ctx context.Context, req *api.CreateTaskRequest) (*api.Task, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("CreateTask", req.Content)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(req.Content) == "" {
+		return nil, badRequest("content is required")
+	}
+	if req.Priority < 0 || req.Priority > 4 {
+		return nil, badRequest("priority must be between 1 and 4")
+	}
+
+	t := &api.Task{
+		ID:          f.newID(),
+		ProjectID:   f.inboxID,
+		Content:     req.Content,
+		Description: req.Description,
+		Labels:      append([]string{}, req.Labels...),
+		Priority:    max(req.Priority, 1),
+		Order:       req.Order,
+		AssigneeID:  req.AssigneeID,
+		CreatedAt:   f.timestamp(),
+	}
+
+	// A parent or section implies its project.
+	switch {
+	case req.ParentID != "":
+		parent := f.findTask(req.ParentID)
+		if parent == nil {
+			return nil, notFound("task", req.ParentID)
+		}
+		t.ParentID, t.ProjectID, t.SectionID = parent.ID, parent.ProjectID, parent.SectionID
+	case req.SectionID != "":
+		s := f.findSection(req.SectionID)
+		if s == nil {
+			return nil, notFound("section", req.SectionID)
+		}
+		t.SectionID, t.ProjectID = s.ID, s.ProjectID
+	case req.ProjectID != "":
+		if f.findProject(req.ProjectID) == nil {
+			return nil, notFound("project", req.ProjectID)
+		}
+		t.ProjectID = req.ProjectID
+	}
+
+	due, err := f.parseDue(req.DueString, req.DueDate, req.DueDatetime, req.DueLang)
+	if err != nil {
+		return nil, err
+	}
+	t.Due = due
+
+	if req.DeadlineDate != "" {
+		if _, err := time.Parse("2006-01-02", req.DeadlineDate); err != nil {
+			return nil, badRequest("invalid deadline_date")
+		}
+		t.Deadline = &api.Deadline{Date: req.DeadlineDate}
+	}
+
+	f.tasks = append(f.tasks, t)
+	return copyTask(t), nil
+}
+
+// GetTask returns a single active task.
+func (f *Fake) GetTask(ctx context.Context, taskID string) (*api.Task, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("GetTask", taskID)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	t := f.findTask(taskID)
+	if t == nil {
+		return nil, notFound("task", taskID)
+	}
+	return copyTask(t), nil
+}
+
+// UpdateTask applies the non-nil fields of req to a task.
+func (f *Fake) UpdateTask(ctx context.Context, taskID string, req *api.UpdateTaskRequest) (*api.Task, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("UpdateTask", taskID)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	t := f.findTask(taskID)
+	if t == nil {
+		return nil, notFound("task", taskID)
+	}
+
+	if req.Content != nil {
+		if strings.TrimSpace(*req.Content) == "" {
+			return nil, badRequest("content must not be empty")
+		}
+		t.Content = *req.Content
+	}
+	if req.Description != nil {
+		t.Description = *req.Description
+	}
+	if req.Labels != nil {
+		t.Labels = append([]string{}, (*req.Labels)...)
+	}
+	if req.Priority != nil {
+		if *req.Priority < 1 || *req.Priority > 4 {
+			return nil, badRequest("priority must be between 1 and 4")
+		}
+		t.Priority = *req.Priority
+	}
+	if req.DueString != nil || req.DueDate != nil || req.DueDatetime != nil {
+		due, err := f.parseDue(deref(req.DueString), deref(req.DueDate), deref(req.DueDatetime), deref(req.DueLang))
+		if err != nil {
+			return nil, err
+		}
+		t.Due = due
+	}
+	if req.AssigneeID != nil {
+		t.AssigneeID = *req.AssigneeID
+	}
+	if req.DeadlineDate != nil {
+		t.Deadline = nil
+		if *req.DeadlineDate != "" {
+			t.Deadline = &api.Deadline{Date: *req.DeadlineDate}
+		}
+	}
+	if req.Duration != nil && *req.Duration == 0 {
+		t.Duration = nil // a null duration over sync
+	} else if req.Duration != nil {
+		unit := deref(req.DurationUnit)
+		if unit != "minute" && unit != "day" {
+			return nil, badRequest("duration_unit must be minute or day")
+		}
+		t.Duration = &api.Duration{Amount: *req.Duration, Unit: unit}
+	}
+
+	return copyTask(t), nil
+}
+
+// MoveTask moves a task and its subtasks to a project, section or parent.
+func (f *Fake) MoveTask(ctx context.Context, taskID string, req *api.MoveTaskRequest) (*api.Task, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("MoveTask", taskID)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	t := f.findTask(taskID)
+	if t == nil {
+		return nil, notFound("task", taskID)
+	}
+
+	switch {
+	case req.ParentID != "":
+		parent := f.findTask(req.ParentID)
+		if parent == nil {
+			return nil, notFound("task", req.ParentID)
+		}
+		if parent.ID == t.ID || slices.Contains(f.descendants(t.ID), parent.ID) {
+			return nil, badRequest("a task cannot be moved under itself")
+		}
+		t.ParentID, t.ProjectID, t.SectionID = parent.ID, parent.ProjectID, parent.SectionID
+	case req.SectionID != "":
+		s := f.findSection(req.SectionID)
+		if s == nil {
+			return nil, notFound("section", req.SectionID)
+		}
+		t.ParentID, t.ProjectID, t.SectionID = "", s.ProjectID, s.ID
+	case req.ProjectID != "":
+		if f.findProject(req.ProjectID) == nil {
+			return nil, notFound("project", req.ProjectID)
+		}
+		t.ParentID, t.ProjectID, t.SectionID = "", req.ProjectID, ""
+	default:
+		return nil, badRequest("a destination is required")
+	}
+
+	for _, id := range f.descendants(t.ID) {
+		child := f.findTask(id)
+		child.ProjectID, child.SectionID = t.ProjectID, t.SectionID
+	}
+	return copyTask(t), nil
+}
+
+// CloseTask moves an active task to the completed list.
+func (f *Fake) CloseTask(ctx context.Context, taskID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("CloseTask", taskID)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	i := slices.IndexFunc(f.tasks, func(t *api.Task) bool { return t.ID == taskID })
+	if i < 0 {
+		return notFound("task", taskID)
+	}
+	t := f.tasks[i]
+	f.tasks = slices.Delete(f.tasks, i, i+1)
+	t.IsCompleted = true
+	t.CompletedAt = f.timestamp()
+	f.completed = append(f.completed, t)
+	return nil
+}
+
+// ReopenTask moves a completed task back to the active list. Reopening an
+// active task is a no-op, as in the real API.
+func (f *Fake) ReopenTask(ctx context.Context, taskID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("ReopenTask", taskID)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if f.findTask(taskID) != nil {
+		return nil
+	}
+	i := slices.IndexFunc(f.completed, func(t *api.Task) bool { return t.ID == taskID })
+	if i < 0 {
+		return notFound("task", taskID)
+	}
+	t := f.completed[i]
+	f.completed = slices.Delete(f.completed, i, i+1)
+	t.IsCompleted = false
+	t.CompletedAt = ""
+	f.tasks = append(f.tasks, t)
+	return nil
+}
+
+// DeleteTask permanently deletes a task and its subtasks.
+func (f *Fake) DeleteTask(ctx context.Context, taskID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("DeleteTask", taskID)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if f.findTask(taskID) == nil {
+		return notFound("task", taskID)
+	}
+	f.deleteTasks(append(f.descendants(taskID), taskID))
+	return nil
+}
+
+// GetCompletedTasks returns completed tasks, newest first, completed at or
+// after since (an ISO datetime) when given.
+func (f *Fake) GetCompletedTasks(ctx context.Context, projectID, since string, limit int) (*api.CompletedResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("GetCompletedTasks", projectID)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var sinceTime time.Time
+	if since != "" {
+		var err error
+		if sinceTime, err = time.Parse("2006-01-02T15:04:05", since); err != nil {
+			return nil, badRequest("invalid since")
+		}
+	}
+
+	// Newest first by completion time, as the API orders them; tasks
+	// completed in the same second keep the order they were completed in.
+	completed := slices.Clone(f.completed)
+	slices.Reverse(completed)
+	slices.SortStableFunc(completed, func(a, b *api.Task) int {
+		return strings.Compare(b.CompletedAt, a.CompletedAt)
+	})
+
+	resp := &api.CompletedResponse{Items: []api.CompletedTask{}, Projects: map[string]api.Project{}}
+	for _, t := range completed {
+		if projectID != "" && t.ProjectID != projectID {
+			continue
+		}
+		if !sinceTime.IsZero() {
+			done, err := time.Parse(time.RFC3339, t.CompletedAt)
+			if err == nil && done.Before(sinceTime) {
+				continue
+			}
+		}
+		if limit > 0 && len(resp.Items) >= limit {
+			break
+		}
+
+		item := api.CompletedTask{
+			ID:          "c" + t.ID,
+			TaskID:      t.ID,
+			ProjectID:   t.ProjectID,
+			Content:     t.Content,
+			CompletedAt: t.CompletedAt,
+			NoteCount:   t.NoteCount,
+		}
+		if t.SectionID != "" {
+			sectionID := t.SectionID
+			item.SectionID = &sectionID
+		}
+		resp.Items = append(resp.Items, item)
+		if p := f.findProject(t.ProjectID); p != nil {
+			resp.Projects[p.ID] = *p
+		}
+	}
+	return resp, nil
+}
+
+// --- Projects ---
+
+// GetProjects returns all active projects.
+func (f *Fake) GetProjects(ctx context.Context) ([]*api.Project, error) {
+	return f.IterProjects(ctx, api.ListOptions{}).All()
+}
+
+// IterProjects returns an iterator over active projects.
+func (f *Fake) IterProjects(ctx context.Context, opts api.ListOptions) *api.Iterator[*api.Project] {
+	return pages(ctx, f, "GetProjects", opts, func() ([]*api.Project, error) {
+		return f.projectList(false), nil
+	})
+}
+
+// CreateProject creates a project, optionally under a parent.
+func (f *Fake) CreateProject(ctx context.Context, req *api.CreateProjectRequest) (*api.Project, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("CreateProject", req.Name)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, badRequest("name is required")
+	}
+	if req.Color != "" && !api.IsValidColor(req.Color) {
+		return nil, badRequest("invalid color")
+	}
+	if req.ParentID != "" && f.findProject(req.ParentID) == nil {
+		return nil, notFound("project", req.ParentID)
+	}
+
+	p := &api.Project{
+		ID:         f.newID(),
+		Name:       req.Name,
+		ParentID:   req.ParentID,
+		Color:      req.Color,
+		IsFavorite: req.IsFavorite,
+		ViewStyle:  req.ViewStyle,
+	}
+	if p.Color == "" {
+		p.Color = "charcoal"
+	}
+	if p.ViewStyle == "" {
+		p.ViewStyle = "list"
+	}
+	f.projects = append(f.projects, p)
+	clone := *p
+	return &clone, nil
+}
+
+// UpdateProject applies the non-nil fields of req to a project.
+func (f *Fake) UpdateProject(ctx context.Context, projectID string, req *api.UpdateProjectRequest) (*api.Project, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("UpdateProject", projectID)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	p := f.findProject(projectID)
+	if p == nil {
+		return nil, notFound("project", projectID)
+	}
+	if req.Name != nil {
+		p.Name = *req.Name
+	}
+	if req.Color != nil {
+		if !api.IsValidColor(*req.Color) {
+			return nil, badRequest("invalid color")
+		}
+		p.Color = *req.Color
+	}
+	if req.IsFavorite != nil {
+		p.IsFavorite = *req.IsFavorite
+	}
+	if req.ViewStyle != nil {
+		p.ViewStyle = *req.ViewStyle
+	}
+	clone := *p
+	return &clone, nil
+}
+
+// ArchiveProject archives a project and its sub-projects.
+func (f *Fake) ArchiveProject(ctx context.Context, projectID string) (*api.Project, error) {
+	return f.setArchived(ctx, "ArchiveProject", projectID, true)
+}
+
+// UnarchiveProject restores an archived project and its sub-projects.
+func (f *Fake) UnarchiveProject(ctx context.Context, projectID string) (*api.Project, error) {
+	return f.setArchived(ctx, "UnarchiveProject", projectID, false)
+}
+
+// GetArchivedProjects returns all archived projects.
+func (f *Fake) GetArchivedProjects(ctx context.Context) ([]*api.Project, error) {
+	return f.IterArchivedProjects(ctx, api.ListOptions{}).All()
+}
+
+// IterArchivedProjects returns an iterator over archived projects.
+func (f *Fake) IterArchivedProjects(ctx context.Context, opts api.ListOptions) *api.Iterator[*api.Project] {
+	return pages(ctx, f, "GetArchivedProjects", opts, func() ([]*api.Project, error) {
+		return f.projectList(true), nil
+	})
+}
+
+// GetCollaborators returns the users a project is shared with.
+func (f *Fake) GetCollaborators(ctx context.Context, projectID string) ([]*api.Collaborator, error) {
+	return f.IterCollaborators(ctx, projectID, api.ListOptions{}).All()
+}
+
+// IterCollaborators returns an iterator over the users a project is shared with.
+func (f *Fake) IterCollaborators(ctx context.Context, projectID string, opts api.ListOptions) *api.Iterator[*api.Collaborator] {
+	return pages(ctx, f, "GetCollaborators", opts, func() ([]*api.Collaborator, error) {
+		if f.findProject(projectID) == nil {
+			return nil, notFound("project", projectID)
+		}
+		out := []*api.Collaborator{}
+		for _, c := range f.collabs[projectID] {
+			clone := *c
+			out = append(out, &clone)
+		}
+		return out, nil
+	})
+}
+
+// DeleteProject deletes a project with its sub-projects, sections and tasks.
+// The Inbox cannot be deleted.
+func (f *Fake) DeleteProject(ctx context.Context, projectID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("DeleteProject", projectID)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	p := f.findProject(projectID)
+	if p == nil {
+		return notFound("project", projectID)
+	}
+	if p.IsInboxProject {
+		return badRequest("the Inbox project cannot be deleted")
+	}
+
+	doomed := append(f.projectDescendants(projectID), projectID)
+	f.projects = slices.DeleteFunc(f.projects, func(p *api.Project) bool { return slices.Contains(doomed, p.ID) })
+	f.sections = slices.DeleteFunc(f.sections, func(s *api.Section) bool { return slices.Contains(doomed, s.ProjectID) })
+	f.tasks = slices.DeleteFunc(f.tasks, func(t *api.Task) bool { return slices.Contains(doomed, t.ProjectID) })
+	for _, id := range doomed {
+		delete(f.archived, id)
+		delete(f.collabs, id)
+	}
+	return nil
+}
+
+// --- Sections ---
+
+// GetSections returns all sections, optionally limited to one project.
+func (f *Fake) GetSections(ctx context.Context, projectID string) ([]*api.Section, error) {
+	return f.IterSections(ctx, projectID, api.ListOptions{}).All()
+}
+
+// IterSections returns an iterator over sections.
+func (f *Fake) IterSections(ctx context.Context, projectID string, opts api.ListOptions) *api.Iterator[*api.Section] {
+	return pages(ctx, f, "GetSections", opts, func() ([]*api.Section, error) {
+		out := []*api.Section{}
+		for _, s := range f.sections {
+			if projectID == "" || s.ProjectID == projectID {
+				clone := *s
+				out = append(out, &clone)
+			}
+		}
+		return out, nil
+	})
+}
+
+// CreateSection creates a section in a project.
+func (f *Fake) CreateSection(ctx context.Context, req *api.CreateSectionRequest) (*api.Section, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("CreateSection", req.Name)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, badRequest("name is required")
+	}
+	if f.findProject(req.ProjectID) == nil {
+		return nil, notFound("project", req.ProjectID)
+	}
+	s := &api.Section{ID: f.newID(), ProjectID: req.ProjectID, Name: req.Name, Order: req.Order}
+	f.sections = append(f.sections, s)
+	clone := *s
+	return &clone, nil
+}
+
+// UpdateSection renames a section.
+func (f *Fake) UpdateSection(ctx context.Context, sectionID string, req *api.UpdateSectionRequest) (*api.Section, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("UpdateSection", sectionID)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s := f.findSection(sectionID)
+	if s == nil {
+		return nil, notFound("section", sectionID)
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, badRequest("name is required")
+	}
+	s.Name = req.Name
+	clone := *s
+	return &clone, nil
+}
+
+// DeleteSection deletes a section and its tasks.
+func (f *Fake) DeleteSection(ctx context.Context, sectionID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("DeleteSection", sectionID)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if f.findSection(sectionID) == nil {
+		return notFound("section", sectionID)
+	}
+	f.sections = slices.DeleteFunc(f.sections, func(s *api.Section) bool { return s.ID == sectionID })
+	f.tasks = slices.DeleteFunc(f.tasks, func(t *api.Task) bool { return t.SectionID == sectionID })
+	return nil
+}
+
+// --- Labels ---
+
+// GetLabels returns all personal labels.
+func (f *Fake) GetLabels(ctx context.Context) ([]*api.Label, error) {
+	return f.IterLabels(ctx, api.ListOptions{}).All()
+}
+
+// IterLabels returns an iterator over personal labels.
+func (f *Fake) IterLabels(ctx context.Context, opts api.ListOptions) *api.Iterator[*api.Label] {
+	return pages(ctx, f, "GetLabels", opts, func() ([]*api.Label, error) {
+		out := []*api.Label{}
+		for _, l := range f.labels {
+			clone := *l
+			out = append(out, &clone)
+		}
+		return out, nil
+	})
+}
+
+// CreateLabel creates a personal label. Names must be unique.
+func (f *Fake) CreateLabel(ctx context.Context, req *api.CreateLabelRequest) (*api.Label, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("CreateLabel", req.Name)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, badRequest("name is required")
+	}
+	if f.findLabelByName(req.Name) != nil {
+		return nil, badRequest("label already exists")
+	}
+	if req.Color != "" && !api.IsValidColor(req.Color) {
+		return nil, badRequest("invalid color")
+	}
+	l := &api.Label{ID: f.newID(), Name: req.Name, Color: req.Color, Order: req.Order, IsFavorite: req.IsFavorite}
+	if l.Color == "" {
+		l.Color = "charcoal"
+	}
+	f.labels = append(f.labels, l)
+	clone := *l
+	return &clone, nil
+}
+
+// UpdateLabel applies the non-nil fields of req to a label. Renaming a
+// label renames it on every task, as the real API does.
+func (f *Fake) UpdateLabel(ctx context.Context, labelID string, req *api.UpdateLabelRequest) (*api.Label, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("UpdateLabel", labelID)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	l := f.findLabel(labelID)
+	if l == nil {
+		return nil, notFound("label", labelID)
+	}
+	if req.Name != nil && *req.Name != l.Name {
+		if f.findLabelByName(*req.Name) != nil {
+			return nil, badRequest("label already exists")
+		}
+		f.renameOnTasks(l.Name, *req.Name)
+		l.Name = *req.Name
+	}
+	if req.Color != nil {
+		if !api.IsValidColor(*req.Color) {
+			return nil, badRequest("invalid color")
+		}
+		l.Color = *req.Color
+	}
+	if req.Order != nil {
+		l.Order = *req.Order
+	}
+	if req.IsFavorite != nil {
+		l.IsFavorite = *req.IsFavorite
+	}
+	clone := *l
+	return &clone, nil
+}
+
+// DeleteLabel deletes a personal label and removes it from every task.
+func (f *Fake) DeleteLabel(ctx context.Context, labelID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("DeleteLabel", labelID)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l := f.findLabel(labelID)
+	if l == nil {
+		return notFound("label", labelID)
+	}
+	f.labels = slices.DeleteFunc(f.labels, func(x *api.Label) bool { return x.ID == labelID })
+	f.renameOnTasks(l.Name, "")
+	return nil
+}
+
+// GetSharedLabels returns every label name in use: labels on tasks,
+// seeded shared labels and, unless omitPersonal, personal labels.
+func (f *Fake) GetSharedLabels(ctx context.Context, omitPersonal bool) ([]string, error) {
+	return f.IterSharedLabels(ctx, omitPersonal, api.ListOptions{}).All()
+}
+
+// IterSharedLabels returns an iterator over label names in use.
+func (f *Fake) IterSharedLabels(ctx context.Context, omitPersonal bool, opts api.ListOptions) *api.Iterator[string] {
+	return pages(ctx, f, "GetSharedLabels", opts, func() ([]string, error) {
+		personal := map[string]bool{}
+		for _, l := range f.labels {
+			personal[l.Name] = true
+		}
+		var names []string
+		add := func(name string) {
+			if omitPersonal && personal[name] {
+				return
+			}
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+		for _, name := range f.shared {
+			add(name)
+		}
+		for _, t := range f.tasks {
+			for _, name := range t.Labels {
+				add(name)
+			}
+		}
+		if !omitPersonal {
+			for _, l := range f.labels {
+				add(l.Name)
+			}
+		}
+		slices.Sort(names)
+		return append([]string{}, names...), nil
+	})
+}
+
+// RenameSharedLabel renames a label on every task that carries it.
+func (f *Fake) RenameSharedLabel(ctx context.Context, name, newName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("RenameSharedLabel", name)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	f.renameOnTasks(name, newName)
+	for i, s := range f.shared {
+		if s == name {
+			f.shared[i] = newName
+		}
+	}
+	return nil
+}
+
+// RemoveSharedLabel removes a label from every task that carries it.
+func (f *Fake) RemoveSharedLabel(ctx context.Context, name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("RemoveSharedLabel", name)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	f.renameOnTasks(name, "")
+	f.shared = slices.DeleteFunc(f.shared, func(s string) bool { return s == name })
+	return nil
+}
+
+// --- Comments ---
+
+// GetComments returns the comments on a task or project, oldest first.
+func (f *Fake) GetComments(ctx context.Context, taskID, projectID string) ([]*api.Comment, error) {
+	return f.IterComments(ctx, taskID, projectID, api.ListOptions{}).All()
+}
+
+// IterComments returns an iterator over the comments on a task or project.
+func (f *Fake) IterComments(ctx context.Context, taskID, projectID string, opts api.ListOptions) *api.Iterator[*api.Comment] {
+	return pages(ctx, f, "GetComments", opts, func() ([]*api.Comment, error) {
+		if (taskID == "") == (projectID == "") {
+			return nil, badRequest("exactly one of task_id and project_id is required")
+		}
+		out := []*api.Comment{}
+		for _, c := range f.comments {
+			if (taskID != "" && c.TaskID == taskID) || (projectID != "" && c.ProjectID == projectID) {
+				clone := *c
+				out = append(out, &clone)
+			}
+		}
+		return out, nil
+	})
+}
+
+// CreateComment adds a comment to a task or project and bumps the task's
+// note count.
+func (f *Fake) CreateComment(ctx context.Context, req *api.CreateCommentRequest) (*api.Comment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("CreateComment", req.TaskID+req.ProjectID)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if (req.TaskID == "") == (req.ProjectID == "") {
+		return nil, badRequest("exactly one of task_id and project_id is required")
+	}
+	if strings.TrimSpace(req.Content) == "" && req.Attachment == nil {
+		return nil, badRequest("content is required")
+	}
+	if req.TaskID != "" {
+		t := f.findTask(req.TaskID)
+		if t == nil {
+			return nil, notFound("task", req.TaskID)
+		}
+		t.NoteCount++
+	} else if f.findProject(req.ProjectID) == nil {
+		return nil, notFound("project", req.ProjectID)
+	}
+
+	c := &api.Comment{
+		ID:         f.newID(),
+		TaskID:     req.TaskID,
+		ProjectID:  req.ProjectID,
+		Content:    req.Content,
+		PostedAt:   f.timestamp(),
+		Attachment: req.Attachment,
+	}
+	f.comments = append(f.comments, c)
+	clone := *c
+	return &clone, nil
+}
+
+// DeleteComment deletes a comment.
+func (f *Fake) DeleteComment(ctx context.Context, commentID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("DeleteComment", commentID)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	i := slices.IndexFunc(f.comments, func(c *api.Comment) bool { return c.ID == commentID })
+	if i < 0 {
+		return notFound("comment", commentID)
+	}
+	if t := f.findTask(f.comments[i].TaskID); t != nil && t.NoteCount > 0 {
+		t.NoteCount--
+	}
+	f.comments = slices.Delete(f.comments, i, i+1)
+	return nil
+}
+
+// UploadFile reads r and returns a completed attachment with a fake URL.
+func (f *Fake) UploadFile(ctx context.Context, fileName string, r io.Reader) (*api.Attachment, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("UploadFile", fileName)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return &api.Attachment{
+		FileName:     fileName,
+		FileSize:     int64(len(data)),
+		FileType:     http.DetectContentType(data),
+		FileURL:      "https://files.example.com/" + f.newID() + "/" + fileName,
+		ResourceType: "file",
+		UploadState:  "completed",
+	}, nil
+}
+
+// --- helpers (callers hold f.mu) ---
+
+func (f *Fake) newID() string {
+	f.nextID++
+	return strconv.Itoa(f.nextID)
+}
+
+func (f *Fake) timestamp() string {
+	return f.Now().UTC().Format("2006-01-02T15:04:05.000000Z")
+}
+
+func (f *Fake) record(method, arg string) {
+	f.calls = append(f.calls, strings.TrimSpace(method+" "+arg))
+}
+
+func (f *Fake) seedTask(t *api.Task) {
+	if t.ID == "" {
+		t.ID = f.newID()
+	}
+	if t.ProjectID == "" {
+		t.ProjectID = f.inboxID
+	}
+	if t.Priority == 0 {
+		t.Priority = 1
+	}
+	if t.Labels == nil {
+		t.Labels = []string{}
+	}
+	if t.CreatedAt == "" {
+		t.CreatedAt = f.timestamp()
+	}
+}
+
+func (f *Fake) findTask(id string) *api.Task {
+	for _, t := range f.tasks {
+		if t.ID == id {
+			return t
+		}
+	}
+	return nil
+}
+
+func (f *Fake) findCompleted(id string) *api.Task {
+	for _, t := range f.completed {
+		if t.ID == id {
+			return t
+		}
+	}
+	return nil
+}
+
+func (f *Fake) findProject(id string) *api.Project {
+	for _, p := range f.projects {
+		if p.ID == id {
+			return p
+		}
+	}
+	return nil
+}
+
+func (f *Fake) findSection(id string) *api.Section {
+	for _, s := range f.sections {
+		if s.ID == id {
+			return s
+		}
+	}
+	return nil
+}
+
+func (f *Fake) findLabel(id string) *api.Label {
+	for _, l := range f.labels {
+		if l.ID == id {
+			return l
+		}
+	}
+	return nil
+}
+
+func (f *Fake) findLabelByName(name string) *api.Label {
+	for _, l := range f.labels {
+		if strings.EqualFold(l.Name, name) {
+			return l
+		}
+	}
+	return nil
+}
+
+// descendants returns the IDs of every active subtask under taskID.
+func (f *Fake) descendants(taskID string) []string {
+	var ids []string
+	for _, t := range f.tasks {
+		if t.ParentID == taskID {
+			ids = append(ids, t.ID)
+			ids = append(ids, f.descendants(t.ID)...)
+		}
+	}
+	return ids
+}
+
+// projectDescendants returns the IDs of every sub-project under projectID.
+func (f *Fake) projectDescendants(projectID string) []string {
+	var ids []string
+	for _, p := range f.projects {
+		if p.ParentID == projectID {
+			ids = append(ids, p.ID)
+			ids = append(ids, f.projectDescendants(p.ID)...)
+		}
+	}
+	return ids
+}
+
+func (f *Fake) deleteTasks(ids []string) {
+	f.tasks = slices.DeleteFunc(f.tasks, func(t *api.Task) bool { return slices.Contains(ids, t.ID) })
+}
+
+func (f *Fake) projectList(archived bool) []*api.Project {
+	out := []*api.Project{}
+	for _, p := range f.projects {
+		if f.archived[p.ID] == archived {
+			clone := *p
+			out = append(out, &clone)
+		}
+	}
+	return out
+}
+
+func (f *Fake) setArchived(ctx context.Context, method, projectID string, archived bool) (*api.Project, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record(method, projectID)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	p := f.findProject(projectID)
+	if p == nil {
+		return nil, notFound("project", projectID)
+	}
+	if p.IsInboxProject {
+		return nil, badRequest("the Inbox project cannot be archived")
+	}
+	for _, id := range append(f.projectDescendants(projectID), projectID) {
+		f.archived[id] = archived
+	}
+	clone := *p
+	return &clone, nil
+}
+
+// renameOnTasks replaces label name with newName on every task, or removes
+// it when newName is empty.
+func (f *Fake) renameOnTasks(name, newName string) {
+	for _, t := range append(slices.Clone(f.tasks), f.completed...) {
+		for i := 0; i < len(t.Labels); i++ {
+			if t.Labels[i] != name {
+				continue
+			}
+			if newName == "" {
+				t.Labels = slices.Delete(t.Labels, i, i+1)
+				i--
+			} else {
+				t.Labels[i] = newName
+			}
+		}
+	}
+}
+
+// parseDue builds a due date the way the API would for the subset of
+// inputs the fake understands: ISO dates and datetimes, plus the due
+// strings "today", "tomorrow", "yesterday", "no date" and YYYY-MM-DD.
+func (f *Fake) parseDue(str, date, datetime, lang string) (*api.Due, error) {
+	switch {
+	case datetime != "":
+		t, err := time.Parse(time.RFC3339, datetime)
+		if err != nil {
+			return nil, badRequest("invalid due_datetime")
+		}
+		return &api.Due{String: datetime, Date: t.Format("2006-01-02"), Datetime: t.UTC().Format(time.RFC3339), Lang: lang}, nil
+	case date != "":
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return nil, badRequest("invalid due_date")
+		}
+		return &api.Due{String: date, Date: date, Lang: lang}, nil
+	case str != "":
+		today := f.Now()
+		var d time.Time
+		switch strings.ToLower(strings.TrimSpace(str)) {
+		case "no date", "no due date":
+			return nil, nil
+		case "today":
+			d = today
+		case "tomorrow":
+			d = today.AddDate(0, 0, 1)
+		case "yesterday":
+			d = today.AddDate(0, 0, -1)
+		default:
+			var err error
+			if d, err = time.Parse("2006-01-02", str); err != nil {
+				return nil, badRequest(fmt.Sprintf("the fake cannot parse due string %q", str))
+			}
+		}
+		return &api.Due{String: str, Date: d.Format("2006-01-02"), Lang: lang}, nil
+	}
+	return nil, nil
+}
+
+// pages serves the snapshot returned by list as an offset-cursor
+// paginated endpoint, the same shape the v1 API uses. list runs with f.mu
+// held.
+func pages[T any](ctx context.Context, f *Fake, method string, opts api.ListOptions, list func() ([]T, error)) *api.Iterator[T] {
+	fetch := func(ctx context.Context, cursor string, limit int) ([]T, string, error) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.record(method, cursor)
+		if err := ctx.Err(); err != nil {
+			return nil, "", err
+		}
+
+		all, err := list()
+		if err != nil {
+			return nil, "", err
+		}
+
+		offset := 0
+		if cursor != "" {
+			if offset, err = strconv.Atoi(cursor); err != nil || offset < 0 {
+				return nil, "", badRequest("invalid cursor")
+			}
+		}
+		if limit <= 0 {
+			limit = DefaultPageSize
+		}
+		limit = min(limit, api.MaxPageSize)
+
+		end := min(offset+limit, len(all))
+		if offset >= end {
+			return []T{}, "", nil
+		}
+		next := ""
+		if end < len(all) {
+			next = strconv.Itoa(end)
+		}
+		return all[offset:end], next, nil
+	}
+	return api.NewIterator(ctx, fetch, opts)
+}
+
+func notFound(kind, id string) error {
+	return &api.TodoistError{StatusCode: http.StatusNotFound, Message: fmt.Sprintf("%s %s not found", kind, id)}
+}
+
+func badRequest(msg string) error {
+	return &api.TodoistError{StatusCode: http.StatusBadRequest, Message: msg}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func copyTask(t *api.Task) *api.Task {
+	c := *t
+	c.Labels = slices.Clone(t.Labels)
+	if c.Labels == nil {
+		c.Labels = []string{}
+	}
+	if t.Due != nil {
+		d := *t.Due
+		c.Due = &d
+	}
+	if t.Deadline != nil {
+		d := *t.Deadline
+		c.Deadline = &d
+	}
+	if t.Duration != nil {
+		d := *t.Duration
+		c.Duration = &d
+	}
+	return &c
+}
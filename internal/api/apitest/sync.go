@@ -0,0 +1,201 @@
+package apitest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+// Sync runs the commands in order through the same code paths as the REST
+// methods, so Calls records them individually, then returns the requested
+// resources. The fake has no change log: every read is a full sync.
+func (f *Fake) Sync(ctx context.Context, req *api.SyncRequest) (*api.SyncResponse, error) {
+	f.mu.Lock()
+	f.record("Sync", strconv.Itoa(len(req.Commands)))
+	f.syncs++
+	token := "fake-sync-" + strconv.Itoa(f.syncs)
+	f.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(req.Commands) > api.MaxSyncCommands {
+		return nil, badRequest(fmt.Sprintf("too many commands: %d", len(req.Commands)))
+	}
+
+	resp := &api.SyncResponse{
+		SyncToken:     token,
+		SyncStatus:    map[string]*api.CommandStatus{},
+		TempIDMapping: map[string]string{},
+	}
+	for _, cmd := range req.Commands {
+		id, err := f.runCommand(ctx, cmd, resp.TempIDMapping)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			resp.SyncStatus[cmd.UUID] = commandStatus(err)
+			continue
+		}
+		resp.SyncStatus[cmd.UUID] = &api.CommandStatus{OK: true}
+		if cmd.TempID != "" && id != "" {
+			resp.TempIDMapping[cmd.TempID] = id
+		}
+	}
+
+	if len(req.ResourceTypes) == 0 {
+		return resp, nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	resp.FullSync = true
+	for _, typ := range req.ResourceTypes {
+		switch typ {
+		case api.ResourceItems:
+			resp.Items = []*api.Task{}
+			for _, t := range f.tasks {
+				resp.Items = append(resp.Items, copyTask(t))
+			}
+		case api.ResourceProjects:
+			resp.Projects = f.projectList(false)
+		case api.ResourceSections:
+			resp.Sections = []*api.Section{}
+			for _, s := range f.sections {
+				clone := *s
+				resp.Sections = append(resp.Sections, &clone)
+			}
+		case api.ResourceLabels:
+			resp.Labels = []*api.Label{}
+			for _, l := range f.labels {
+				clone := *l
+				resp.Labels = append(resp.Labels, &clone)
+			}
+		case api.ResourceUser:
+			user := f.User
+			resp.User = &user
+		case api.ResourceCollaborators:
+			// Someone sharing several projects is listed once.
+			resp.Collaborators = []*api.Collaborator{}
+			seen := map[string]bool{}
+			for _, p := range f.projects {
+				for _, c := range f.collabs[p.ID] {
+					if !seen[c.ID] {
+						seen[c.ID] = true
+						clone := *c
+						resp.Collaborators = append(resp.Collaborators, &clone)
+					}
+				}
+			}
+		default:
+			return nil, badRequest("unknown resource type: " + typ)
+		}
+	}
+	return resp, nil
+}
+
+// runCommand executes one sync command and returns the ID of any object it
+// created. IDs in args may be temp IDs from earlier commands in the batch.
+func (f *Fake) runCommand(ctx context.Context, cmd api.SyncCommand, tempIDs map[string]string) (string, error) {
+	args := map[string]any{}
+	for k, v := range cmd.Args {
+		if s, ok := v.(string); ok {
+			if real, ok := tempIDs[s]; ok {
+				v = real
+			}
+		}
+		args[k] = v
+	}
+	id, _ := args["id"].(string)
+
+	switch cmd.Type {
+	case api.CommandItemAdd:
+		fields, err := api.ParseTaskArgs(args)
+		if err != nil {
+			return "", badRequest(err.Error())
+		}
+		u := fields.Update
+		t, err := f.CreateTask(ctx, &api.CreateTaskRequest{
+			Content: deref(u.Content), Description: deref(u.Description),
+			ProjectID: fields.ProjectID, SectionID: fields.SectionID, ParentID: fields.ParentID, Order: fields.Order,
+			Labels: derefLabels(u.Labels), Priority: derefInt(u.Priority),
+			DueString: deref(u.DueString), DueDate: deref(u.DueDate), DueDatetime: deref(u.DueDatetime), DueLang: deref(u.DueLang),
+			AssigneeID: deref(u.AssigneeID), DeadlineDate: deref(u.DeadlineDate),
+		})
+		if err != nil {
+			return "", err
+		}
+		// The REST create has no duration; set it the way an update would.
+		if u.Duration != nil {
+			if _, err := f.UpdateTask(ctx, t.ID, &api.UpdateTaskRequest{Duration: u.Duration, DurationUnit: u.DurationUnit}); err != nil {
+				return "", err
+			}
+		}
+		return t.ID, nil
+	case api.CommandItemUpdate:
+		fields, err := api.ParseTaskArgs(args)
+		if err != nil {
+			return "", badRequest(err.Error())
+		}
+		if fields.ProjectID != "" || fields.SectionID != "" || fields.ParentID != "" {
+			return "", badRequest("item_update can't move a task; use item_move")
+		}
+		_, err = f.UpdateTask(ctx, id, &fields.Update)
+		return "", err
+	case api.CommandItemMove:
+		var req api.MoveTaskRequest
+		if err := decodeArgs(args, &req); err != nil {
+			return "", err
+		}
+		_, err := f.MoveTask(ctx, id, &req)
+		return "", err
+	case api.CommandItemClose:
+		return "", f.CloseTask(ctx, id)
+	case api.CommandItemUncomplete:
+		return "", f.ReopenTask(ctx, id)
+	case api.CommandItemDelete:
+		return "", f.DeleteTask(ctx, id)
+	default:
+		return "", badRequest("unknown command type: " + cmd.Type)
+	}
+}
+
+func derefLabels(l *[]string) []string {
+	if l == nil {
+		return nil
+	}
+	return *l
+}
+
+func derefInt(n *int) int {
+	if n == nil {
+		return 0
+	}
+	return *n
+}
+
+func decodeArgs(args map[string]any, v any) error {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return badRequest("invalid args")
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return badRequest("invalid args")
+	}
+	return nil
+}
+
+// commandStatus converts an error to the sync_status form the API uses.
+func commandStatus(err error) *api.CommandStatus {
+	st := &api.CommandStatus{Error: err.Error(), HTTPCode: http.StatusBadRequest}
+	var apiErr *api.TodoistError
+	if errors.As(err, &apiErr) {
+		st.Error = apiErr.Message
+		st.HTTPCode = apiErr.StatusCode
+	}
+	return st
+}
@@ -3,10 +3,16 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
+	"math/rand/v2"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -19,33 +25,136 @@ const (
 
 	// InitialBackoff is the initial backoff duration.
 	InitialBackoff = 1 * time.Second
+
+	// DefaultRateLimitBudget is the default cap on the total time a single
+	// request spends waiting out 429 responses before giving up.
+	DefaultRateLimitBudget = 60 * time.Second
+
+	// DefaultUserAgent is the User-Agent header sent with every request.
+	DefaultUserAgent = "Via-Todoist/0.1"
+
+	// DefaultTimeout is the per-request timeout of the default HTTP client.
+	DefaultTimeout = 30 * time.Second
 )
 
+// RetryPolicy controls how failed requests are retried. Network errors and
+// 5xx responses back off exponentially from InitialBackoff; 429 responses
+// wait as long as the server asks.
+type RetryPolicy struct {
+	MaxRetries     int
+	InitialBackoff time.Duration
+}
+
+// DefaultRetryPolicy is the retry policy used unless WithRetryPolicy is given.
+var DefaultRetryPolicy = RetryPolicy{MaxRetries: MaxRetries, InitialBackoff: InitialBackoff}
+
 // Client is the Todoist API client.
 type Client struct {
-	token      string
-	baseURL    string
-	httpClient *http.Client
+	token           string
+	baseURL         string
+	httpClient      *http.Client
+	userAgent       string
+	retry           RetryPolicy
+	logger          *slog.Logger
+	rateLimitBudget time.Duration
+	limiter         *rateLimiter
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithBaseURL points the client at a different API root, such as a local
+// stand-in server. A trailing slash is ignored.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) { c.baseURL = strings.TrimRight(baseURL, "/") }
+}
+
+// WithHTTPClient replaces the default HTTP client (30s timeout).
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) { c.userAgent = ua }
+}
+
+// WithRetryPolicy replaces the default retry policy. A MaxRetries of zero
+// disables retries.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) { c.retry = p }
+}
+
+// WithLogger logs each request, response and retry at debug level.
+func WithLogger(l *slog.Logger) Option {
+	return func(c *Client) { c.logger = l }
+}
+
+// WithRateLimit paces requests to at most perMinute per minute across every
+// goroutine using the client. Zero disables pacing.
+func WithRateLimit(perMinute int) Option {
+	return func(c *Client) { c.limiter = newRateLimiter(perMinute) }
+}
+
+// WithRateLimitBudget caps the total time one request may spend waiting out
+// 429 responses. When the server asks for a longer wait than the remaining
+// budget, the request fails immediately with a rate limit error.
+// A zero budget never waits.
+func WithRateLimitBudget(d time.Duration) Option {
+	return func(c *Client) { c.rateLimitBudget = nonNegative(d) }
 }
 
 // NewClient creates a new Todoist API client.
-func NewClient(token string) (*Client, error) {
+func NewClient(token string, opts ...Option) (*Client, error) {
 	if token == "" {
 		return nil, fmt.Errorf("access token is required")
 	}
 
-	return &Client{
+	c := &Client{
 		token:   token,
 		baseURL: BaseURL,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: DefaultTimeout,
 		},
-	}, nil
+		userAgent:       DefaultUserAgent,
+		retry:           DefaultRetryPolicy,
+		logger:          slog.New(slog.DiscardHandler),
+		rateLimitBudget: DefaultRateLimitBudget,
+		limiter:         newRateLimiter(DefaultRequestsPerMinute),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	u, err := url.Parse(c.baseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q", c.baseURL)
+	}
+	if c.httpClient == nil {
+		return nil, fmt.Errorf("HTTP client must not be nil")
+	}
+	if c.logger == nil {
+		c.logger = slog.New(slog.DiscardHandler)
+	}
+	if c.retry.MaxRetries < 0 {
+		c.retry.MaxRetries = 0
+	}
+	if c.retry.InitialBackoff <= 0 {
+		c.retry.InitialBackoff = InitialBackoff
+	}
+
+	return c, nil
+}
+
+// request performs a JSON HTTP request with retry logic and rate limit handling.
+func (c *Client) request(ctx context.Context, method, endpoint string, body io.Reader) ([]byte, int, error) {
+	return c.requestWithContentType(ctx, method, endpoint, "application/json", body)
 }
 
-// request performs an HTTP request with retry logic and rate limit handling.
+// requestWithContentType performs an HTTP request with retry logic and rate limit handling.
 // Body bytes are captured up front so the request can be retried safely.
-func (c *Client) request(method, endpoint string, body io.Reader) ([]byte, int, error) {
+// Cancelling ctx aborts both the in-flight request and any backoff sleep.
+func (c *Client) requestWithContentType(ctx context.Context, method, endpoint, contentType string, body io.Reader) ([]byte, int, error) {
 	// Read body once so we can replay it on retries.
 	var bodyBytes []byte
 	if body != nil {
@@ -57,47 +166,75 @@ func (c *Client) request(method, endpoint string, body io.Reader) ([]byte, int,
 	}
 
 	var lastErr error
-	backoff := InitialBackoff
+	backoff := c.retry.InitialBackoff
+	var wait time.Duration            // delay before the next attempt
+	var rateLimitWaited time.Duration // total time spent waiting out 429s
 
-	for attempt := 0; attempt <= MaxRetries; attempt++ {
+	for attempt := 0; attempt <= c.retry.MaxRetries; attempt++ {
 		if attempt > 0 {
-			time.Sleep(backoff)
-			backoff *= 2 // Exponential backoff: 1s, 2s, 4s
+			c.logger.Debug("retrying request", "method", method, "endpoint", endpoint, "attempt", attempt, "wait", wait, "error", lastErr)
+			if err := sleep(ctx, wait); err != nil {
+				return nil, 0, err
+			}
 		}
 
-		url := c.baseURL + endpoint
+		// Every attempt, retries included, counts against the request rate.
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, 0, err
+		}
 
 		var reqBody io.Reader
 		if bodyBytes != nil {
 			reqBody = bytes.NewReader(bodyBytes)
 		}
 
-		req, err := http.NewRequest(method, url, reqBody)
+		req, err := http.NewRequestWithContext(ctx, method, c.baseURL+endpoint, reqBody)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to create request: %w", err)
 		}
 
 		req.Header.Set("Authorization", "Bearer "+c.token)
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "Via-Todoist/0.1")
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("User-Agent", c.userAgent)
 
+		c.logger.Debug("sending request", "method", method, "endpoint", endpoint)
+		start := time.Now()
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
+			// A cancelled context is not a network error; don't retry it.
+			if ctx.Err() != nil {
+				return nil, 0, ctx.Err()
+			}
 			lastErr = fmt.Errorf("request failed: %w", err)
-			continue // Retry on network errors
+			wait, backoff = backoff, backoff*2 // Exponential backoff: 1s, 2s, 4s
+			continue                           // Retry on network errors
 		}
 
 		respBody, err := io.ReadAll(resp.Body)
 		resp.Body.Close()
+		c.logger.Debug("received response", "method", method, "endpoint", endpoint, "status", resp.StatusCode, "duration", time.Since(start))
 		if err != nil {
 			lastErr = fmt.Errorf("failed to read response: %w", err)
+			wait, backoff = backoff, backoff*2
 			continue
 		}
 
-		// Handle rate limiting (429) — retry after backoff
+		// Handle rate limiting (429) — wait as long as the server asks,
+		// unless that would blow the budget or we're out of retries.
 		if resp.StatusCode == http.StatusTooManyRequests {
-			lastErr = NewRateLimitError(60)
-			time.Sleep(60 * time.Second)
+			delay, ok := retryAfter(resp.Header, respBody, time.Now())
+			if !ok {
+				delay = jitter(backoff)
+			}
+			backoff *= 2
+
+			rateErr := NewRateLimitError(delay)
+			if attempt == c.retry.MaxRetries || rateLimitWaited+delay > c.rateLimitBudget {
+				return nil, resp.StatusCode, rateErr
+			}
+			rateLimitWaited += delay
+			lastErr = rateErr
+			wait = delay
 			continue
 		}
 
@@ -122,6 +259,7 @@ func (c *Client) request(method, endpoint string, body io.Reader) ([]byte, int,
 			// Retry server errors (5xx) with exponential backoff
 			if apiErr.IsServerError() {
 				lastErr = apiErr
+				wait, backoff = backoff, backoff*2
 				continue
 			}
 
@@ -133,9 +271,76 @@ func (c *Client) request(method, endpoint string, body io.Reader) ([]byte, int,
 	}
 
 	if lastErr != nil {
-		return nil, 0, fmt.Errorf("request failed after %d retries: %w", MaxRetries, lastErr)
+		return nil, 0, fmt.Errorf("request failed after %d retries: %w", c.retry.MaxRetries, lastErr)
+	}
+	return nil, 0, fmt.Errorf("request failed after %d retries", c.retry.MaxRetries)
+}
+
+// sleep waits for d or until ctx is done, whichever comes first.
+// It returns ctx.Err() if the wait was cut short.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryAfter reports how long a 429 response asks the client to wait.
+// It checks, in order: the Retry-After header (delta-seconds or HTTP-date),
+// the retry_after hint in the v1 error body, and X-RateLimit-Reset
+// (delta-seconds or a Unix timestamp). ok is false if none is present.
+func retryAfter(h http.Header, body []byte, now time.Time) (time.Duration, bool) {
+	if v := strings.TrimSpace(h.Get("Retry-After")); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+			return time.Duration(secs) * time.Second, true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return nonNegative(t.Sub(now)), true
+		}
+	}
+
+	var structured struct {
+		ErrorExtra struct {
+			RetryAfter *float64 `json:"retry_after"`
+		} `json:"error_extra"`
+	}
+	if err := json.Unmarshal(body, &structured); err == nil && structured.ErrorExtra.RetryAfter != nil {
+		return nonNegative(time.Duration(*structured.ErrorExtra.RetryAfter * float64(time.Second))), true
+	}
+
+	if v := strings.TrimSpace(h.Get("X-RateLimit-Reset")); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil && n >= 0 {
+			// Large values are epoch timestamps rather than a delta.
+			if n > 1_000_000_000 {
+				return nonNegative(time.Unix(n, 0).Sub(now)), true
+			}
+			return time.Duration(n) * time.Second, true
+		}
+	}
+
+	return 0, false
+}
+
+// jitter returns a random duration in [d/2, d) so concurrent clients
+// backing off from the same 429 don't retry in lockstep.
+func jitter(d time.Duration) time.Duration {
+	half := d / 2
+	if half <= 0 {
+		return d
+	}
+	return half + rand.N(half)
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
 	}
-	return nil, 0, fmt.Errorf("request failed after %d retries", MaxRetries)
+	return d
 }
 
 // parseErrorBody extracts a message from a Todoist error response.
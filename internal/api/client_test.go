@@ -0,0 +1,178 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRequest_cancelDuringBackoff(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+	c := newTestClient(t, srv.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, _, err := c.request(ctx, http.MethodGet, "/tasks", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("request() error = %v, want context.DeadlineExceeded", err)
+	}
+	// The first backoff is a full second; cancellation must cut it short.
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("request() took %v after cancellation, want it to return promptly", elapsed)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		body    string
+		want    time.Duration
+		wantOK  bool
+	}{
+		{"seconds", map[string]string{"Retry-After": "7"}, "", 7 * time.Second, true},
+		{"http date", map[string]string{"Retry-After": now.Add(30 * time.Second).Format(http.TimeFormat)}, "", 30 * time.Second, true},
+		{"date in past", map[string]string{"Retry-After": now.Add(-time.Minute).Format(http.TimeFormat)}, "", 0, true},
+		{"error body", nil, `{"error":"Too many requests","error_extra":{"retry_after":12}}`, 12 * time.Second, true},
+		{"header beats body", map[string]string{"Retry-After": "3"}, `{"error_extra":{"retry_after":12}}`, 3 * time.Second, true},
+		{"reset delta", map[string]string{"X-RateLimit-Reset": "20"}, "", 20 * time.Second, true},
+		{"reset epoch", map[string]string{"X-RateLimit-Reset": strconv.FormatInt(now.Add(45*time.Second).Unix(), 10)}, "", 45 * time.Second, true},
+		{"garbage header", map[string]string{"Retry-After": "soon"}, "", 0, false},
+		{"none", nil, `"Too many requests"`, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			for k, v := range tt.headers {
+				h.Set(k, v)
+			}
+			got, ok := retryAfter(h, []byte(tt.body), now)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("retryAfter() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRequest_rateLimitBeyondBudgetFailsFast(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(srv.Close)
+	c := newTestClient(t, srv.URL)
+
+	start := time.Now()
+	_, _, err := c.request(context.Background(), http.MethodGet, "/tasks", nil)
+	if !IsRateLimitError(err) {
+		t.Fatalf("request() error = %v, want rate limit error", err)
+	}
+	var apiErr *TodoistError
+	if !errors.As(err, &apiErr) || apiErr.RetryAfter != 120*time.Second {
+		t.Errorf("request() error RetryAfter = %v, want 2m0s", apiErr)
+	}
+	if calls != 1 {
+		t.Errorf("server called %d times, want 1", calls)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("request() took %v, want it to fail fast", elapsed)
+	}
+}
+
+func TestRequest_rateLimitRetriesAfterHint(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(srv.Close)
+	c := newTestClient(t, srv.URL)
+
+	body, status, err := c.request(context.Background(), http.MethodGet, "/tasks", nil)
+	if err != nil {
+		t.Fatalf("request() error = %v", err)
+	}
+	if status != http.StatusOK || string(body) != `{"ok":true}` {
+		t.Errorf("request() = %d %q, want 200 {\"ok\":true}", status, body)
+	}
+	if calls != 2 {
+		t.Errorf("server called %d times, want 2", calls)
+	}
+}
+
+func TestNewClient_options(t *testing.T) {
+	var gotUA string
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		gotUA = r.Header.Get("User-Agent")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+
+	var logs bytes.Buffer
+	c, err := NewClient("test-token",
+		WithBaseURL(srv.URL+"/"),
+		WithHTTPClient(srv.Client()),
+		WithUserAgent("my-tool/2.0"),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 1, InitialBackoff: time.Millisecond}),
+		WithLogger(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	_, _, err = c.request(context.Background(), http.MethodGet, "/tasks", nil)
+	var apiErr *TodoistError
+	if !errors.As(err, &apiErr) || !apiErr.IsServerError() {
+		t.Fatalf("request() error = %v, want server error", err)
+	}
+	if calls != 2 {
+		t.Errorf("server called %d times, want 2 (1 retry)", calls)
+	}
+	if gotUA != "my-tool/2.0" {
+		t.Errorf("User-Agent = %q, want my-tool/2.0", gotUA)
+	}
+	if !strings.Contains(logs.String(), "retrying request") {
+		t.Errorf("logger output missing retry:\n%s", logs.String())
+	}
+}
+
+func TestNewClient_invalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  Option
+	}{
+		{"relative base URL", WithBaseURL("localhost:8080")},
+		{"nil HTTP client", WithHTTPClient(nil)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewClient("test-token", tt.opt); err == nil {
+				t.Error("NewClient() error = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+)
+
+// GetComments returns all comments on a task or project. Pass exactly one of
+// taskID and projectID. Every page is fetched before returning.
+func (c *Client) GetComments(ctx context.Context, taskID, projectID string) ([]*Comment, error) {
+	return c.IterComments(ctx, taskID, projectID, ListOptions{}).All()
+}
+
+// IterComments returns an iterator over the comments on a task or project.
+// Pass exactly one of taskID and projectID.
+func (c *Client) IterComments(ctx context.Context, taskID, projectID string, opts ListOptions) *Iterator[*Comment] {
+	params := url.Values{}
+	if taskID != "" {
+		params.Set("task_id", taskID)
+	}
+	if projectID != "" {
+		params.Set("project_id", projectID)
+	}
+	return newIterator[*Comment](ctx, c, "/comments", params, opts, "comments")
+}
+
+// CreateComment adds a comment to a task or project.
+func (c *Client) CreateComment(ctx context.Context, req *CreateCommentRequest) (*Comment, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	body, _, err := c.request(ctx, http.MethodPost, "/comments", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	var comment Comment
+	if err := json.Unmarshal(body, &comment); err != nil {
+		return nil, fmt.Errorf("failed to parse comment: %w", err)
+	}
+
+	return &comment, nil
+}
+
+// DeleteComment permanently deletes a comment. Returns 204 No Content on success.
+func (c *Client) DeleteComment(ctx context.Context, commentID string) error {
+	_, statusCode, err := c.request(ctx, http.MethodDelete, "/comments/"+commentID, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+	if statusCode != http.StatusNoContent {
+		return fmt.Errorf("unexpected status code: %d", statusCode)
+	}
+	return nil
+}
+
+// UploadFile uploads a file through the uploads endpoint and returns the
+// attachment to pass in CreateCommentRequest.
+func (c *Client) UploadFile(ctx context.Context, fileName string, r io.Reader) (*Attachment, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	if err := mw.WriteField("file_name", fileName); err != nil {
+		return nil, fmt.Errorf("failed to build upload: %w", err)
+	}
+	part, err := mw.CreateFormFile("file", fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build upload: %w", err)
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to build upload: %w", err)
+	}
+
+	body, _, err := c.requestWithContentType(ctx, http.MethodPost, "/uploads", mw.FormDataContentType(), &buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	var attachment Attachment
+	if err := json.Unmarshal(body, &attachment); err != nil {
+		return nil, fmt.Errorf("failed to parse upload: %w", err)
+	}
+
+	return &attachment, nil
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUploadFile_attachesToComment(t *testing.T) {
+	var posted CreateCommentRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/uploads":
+			mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if err != nil || mediaType != "multipart/form-data" {
+				t.Errorf("upload Content-Type = %q, want multipart/form-data", r.Header.Get("Content-Type"))
+			}
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Fatalf("ParseMultipartForm() error = %v", err)
+			}
+			if got := r.FormValue("file_name"); got != "notes.txt" {
+				t.Errorf("file_name field = %q, want notes.txt", got)
+			}
+			file, header, err := r.FormFile("file")
+			if err != nil {
+				t.Fatalf(`no "file" part: %v`, err)
+			}
+			defer file.Close()
+			if header.Filename != "notes.txt" {
+				t.Errorf("part file name = %q, want notes.txt", header.Filename)
+			}
+			if ct := header.Header.Get("Content-Type"); ct != "application/octet-stream" {
+				t.Errorf("part Content-Type = %q, want application/octet-stream", ct)
+			}
+			data, _ := io.ReadAll(file)
+			if string(data) != "buy milk" {
+				t.Errorf("part body = %q, want the file contents", data)
+			}
+			json.NewEncoder(w).Encode(Attachment{
+				FileName: header.Filename, FileSize: int64(len(data)), FileType: "text/plain",
+				FileURL: "https://files.example.com/1/notes.txt", ResourceType: "file", UploadState: "completed",
+			})
+		case "/comments":
+			if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+				t.Errorf("comment Content-Type = %q, want application/json", ct)
+			}
+			if err := json.NewDecoder(r.Body).Decode(&posted); err != nil {
+				t.Fatalf("invalid comment body: %v", err)
+			}
+			json.NewEncoder(w).Encode(Comment{ID: "c1", TaskID: posted.TaskID, Content: posted.Content, Attachment: posted.Attachment})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	c := newTestClient(t, srv.URL)
+	ctx := context.Background()
+
+	att, err := c.UploadFile(ctx, "notes.txt", strings.NewReader("buy milk"))
+	if err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+	if att.FileURL != "https://files.example.com/1/notes.txt" || att.FileSize != 8 || att.UploadState != "completed" {
+		t.Errorf("UploadFile() = %+v", att)
+	}
+
+	comment, err := c.CreateComment(ctx, &CreateCommentRequest{TaskID: "42", Content: "see file", Attachment: att})
+	if err != nil {
+		t.Fatalf("CreateComment() error = %v", err)
+	}
+	if posted.Attachment == nil || *posted.Attachment != *att {
+		t.Errorf("posted attachment = %+v, want the upload result %+v", posted.Attachment, att)
+	}
+	if comment.Attachment == nil || comment.Attachment.FileName != "notes.txt" {
+		t.Errorf("CreateComment() = %+v, want the attachment", comment)
+	}
+}
+
+func TestUploadFile_error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"file too large"}`, http.StatusRequestEntityTooLarge)
+	}))
+	t.Cleanup(srv.Close)
+	c, err := NewClient("test-token", WithBaseURL(srv.URL), WithRetryPolicy(RetryPolicy{MaxRetries: 0}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.UploadFile(context.Background(), "big.bin", strings.NewReader("x")); err == nil ||
+		!strings.Contains(err.Error(), "failed to upload file") {
+		t.Errorf("UploadFile() error = %v, want an upload failure", err)
+	}
+}
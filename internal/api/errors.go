@@ -4,12 +4,16 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 // TodoistError represents an error from the Todoist API.
 type TodoistError struct {
 	Message    string
 	StatusCode int
+
+	// RetryAfter is how long the server asked us to wait (rate limit errors only).
+	RetryAfter time.Duration
 }
 
 func (e *TodoistError) Error() string {
@@ -102,10 +106,11 @@ func NewAuthError() *TodoistError {
 	}
 }
 
-// NewRateLimitError creates a new rate limit error.
-func NewRateLimitError(retryAfter int) *TodoistError {
+// NewRateLimitError creates a new rate limit error carrying the wait the server asked for.
+func NewRateLimitError(retryAfter time.Duration) *TodoistError {
 	return &TodoistError{
-		Message:    fmt.Sprintf("Rate limit exceeded. Retry after %d seconds", retryAfter),
+		Message:    fmt.Sprintf("Rate limit exceeded. Retry after %s", retryAfter.Round(time.Second)),
 		StatusCode: http.StatusTooManyRequests,
+		RetryAfter: retryAfter,
 	}
 }
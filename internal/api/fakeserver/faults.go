@@ -0,0 +1,113 @@
+package fakeserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Response is a canned HTTP response used to inject faults.
+type Response struct {
+	Status int
+	Header http.Header
+	Body   string
+}
+
+func (r Response) write(w http.ResponseWriter) {
+	for k, vs := range r.Header {
+		for _, v := range vs {
+			w.Header().Add(k, v)
+		}
+	}
+	w.WriteHeader(r.Status)
+	io.WriteString(w, r.Body)
+}
+
+// RateLimited returns a 429 with a Retry-After header in whole seconds.
+func RateLimited(retryAfter time.Duration) Response {
+	return Response{
+		Status: http.StatusTooManyRequests,
+		Header: http.Header{"Retry-After": {strconv.Itoa(int(retryAfter / time.Second))}},
+		Body:   `{"error":"Too many requests"}`,
+	}
+}
+
+// RateLimitedBody returns a 429 that carries its wait only in the v1 error
+// body, as {"error_extra": {"retry_after": N}}.
+func RateLimitedBody(retryAfter time.Duration) Response {
+	body, _ := json.Marshal(map[string]any{
+		"error":       "Too many requests",
+		"error_code":  429,
+		"error_extra": map[string]any{"retry_after": retryAfter.Seconds()},
+	})
+	return Response{Status: http.StatusTooManyRequests, Body: string(body)}
+}
+
+// ServerError returns a 5xx with a JSON error body.
+func ServerError(status int) Response {
+	return ErrorJSON(status, http.StatusText(status))
+}
+
+// ErrorJSON returns an error response with body {"error": msg}.
+func ErrorJSON(status int, msg string) Response {
+	body, _ := json.Marshal(map[string]string{"error": msg})
+	return Response{Status: status, Header: http.Header{"Content-Type": {"application/json"}}, Body: string(body)}
+}
+
+// ErrorString returns an error response whose body is a bare JSON string.
+func ErrorString(status int, msg string) Response {
+	body, _ := json.Marshal(msg)
+	return Response{Status: status, Header: http.Header{"Content-Type": {"application/json"}}, Body: string(body)}
+}
+
+// ErrorText returns an error response with a plain text body.
+func ErrorText(status int, msg string) Response {
+	return Response{Status: status, Header: http.Header{"Content-Type": {"text/plain"}}, Body: msg}
+}
+
+// ErrorEmpty returns an error response with no body.
+func ErrorEmpty(status int) Response {
+	return Response{Status: status}
+}
+
+type fault struct {
+	method string
+	path   string
+	resp   Response
+}
+
+// Fail queues responses for the next requests matching method and path,
+// served in order before any normal handling (including the token check).
+// An empty method or path matches anything.
+func (s *Server) Fail(method, path string, responses ...Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range responses {
+		s.faults = append(s.faults, fault{method: method, path: path, resp: r})
+	}
+}
+
+// nextFault pops the first queued fault matching r. Callers hold s.mu.
+func (s *Server) nextFault(r *http.Request) (fault, bool) {
+	for i, f := range s.faults {
+		if (f.method == "" || f.method == r.Method) && (f.path == "" || f.path == r.URL.Path) {
+			s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			return f, true
+		}
+	}
+	return fault{}, false
+}
+
+// readBody reads and restores r.Body so handlers can still decode it.
+func readBody(r *http.Request) []byte {
+	if r.Body == nil {
+		return nil
+	}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body
+}
@@ -0,0 +1,261 @@
+package fakeserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+// --- tasks ---
+
+func (s *Server) listTasks(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	tasks, err := s.Fake.GetTasks(r.Context(), q.Get("filter"), q.Get("project_id"), q.Get("section_id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writePage(w, r, tasks)
+}
+
+func (s *Server) createTask(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateTaskRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	task, err := s.Fake.CreateTask(r.Context(), &req)
+	writeResult(w, task, err)
+}
+
+func (s *Server) completedTasks(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	resp, err := s.Fake.GetCompletedTasks(r.Context(), q.Get("project_id"), q.Get("since"), 0)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	page, next, ok := pageOf(w, r, resp.Items)
+	if !ok {
+		return
+	}
+	projects := map[string]api.Project{}
+	for _, t := range page {
+		if p, ok := resp.Projects[t.ProjectID]; ok {
+			projects[p.ID] = p
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"items": page, "projects": projects, "next_cursor": next})
+}
+
+func (s *Server) getTask(w http.ResponseWriter, r *http.Request) {
+	task, err := s.Fake.GetTask(r.Context(), r.PathValue("id"))
+	writeResult(w, task, err)
+}
+
+func (s *Server) updateTask(w http.ResponseWriter, r *http.Request) {
+	var req api.UpdateTaskRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	task, err := s.Fake.UpdateTask(r.Context(), r.PathValue("id"), &req)
+	writeResult(w, task, err)
+}
+
+func (s *Server) deleteTask(w http.ResponseWriter, r *http.Request) {
+	writeNoContent(w, s.Fake.DeleteTask(r.Context(), r.PathValue("id")))
+}
+
+func (s *Server) closeTask(w http.ResponseWriter, r *http.Request) {
+	writeNoContent(w, s.Fake.CloseTask(r.Context(), r.PathValue("id")))
+}
+
+func (s *Server) reopenTask(w http.ResponseWriter, r *http.Request) {
+	writeNoContent(w, s.Fake.ReopenTask(r.Context(), r.PathValue("id")))
+}
+
+func (s *Server) moveTask(w http.ResponseWriter, r *http.Request) {
+	var req api.MoveTaskRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	task, err := s.Fake.MoveTask(r.Context(), r.PathValue("id"), &req)
+	writeResult(w, task, err)
+}
+
+// --- projects ---
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request) {
+	projects, err := s.Fake.GetProjects(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writePage(w, r, projects)
+}
+
+func (s *Server) listArchivedProjects(w http.ResponseWriter, r *http.Request) {
+	projects, err := s.Fake.GetArchivedProjects(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writePage(w, r, projects)
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateProjectRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	project, err := s.Fake.CreateProject(r.Context(), &req)
+	writeResult(w, project, err)
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request) {
+	var req api.UpdateProjectRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	project, err := s.Fake.UpdateProject(r.Context(), r.PathValue("id"), &req)
+	writeResult(w, project, err)
+}
+
+func (s *Server) deleteProject(w http.ResponseWriter, r *http.Request) {
+	writeNoContent(w, s.Fake.DeleteProject(r.Context(), r.PathValue("id")))
+}
+
+func (s *Server) archiveProject(w http.ResponseWriter, r *http.Request) {
+	project, err := s.Fake.ArchiveProject(r.Context(), r.PathValue("id"))
+	writeResult(w, project, err)
+}
+
+func (s *Server) unarchiveProject(w http.ResponseWriter, r *http.Request) {
+	project, err := s.Fake.UnarchiveProject(r.Context(), r.PathValue("id"))
+	writeResult(w, project, err)
+}
+
+func (s *Server) listCollaborators(w http.ResponseWriter, r *http.Request) {
+	collaborators, err := s.Fake.GetCollaborators(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writePage(w, r, collaborators)
+}
+
+// --- sections ---
+
+func (s *Server) listSections(w http.ResponseWriter, r *http.Request) {
+	sections, err := s.Fake.GetSections(r.Context(), r.URL.Query().Get("project_id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writePage(w, r, sections)
+}
+
+func (s *Server) createSection(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateSectionRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	section, err := s.Fake.CreateSection(r.Context(), &req)
+	writeResult(w, section, err)
+}
+
+func (s *Server) updateSection(w http.ResponseWriter, r *http.Request) {
+	var req api.UpdateSectionRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	section, err := s.Fake.UpdateSection(r.Context(), r.PathValue("id"), &req)
+	writeResult(w, section, err)
+}
+
+func (s *Server) deleteSection(w http.ResponseWriter, r *http.Request) {
+	writeNoContent(w, s.Fake.DeleteSection(r.Context(), r.PathValue("id")))
+}
+
+// --- labels ---
+
+func (s *Server) listLabels(w http.ResponseWriter, r *http.Request) {
+	labels, err := s.Fake.GetLabels(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writePage(w, r, labels)
+}
+
+func (s *Server) createLabel(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateLabelRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	label, err := s.Fake.CreateLabel(r.Context(), &req)
+	writeResult(w, label, err)
+}
+
+func (s *Server) updateLabel(w http.ResponseWriter, r *http.Request) {
+	var req api.UpdateLabelRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	label, err := s.Fake.UpdateLabel(r.Context(), r.PathValue("id"), &req)
+	writeResult(w, label, err)
+}
+
+func (s *Server) deleteLabel(w http.ResponseWriter, r *http.Request) {
+	writeNoContent(w, s.Fake.DeleteLabel(r.Context(), r.PathValue("id")))
+}
+
+func (s *Server) listSharedLabels(w http.ResponseWriter, r *http.Request) {
+	names, err := s.Fake.GetSharedLabels(r.Context(), r.URL.Query().Get("omit_personal") == "true")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writePage(w, r, names)
+}
+
+func (s *Server) renameSharedLabel(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name    string `json:"name"`
+		NewName string `json:"new_name"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	writeNoContent(w, s.Fake.RenameSharedLabel(r.Context(), req.Name, req.NewName))
+}
+
+func (s *Server) removeSharedLabel(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name string `json:"name"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	writeNoContent(w, s.Fake.RemoveSharedLabel(r.Context(), req.Name))
+}
+
+// --- sync ---
+
+func (s *Server) sync(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		ErrorJSON(http.StatusBadRequest, "invalid form body").write(w)
+		return
+	}
+	req := api.SyncRequest{SyncToken: r.PostForm.Get("sync_token")}
+	for field, v := range map[string]any{"resource_types": &req.ResourceTypes, "commands": &req.Commands} {
+		if raw := r.PostForm.Get(field); raw != "" {
+			if err := json.Unmarshal([]byte(raw), v); err != nil {
+				ErrorJSON(http.StatusBadRequest, "invalid "+field).write(w)
+				return
+			}
+		}
+	}
+	resp, err := s.Fake.Sync(r.Context(), &req)
+	writeResult(w, resp, err)
+}
@@ -0,0 +1,70 @@
+package fakeserver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+func TestRecordReplay(t *testing.T) {
+	fixture := filepath.Join(t.TempDir(), "labels.json")
+
+	// Record against a stateful server standing in for the real API.
+	t.Run("record", func(t *testing.T) {
+		upstream := New(t)
+		upstream.Fake.AddLabel(api.Label{Name: "home"})
+		upstream.Fake.AddLabel(api.Label{Name: "work"})
+		upstream.Fake.AddLabel(api.Label{Name: "errand"})
+
+		rec := Record(t, upstream.URL(), Token, fixture)
+		labels, err := rec.Client(t).IterLabels(context.Background(), api.ListOptions{PageSize: 2}).All()
+		if err != nil || len(labels) != 3 {
+			t.Fatalf("IterLabels() = %d labels, %v, want 3", len(labels), err)
+		}
+	})
+
+	data, err := os.ReadFile(fixture)
+	if err != nil {
+		t.Fatalf("fixture not written: %v", err)
+	}
+	if strings.Contains(string(data), Token) {
+		t.Error("fixture contains the access token")
+	}
+
+	t.Run("replay", func(t *testing.T) {
+		srv := Replay(t, fixture)
+		labels, err := srv.Client(t).IterLabels(context.Background(), api.ListOptions{PageSize: 2}).All()
+		if err != nil {
+			t.Fatalf("IterLabels() error = %v", err)
+		}
+		var names []string
+		for _, l := range labels {
+			names = append(names, l.Name)
+		}
+		if got := strings.Join(names, ","); got != "home,work,errand" {
+			t.Errorf("replayed labels = %s, want home,work,errand", got)
+		}
+	})
+}
+
+func TestReplay_fixture(t *testing.T) {
+	srv := RecordOrReplay(t, filepath.Join("testdata", "projects.json"))
+	c := srv.Client(t)
+
+	projects, err := c.GetProjects(context.Background())
+	if err != nil {
+		t.Fatalf("GetProjects() error = %v", err)
+	}
+	if len(projects) != 2 || !projects[0].IsInboxProject {
+		t.Fatalf("GetProjects() = %+v, want Inbox and one more", projects)
+	}
+
+	_, err = c.GetTask(context.Background(), "0")
+	if !api.IsNotFoundError(err) {
+		t.Errorf("GetTask() error = %v, want recorded 404", err)
+	}
+}
@@ -0,0 +1,248 @@
+// Package fakeserver runs a stand-in for the Todoist v1 REST API on an
+// httptest.Server. Point a client at it with api.WithBaseURL(s.URL()).
+//
+// The server keeps its state in an apitest.Fake, serves the task, completed
+// task, project, section and label endpoints with cursor pagination as well
+// as /sync, and can be told to answer the next requests with 429s, 5xx
+// errors or any of the error body shapes the client understands. Record and
+// Replay capture real traffic to fixture files and serve it back without a
+// network.
+package fakeserver
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/api/apitest"
+)
+
+// Token is the access token the server accepts. Requests carrying any
+// other bearer token get a 401.
+const Token = "fakeserver-token"
+
+// Server is a running stand-in API.
+type Server struct {
+	// Fake holds the account state. Seed it before making requests.
+	Fake *apitest.Fake
+
+	srv *httptest.Server
+
+	mu       sync.Mutex
+	faults   []fault
+	requests []Request
+}
+
+// Request is a request the server received.
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Body   string
+}
+
+// New starts a server backed by an empty apitest.Fake. It is closed when
+// the test finishes.
+func New(tb testing.TB) *Server {
+	tb.Helper()
+	s := &Server{Fake: apitest.New()}
+	s.srv = httptest.NewServer(s.routes())
+	tb.Cleanup(s.srv.Close)
+	return s
+}
+
+// URL returns the base URL to pass to api.WithBaseURL.
+func (s *Server) URL() string {
+	return s.srv.URL
+}
+
+// Client returns an api.Client pointed at the server, authenticated with
+// Token. Extra options are applied after the base URL.
+func (s *Server) Client(tb testing.TB, opts ...api.Option) *api.Client {
+	tb.Helper()
+	c, err := api.NewClient(Token, append([]api.Option{api.WithBaseURL(s.URL())}, opts...)...)
+	if err != nil {
+		tb.Fatalf("api.NewClient() error = %v", err)
+	}
+	return c
+}
+
+// Requests returns every request received so far, in order, including
+// ones answered by an injected fault.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /tasks", s.listTasks)
+	mux.HandleFunc("POST /tasks", s.createTask)
+	mux.HandleFunc("GET /tasks/completed", s.completedTasks)
+	mux.HandleFunc("GET /tasks/{id}", s.getTask)
+	mux.HandleFunc("POST /tasks/{id}", s.updateTask)
+	mux.HandleFunc("DELETE /tasks/{id}", s.deleteTask)
+	mux.HandleFunc("POST /tasks/{id}/close", s.closeTask)
+	mux.HandleFunc("POST /tasks/{id}/reopen", s.reopenTask)
+	mux.HandleFunc("POST /tasks/{id}/move", s.moveTask)
+
+	mux.HandleFunc("GET /projects", s.listProjects)
+	mux.HandleFunc("POST /projects", s.createProject)
+	mux.HandleFunc("GET /projects/archived", s.listArchivedProjects)
+	mux.HandleFunc("POST /projects/{id}", s.updateProject)
+	mux.HandleFunc("DELETE /projects/{id}", s.deleteProject)
+	mux.HandleFunc("POST /projects/{id}/archive", s.archiveProject)
+	mux.HandleFunc("POST /projects/{id}/unarchive", s.unarchiveProject)
+	mux.HandleFunc("GET /projects/{id}/collaborators", s.listCollaborators)
+
+	mux.HandleFunc("GET /sections", s.listSections)
+	mux.HandleFunc("POST /sections", s.createSection)
+	mux.HandleFunc("POST /sections/{id}", s.updateSection)
+	mux.HandleFunc("DELETE /sections/{id}", s.deleteSection)
+
+	mux.HandleFunc("GET /labels", s.listLabels)
+	mux.HandleFunc("POST /labels", s.createLabel)
+	mux.HandleFunc("GET /labels/shared", s.listSharedLabels)
+	mux.HandleFunc("POST /labels/shared/rename", s.renameSharedLabel)
+	mux.HandleFunc("POST /labels/shared/remove", s.removeSharedLabel)
+	mux.HandleFunc("POST /labels/{id}", s.updateLabel)
+	mux.HandleFunc("DELETE /labels/{id}", s.deleteLabel)
+
+	mux.HandleFunc("POST /sync", s.sync)
+
+	return s.middleware(mux)
+}
+
+// middleware logs the request, serves any queued fault, and checks the
+// bearer token before handing off to the route.
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return s.logging(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+Token {
+			ErrorJSON(http.StatusUnauthorized, "Unauthorized").write(w)
+			return
+		}
+		next.ServeHTTP(w, r)
+	}))
+}
+
+// --- pagination ---
+
+// DefaultPageSize is the page size used when a request has no limit.
+const DefaultPageSize = 50
+
+// writePage writes one page of items as {"results": [...], "next_cursor": ...},
+// honoring the limit and cursor query parameters. Cursors are opaque.
+func writePage[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	page, next, ok := pageOf(w, r, items)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"results": page, "next_cursor": next})
+}
+
+// pageOf returns the page of items the limit and cursor query parameters
+// ask for and the cursor of the next page, nil at the end. It writes a 400
+// and returns false when either parameter is invalid.
+func pageOf[T any](w http.ResponseWriter, r *http.Request, items []T) ([]T, *string, bool) {
+	limit := DefaultPageSize
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > api.MaxPageSize {
+			ErrorJSON(http.StatusBadRequest, "limit must be between 1 and "+strconv.Itoa(api.MaxPageSize)).write(w)
+			return nil, nil, false
+		}
+		limit = n
+	}
+
+	offset := 0
+	if v := r.URL.Query().Get("cursor"); v != "" {
+		n, ok := decodeCursor(v)
+		if !ok {
+			ErrorJSON(http.StatusBadRequest, "invalid cursor").write(w)
+			return nil, nil, false
+		}
+		offset = n
+	}
+
+	end := min(offset+limit, len(items))
+	page := []T{}
+	if offset < end {
+		page = items[offset:end]
+	}
+	var next *string
+	if end < len(items) {
+		c := encodeCursor(end)
+		next = &c
+	}
+	return page, next, true
+}
+
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("offset:" + strconv.Itoa(offset)))
+}
+
+func decodeCursor(c string) (int, bool) {
+	raw, err := base64.RawURLEncoding.DecodeString(c)
+	if err != nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(string(raw), "offset:"))
+	if err != nil || n < 0 || !strings.HasPrefix(string(raw), "offset:") {
+		return 0, false
+	}
+	return n, true
+}
+
+// --- response helpers ---
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeResult writes v as JSON, or err as the API would report it.
+func writeResult(w http.ResponseWriter, v any, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, v)
+}
+
+// writeNoContent writes 204, or err as the API would report it.
+func writeNoContent(w http.ResponseWriter, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	msg := err.Error()
+	var apiErr *api.TodoistError
+	if errors.As(err, &apiErr) {
+		status, msg = apiErr.StatusCode, apiErr.Message
+	}
+	ErrorJSON(status, msg).write(w)
+}
+
+// decode reads a JSON request body into v, answering 400 on failure.
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		ErrorJSON(http.StatusBadRequest, "invalid JSON body").write(w)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"io"
+)
+
+// TodoistAPI is the set of Todoist operations the CLI commands use.
+// *Client implements it against the real API; apitest.Fake implements it
+// in memory for tests.
+type TodoistAPI interface {
+	// Tasks
+	GetTasks(ctx context.Context, filter, projectID, sectionID string) ([]*Task, error)
+	IterTasks(ctx context.Context, filter, projectID, sectionID string, opts ListOptions) *Iterator[*Task]
+	CreateTask(ctx context.Context, req *CreateTaskRequest) (*Task, error)
+	GetTask(ctx context.Context, taskID string) (*Task, error)
+	UpdateTask(ctx context.Context, taskID string, req *UpdateTaskRequest) (*Task, error)
+	MoveTask(ctx context.Context, taskID string, req *MoveTaskRequest) (*Task, error)
+	CloseTask(ctx context.Context, taskID string) error
+	ReopenTask(ctx context.Context, taskID string) error
+	DeleteTask(ctx context.Context, taskID string) error
+	GetCompletedTasks(ctx context.Context, projectID, since string, limit int) (*CompletedResponse, error)
+
+	// Projects
+	GetProjects(ctx context.Context) ([]*Project, error)
+	IterProjects(ctx context.Context, opts ListOptions) *Iterator[*Project]
+	CreateProject(ctx context.Context, req *CreateProjectRequest) (*Project, error)
+	UpdateProject(ctx context.Context, projectID string, req *UpdateProjectRequest) (*Project, error)
+	ArchiveProject(ctx context.Context, projectID string) (*Project, error)
+	UnarchiveProject(ctx context.Context, projectID string) (*Project, error)
+	GetArchivedProjects(ctx context.Context) ([]*Project, error)
+	IterArchivedProjects(ctx context.Context, opts ListOptions) *Iterator[*Project]
+	DeleteProject(ctx context.Context, projectID string) error
+	GetCollaborators(ctx context.Context, projectID string) ([]*Collaborator, error)
+	IterCollaborators(ctx context.Context, projectID string, opts ListOptions) *Iterator[*Collaborator]
+
+	// Sections
+	GetSections(ctx context.Context, projectID string) ([]*Section, error)
+	IterSections(ctx context.Context, projectID string, opts ListOptions) *Iterator[*Section]
+	CreateSection(ctx context.Context, req *CreateSectionRequest) (*Section, error)
+	UpdateSection(ctx context.Context, sectionID string, req *UpdateSectionRequest) (*Section, error)
+	DeleteSection(ctx context.Context, sectionID string) error
+
+	// Labels
+	GetLabels(ctx context.Context) ([]*Label, error)
+	IterLabels(ctx context.Context, opts ListOptions) *Iterator[*Label]
+	CreateLabel(ctx context.Context, req *CreateLabelRequest) (*Label, error)
+	UpdateLabel(ctx context.Context, labelID string, req *UpdateLabelRequest) (*Label, error)
+	DeleteLabel(ctx context.Context, labelID string) error
+	GetSharedLabels(ctx context.Context, omitPersonal bool) ([]string, error)
+	IterSharedLabels(ctx context.Context, omitPersonal bool, opts ListOptions) *Iterator[string]
+	RenameSharedLabel(ctx context.Context, name, newName string) error
+	RemoveSharedLabel(ctx context.Context, name string) error
+
+	// Comments
+	GetComments(ctx context.Context, taskID, projectID string) ([]*Comment, error)
+	IterComments(ctx context.Context, taskID, projectID string, opts ListOptions) *Iterator[*Comment]
+	CreateComment(ctx context.Context, req *CreateCommentRequest) (*Comment, error)
+	DeleteComment(ctx context.Context, commentID string) error
+	UploadFile(ctx context.Context, fileName string, r io.Reader) (*Attachment, error)
+
+	// Sync
+	Sync(ctx context.Context, req *SyncRequest) (*SyncResponse, error)
+}
+
+var _ TodoistAPI = (*Client)(nil)
@@ -2,21 +2,23 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 )
 
-// paginatedResponse wraps the v1 API list responses which return
-// {"results": [...], "next_cursor": ...} instead of a raw array.
-type paginatedResponse[T any] struct {
-	Results    []T     `json:"results"`
-	NextCursor *string `json:"next_cursor,omitempty"`
+// GetTasks returns all active tasks, optionally filtered by filter query,
+// project ID and/or section ID. Every page is fetched before returning.
+func (c *Client) GetTasks(ctx context.Context, filter, projectID, sectionID string) ([]*Task, error) {
+	return c.IterTasks(ctx, filter, projectID, sectionID, ListOptions{}).All()
 }
 
-// GetTasks returns all active tasks, optionally filtered by filter query and/or project ID.
-func (c *Client) GetTasks(filter, projectID string) ([]*Task, error) {
+// IterTasks returns an iterator over active tasks, optionally filtered by
+// filter query, project ID and/or section ID.
+func (c *Client) IterTasks(ctx context.Context, filter, projectID, sectionID string, opts ListOptions) *Iterator[*Task] {
 	params := url.Values{}
 	if filter != "" {
 		params.Set("filter", filter)
@@ -24,35 +26,88 @@ func (c *Client) GetTasks(filter, projectID string) ([]*Task, error) {
 	if projectID != "" {
 		params.Set("project_id", projectID)
 	}
+	if sectionID != "" {
+		params.Set("section_id", sectionID)
+	}
+	return newIterator[*Task](ctx, c, "/tasks", params, opts, "tasks")
+}
 
-	endpoint := "/tasks"
-	if encoded := params.Encode(); encoded != "" {
-		endpoint += "?" + encoded
+// CreateTask creates a new task.
+func (c *Client) CreateTask(ctx context.Context, req *CreateTaskRequest) (*Task, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	body, _, err := c.request(http.MethodGet, endpoint, nil)
+	body, _, err := c.request(ctx, http.MethodPost, "/tasks", bytes.NewReader(payload))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tasks: %w", err)
+		return nil, fmt.Errorf("failed to create task: %w", err)
 	}
 
-	var resp paginatedResponse[*Task]
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("failed to parse tasks: %w", err)
+	var task Task
+	if err := json.Unmarshal(body, &task); err != nil {
+		return nil, fmt.Errorf("failed to parse task: %w", err)
 	}
 
-	return resp.Results, nil
+	return &task, nil
 }
 
-// CreateTask creates a new task.
-func (c *Client) CreateTask(req *CreateTaskRequest) (*Task, error) {
+// GetTask returns a single active task by ID.
+func (c *Client) GetTask(ctx context.Context, taskID string) (*Task, error) {
+	body, _, err := c.request(ctx, http.MethodGet, "/tasks/"+taskID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	var task Task
+	if err := json.Unmarshal(body, &task); err != nil {
+		return nil, fmt.Errorf("failed to parse task: %w", err)
+	}
+
+	return &task, nil
+}
+
+// UpdateTask updates a task. Only the non-nil fields of req are sent.
+func (c *Client) UpdateTask(ctx context.Context, taskID string, req *UpdateTaskRequest) (*Task, error) {
 	payload, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	body, _, err := c.request(http.MethodPost, "/tasks", bytes.NewReader(payload))
+	body, _, err := c.request(ctx, http.MethodPost, "/tasks/"+taskID, bytes.NewReader(payload))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create task: %w", err)
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	var task Task
+	if err := json.Unmarshal(body, &task); err != nil {
+		return nil, fmt.Errorf("failed to parse task: %w", err)
+	}
+
+	return &task, nil
+}
+
+// MoveTask moves a task to another project, section, or parent task.
+// Exactly one destination in req must be set. Subtasks move with their parent.
+func (c *Client) MoveTask(ctx context.Context, taskID string, req *MoveTaskRequest) (*Task, error) {
+	set := 0
+	for _, id := range []string{req.ProjectID, req.SectionID, req.ParentID} {
+		if id != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return nil, fmt.Errorf("move requires exactly one of project, section, or parent")
+	}
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	body, _, err := c.request(ctx, http.MethodPost, "/tasks/"+taskID+"/move", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to move task: %w", err)
 	}
 
 	var task Task
@@ -64,8 +119,8 @@ func (c *Client) CreateTask(req *CreateTaskRequest) (*Task, error) {
 }
 
 // CloseTask marks a task as complete. Returns 204 No Content on success.
-func (c *Client) CloseTask(taskID string) error {
-	_, statusCode, err := c.request(http.MethodPost, "/tasks/"+taskID+"/close", nil)
+func (c *Client) CloseTask(ctx context.Context, taskID string) error {
+	_, statusCode, err := c.request(ctx, http.MethodPost, "/tasks/"+taskID+"/close", nil)
 	if err != nil {
 		return fmt.Errorf("failed to close task: %w", err)
 	}
@@ -75,9 +130,21 @@ func (c *Client) CloseTask(taskID string) error {
 	return nil
 }
 
+// ReopenTask reopens a completed task. Returns 204 No Content on success.
+func (c *Client) ReopenTask(ctx context.Context, taskID string) error {
+	_, statusCode, err := c.request(ctx, http.MethodPost, "/tasks/"+taskID+"/reopen", nil)
+	if err != nil {
+		return fmt.Errorf("failed to reopen task: %w", err)
+	}
+	if statusCode != http.StatusNoContent {
+		return fmt.Errorf("unexpected status code: %d", statusCode)
+	}
+	return nil
+}
+
 // DeleteTask permanently deletes a task. Returns 204 No Content on success.
-func (c *Client) DeleteTask(taskID string) error {
-	_, statusCode, err := c.request(http.MethodDelete, "/tasks/"+taskID, nil)
+func (c *Client) DeleteTask(ctx context.Context, taskID string) error {
+	_, statusCode, err := c.request(ctx, http.MethodDelete, "/tasks/"+taskID, nil)
 	if err != nil {
 		return fmt.Errorf("failed to delete task: %w", err)
 	}
@@ -87,8 +154,10 @@ func (c *Client) DeleteTask(taskID string) error {
 	return nil
 }
 
-// GetCompletedTasks returns completed tasks with optional filtering.
-func (c *Client) GetCompletedTasks(projectID, since string, limit int) (*CompletedResponse, error) {
+// GetCompletedTasks returns completed tasks with optional filtering,
+// following next_cursor until limit tasks (zero means all) are fetched.
+// The projects the tasks belong to are merged across pages.
+func (c *Client) GetCompletedTasks(ctx context.Context, projectID, since string, limit int) (*CompletedResponse, error) {
 	params := url.Values{}
 	if projectID != "" {
 		params.Set("project_id", projectID)
@@ -96,53 +165,113 @@ func (c *Client) GetCompletedTasks(projectID, since string, limit int) (*Complet
 	if since != "" {
 		params.Set("since", since)
 	}
-	if limit > 0 {
-		params.Set("limit", fmt.Sprintf("%d", limit))
-	}
 
-	endpoint := "/tasks/completed"
-	if encoded := params.Encode(); encoded != "" {
-		endpoint += "?" + encoded
+	// The endpoint pages like the list endpoints but names its results
+	// "items" and sends the tasks' projects alongside.
+	resp := &CompletedResponse{Projects: map[string]Project{}}
+	fetch := func(ctx context.Context, cursor string, limit int) ([]CompletedTask, string, error) {
+		q := url.Values{}
+		for k, v := range params {
+			q[k] = v
+		}
+		if cursor != "" {
+			q.Set("cursor", cursor)
+		}
+		if limit > 0 {
+			q.Set("limit", strconv.Itoa(limit))
+		}
+
+		endpoint := "/tasks/completed"
+		if encoded := q.Encode(); encoded != "" {
+			endpoint += "?" + encoded
+		}
+
+		body, _, err := c.request(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get completed tasks: %w", err)
+		}
+
+		var page struct {
+			CompletedResponse
+			NextCursor *string `json:"next_cursor,omitempty"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, "", fmt.Errorf("failed to parse completed tasks: %w", err)
+		}
+		for id, p := range page.Projects {
+			resp.Projects[id] = p
+		}
+
+		next := ""
+		if page.NextCursor != nil {
+			next = *page.NextCursor
+		}
+		return page.Items, next, nil
 	}
 
-	body, _, err := c.request(http.MethodGet, endpoint, nil)
+	items, err := NewIterator(ctx, fetch, ListOptions{Limit: limit}).All()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get completed tasks: %w", err)
+		return nil, err
 	}
+	resp.Items = items
+	return resp, nil
+}
 
-	var resp CompletedResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("failed to parse completed tasks: %w", err)
-	}
+// GetProjects returns all projects, fetching every page.
+func (c *Client) GetProjects(ctx context.Context) ([]*Project, error) {
+	return c.IterProjects(ctx, ListOptions{}).All()
+}
 
-	return &resp, nil
+// IterProjects returns an iterator over all projects.
+func (c *Client) IterProjects(ctx context.Context, opts ListOptions) *Iterator[*Project] {
+	return newIterator[*Project](ctx, c, "/projects", nil, opts, "projects")
 }
 
-// GetProjects returns all projects.
-func (c *Client) GetProjects() ([]*Project, error) {
-	body, _, err := c.request(http.MethodGet, "/projects", nil)
+// CreateProject creates a new project.
+func (c *Client) CreateProject(ctx context.Context, req *CreateProjectRequest) (*Project, error) {
+	payload, err := json.Marshal(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get projects: %w", err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	var resp paginatedResponse[*Project]
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("failed to parse projects: %w", err)
+	body, _, err := c.request(ctx, http.MethodPost, "/projects", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+
+	var project Project
+	if err := json.Unmarshal(body, &project); err != nil {
+		return nil, fmt.Errorf("failed to parse project: %w", err)
 	}
 
-	return resp.Results, nil
+	return &project, nil
 }
 
-// CreateProject creates a new project.
-func (c *Client) CreateProject(req *CreateProjectRequest) (*Project, error) {
+// UpdateProject updates a project. Only the non-nil fields of req are sent.
+func (c *Client) UpdateProject(ctx context.Context, projectID string, req *UpdateProjectRequest) (*Project, error) {
 	payload, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	body, _, err := c.request(http.MethodPost, "/projects", bytes.NewReader(payload))
+	body, _, err := c.request(ctx, http.MethodPost, "/projects/"+projectID, bytes.NewReader(payload))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create project: %w", err)
+		return nil, fmt.Errorf("failed to update project: %w", err)
+	}
+
+	var project Project
+	if err := json.Unmarshal(body, &project); err != nil {
+		return nil, fmt.Errorf("failed to parse project: %w", err)
+	}
+
+	return &project, nil
+}
+
+// ArchiveProject archives a project and its sub-projects.
+func (c *Client) ArchiveProject(ctx context.Context, projectID string) (*Project, error) {
+	body, _, err := c.request(ctx, http.MethodPost, "/projects/"+projectID+"/archive", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive project: %w", err)
 	}
 
 	var project Project
@@ -153,9 +282,44 @@ func (c *Client) CreateProject(req *CreateProjectRequest) (*Project, error) {
 	return &project, nil
 }
 
+// UnarchiveProject restores an archived project.
+func (c *Client) UnarchiveProject(ctx context.Context, projectID string) (*Project, error) {
+	body, _, err := c.request(ctx, http.MethodPost, "/projects/"+projectID+"/unarchive", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unarchive project: %w", err)
+	}
+
+	var project Project
+	if err := json.Unmarshal(body, &project); err != nil {
+		return nil, fmt.Errorf("failed to parse project: %w", err)
+	}
+
+	return &project, nil
+}
+
+// GetArchivedProjects returns all archived projects, fetching every page.
+func (c *Client) GetArchivedProjects(ctx context.Context) ([]*Project, error) {
+	return c.IterArchivedProjects(ctx, ListOptions{}).All()
+}
+
+// IterArchivedProjects returns an iterator over archived projects.
+func (c *Client) IterArchivedProjects(ctx context.Context, opts ListOptions) *Iterator[*Project] {
+	return newIterator[*Project](ctx, c, "/projects/archived", nil, opts, "archived projects")
+}
+
+// GetCollaborators returns the users a project is shared with, fetching every page.
+func (c *Client) GetCollaborators(ctx context.Context, projectID string) ([]*Collaborator, error) {
+	return c.IterCollaborators(ctx, projectID, ListOptions{}).All()
+}
+
+// IterCollaborators returns an iterator over the users a project is shared with.
+func (c *Client) IterCollaborators(ctx context.Context, projectID string, opts ListOptions) *Iterator[*Collaborator] {
+	return newIterator[*Collaborator](ctx, c, "/projects/"+projectID+"/collaborators", nil, opts, "collaborators")
+}
+
 // DeleteProject permanently deletes a project. Returns 204 No Content on success.
-func (c *Client) DeleteProject(projectID string) error {
-	_, statusCode, err := c.request(http.MethodDelete, "/projects/"+projectID, nil)
+func (c *Client) DeleteProject(ctx context.Context, projectID string) error {
+	_, statusCode, err := c.request(ctx, http.MethodDelete, "/projects/"+projectID, nil)
 	if err != nil {
 		return fmt.Errorf("failed to delete project: %w", err)
 	}
@@ -165,17 +329,186 @@ func (c *Client) DeleteProject(projectID string) error {
 	return nil
 }
 
-// GetLabels returns all personal labels.
-func (c *Client) GetLabels() ([]*Label, error) {
-	body, _, err := c.request(http.MethodGet, "/labels", nil)
+// GetSections returns all sections, optionally limited to one project.
+// Every page is fetched before returning.
+func (c *Client) GetSections(ctx context.Context, projectID string) ([]*Section, error) {
+	return c.IterSections(ctx, projectID, ListOptions{}).All()
+}
+
+// IterSections returns an iterator over sections, optionally limited to one project.
+func (c *Client) IterSections(ctx context.Context, projectID string, opts ListOptions) *Iterator[*Section] {
+	params := url.Values{}
+	if projectID != "" {
+		params.Set("project_id", projectID)
+	}
+	return newIterator[*Section](ctx, c, "/sections", params, opts, "sections")
+}
+
+// CreateSection creates a new section in a project.
+func (c *Client) CreateSection(ctx context.Context, req *CreateSectionRequest) (*Section, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	body, _, err := c.request(ctx, http.MethodPost, "/sections", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create section: %w", err)
+	}
+
+	var section Section
+	if err := json.Unmarshal(body, &section); err != nil {
+		return nil, fmt.Errorf("failed to parse section: %w", err)
+	}
+
+	return &section, nil
+}
+
+// UpdateSection renames a section.
+func (c *Client) UpdateSection(ctx context.Context, sectionID string, req *UpdateSectionRequest) (*Section, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	body, _, err := c.request(ctx, http.MethodPost, "/sections/"+sectionID, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update section: %w", err)
+	}
+
+	var section Section
+	if err := json.Unmarshal(body, &section); err != nil {
+		return nil, fmt.Errorf("failed to parse section: %w", err)
+	}
+
+	return &section, nil
+}
+
+// DeleteSection permanently deletes a section and all its tasks.
+// Returns 204 No Content on success.
+func (c *Client) DeleteSection(ctx context.Context, sectionID string) error {
+	_, statusCode, err := c.request(ctx, http.MethodDelete, "/sections/"+sectionID, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete section: %w", err)
+	}
+	if statusCode != http.StatusNoContent {
+		return fmt.Errorf("unexpected status code: %d", statusCode)
+	}
+	return nil
+}
+
+// GetLabels returns all personal labels, fetching every page.
+func (c *Client) GetLabels(ctx context.Context) ([]*Label, error) {
+	return c.IterLabels(ctx, ListOptions{}).All()
+}
+
+// IterLabels returns an iterator over all personal labels.
+func (c *Client) IterLabels(ctx context.Context, opts ListOptions) *Iterator[*Label] {
+	return newIterator[*Label](ctx, c, "/labels", nil, opts, "labels")
+}
+
+// CreateLabel creates a new personal label.
+func (c *Client) CreateLabel(ctx context.Context, req *CreateLabelRequest) (*Label, error) {
+	payload, err := json.Marshal(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get labels: %w", err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	var resp paginatedResponse[*Label]
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("failed to parse labels: %w", err)
+	body, _, err := c.request(ctx, http.MethodPost, "/labels", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create label: %w", err)
 	}
 
-	return resp.Results, nil
+	var label Label
+	if err := json.Unmarshal(body, &label); err != nil {
+		return nil, fmt.Errorf("failed to parse label: %w", err)
+	}
+
+	return &label, nil
+}
+
+// UpdateLabel updates a personal label. Renaming a label also renames it
+// on every task that uses it.
+func (c *Client) UpdateLabel(ctx context.Context, labelID string, req *UpdateLabelRequest) (*Label, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	body, _, err := c.request(ctx, http.MethodPost, "/labels/"+labelID, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update label: %w", err)
+	}
+
+	var label Label
+	if err := json.Unmarshal(body, &label); err != nil {
+		return nil, fmt.Errorf("failed to parse label: %w", err)
+	}
+
+	return &label, nil
+}
+
+// DeleteLabel permanently deletes a personal label and removes it from all tasks.
+// Returns 204 No Content on success.
+func (c *Client) DeleteLabel(ctx context.Context, labelID string) error {
+	_, statusCode, err := c.request(ctx, http.MethodDelete, "/labels/"+labelID, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete label: %w", err)
+	}
+	if statusCode != http.StatusNoContent {
+		return fmt.Errorf("unexpected status code: %d", statusCode)
+	}
+	return nil
+}
+
+// GetSharedLabels returns the names of all labels used on tasks, including
+// labels from shared projects that are not in the personal label list.
+// With omitPersonal, personal labels are left out. Every page is fetched.
+func (c *Client) GetSharedLabels(ctx context.Context, omitPersonal bool) ([]string, error) {
+	return c.IterSharedLabels(ctx, omitPersonal, ListOptions{}).All()
+}
+
+// IterSharedLabels returns an iterator over shared label names.
+func (c *Client) IterSharedLabels(ctx context.Context, omitPersonal bool, opts ListOptions) *Iterator[string] {
+	params := url.Values{}
+	if omitPersonal {
+		params.Set("omit_personal", "true")
+	}
+	return newIterator[string](ctx, c, "/labels/shared", params, opts, "shared labels")
+}
+
+// RenameSharedLabel renames a shared label on every task that uses it.
+// Returns 204 No Content on success.
+func (c *Client) RenameSharedLabel(ctx context.Context, name, newName string) error {
+	payload, err := json.Marshal(map[string]string{"name": name, "new_name": newName})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	_, statusCode, err := c.request(ctx, http.MethodPost, "/labels/shared/rename", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to rename shared label: %w", err)
+	}
+	if statusCode != http.StatusNoContent {
+		return fmt.Errorf("unexpected status code: %d", statusCode)
+	}
+	return nil
+}
+
+// RemoveSharedLabel removes a shared label from every task that uses it.
+// Returns 204 No Content on success.
+func (c *Client) RemoveSharedLabel(ctx context.Context, name string) error {
+	payload, err := json.Marshal(map[string]string{"name": name})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	_, statusCode, err := c.request(ctx, http.MethodPost, "/labels/shared/remove", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to remove shared label: %w", err)
+	}
+	if statusCode != http.StatusNoContent {
+		return fmt.Errorf("unexpected status code: %d", statusCode)
+	}
+	return nil
 }
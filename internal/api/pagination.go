@@ -0,0 +1,183 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// MaxPageSize is the largest page the v1 API returns for list endpoints.
+const MaxPageSize = 200
+
+// paginatedResponse wraps the v1 API list responses which return
+// {"results": [...], "next_cursor": ...} instead of a raw array.
+type paginatedResponse[T any] struct {
+	Results    []T     `json:"results"`
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// ListOptions controls how a paginated list endpoint is walked.
+type ListOptions struct {
+	// Limit caps the total number of results. Zero means no cap.
+	Limit int
+	// PageSize is the number of results requested per page.
+	// Zero uses the API default; values above MaxPageSize are clamped.
+	PageSize int
+}
+
+// Iterator walks a cursor-paginated v1 list endpoint one page at a time,
+// following next_cursor until the API reports no more results.
+//
+//	it := client.IterTasks(ctx, "today", "", "", api.ListOptions{})
+//	for it.Next() {
+//		for _, t := range it.Page() {
+//			// ...
+//		}
+//	}
+//	if err := it.Err(); err != nil {
+//		// ...
+//	}
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch PageFunc[T]
+	opts  ListOptions
+
+	page    []T
+	cursor  string
+	fetched int
+	done    bool
+	err     error
+}
+
+// PageFunc fetches one page of results starting at cursor ("" for the first
+// page), asking for at most limit results (zero means the server default).
+// It returns the page and the cursor of the next one, or "" at the end.
+type PageFunc[T any] func(ctx context.Context, cursor string, limit int) ([]T, string, error)
+
+// NewIterator returns an Iterator that pages through fetch. It lets other
+// TodoistAPI implementations, such as test fakes, reuse the paging logic.
+func NewIterator[T any](ctx context.Context, fetch PageFunc[T], opts ListOptions) *Iterator[T] {
+	return &Iterator[T]{
+		ctx:   ctx,
+		fetch: fetch,
+		opts:  opts,
+	}
+}
+
+// newIterator returns an Iterator over a v1 list endpoint. noun is used in
+// error messages, e.g. "tasks".
+func newIterator[T any](ctx context.Context, c *Client, endpoint string, params url.Values, opts ListOptions, noun string) *Iterator[T] {
+	fetch := func(ctx context.Context, cursor string, limit int) ([]T, string, error) {
+		q := url.Values{}
+		for k, v := range params {
+			q[k] = v
+		}
+		if cursor != "" {
+			q.Set("cursor", cursor)
+		}
+		if limit > 0 {
+			q.Set("limit", strconv.Itoa(limit))
+		}
+
+		path := endpoint
+		if encoded := q.Encode(); encoded != "" {
+			path += "?" + encoded
+		}
+
+		body, _, err := c.request(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get %s: %w", noun, err)
+		}
+
+		var resp paginatedResponse[T]
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, "", fmt.Errorf("failed to parse %s: %w", noun, err)
+		}
+
+		next := ""
+		if resp.NextCursor != nil {
+			next = *resp.NextCursor
+		}
+		return resp.Results, next, nil
+	}
+	return NewIterator(ctx, fetch, opts)
+}
+
+// Next fetches the next page. It returns false when there are no more
+// pages or an error occurred; check Err to tell the two apart.
+func (it *Iterator[T]) Next() bool {
+	if it.done || it.err != nil {
+		return false
+	}
+	if it.opts.Limit > 0 && it.fetched >= it.opts.Limit {
+		it.done = true
+		return false
+	}
+
+	results, next, err := it.fetch(it.ctx, it.cursor, it.pageSize())
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	page := results
+	if it.opts.Limit > 0 && it.fetched+len(page) > it.opts.Limit {
+		page = page[:it.opts.Limit-it.fetched]
+	}
+	it.page = page
+	it.fetched += len(page)
+
+	// An empty page with a cursor would loop forever; treat it as the end.
+	if next == "" || len(results) == 0 {
+		it.done = true
+	} else {
+		it.cursor = next
+	}
+
+	return true
+}
+
+// Page returns the results fetched by the most recent call to Next.
+func (it *Iterator[T]) Page() []T {
+	return it.page
+}
+
+// Err returns the first error encountered while paging, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All drains the iterator and returns every remaining result.
+// The returned slice is never nil, so it encodes as [] rather than null.
+func (it *Iterator[T]) All() ([]T, error) {
+	all := make([]T, 0)
+	for it.Next() {
+		all = append(all, it.Page()...)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// pageSize returns the "limit" query value for the next request,
+// never asking for more results than the overall Limit still allows.
+func (it *Iterator[T]) pageSize() int {
+	size := it.opts.PageSize
+	if size > MaxPageSize {
+		size = MaxPageSize
+	}
+	if it.opts.Limit > 0 {
+		remaining := it.opts.Limit - it.fetched
+		if size == 0 || remaining < size {
+			size = remaining
+		}
+		if size > MaxPageSize {
+			size = MaxPageSize
+		}
+	}
+	return size
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// newPagedServer serves total labels in pages, honoring the cursor and limit
+// query parameters the same way the v1 API does. Cursors are plain offsets.
+func newPagedServer(t *testing.T, total, defaultSize int, requests *[]string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.URL.RawQuery)
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+		size := defaultSize
+		if l := r.URL.Query().Get("limit"); l != "" {
+			size, _ = strconv.Atoi(l)
+		}
+
+		resp := paginatedResponse[*Label]{Results: []*Label{}}
+		for i := offset; i < total && i < offset+size; i++ {
+			resp.Results = append(resp.Results, &Label{ID: strconv.Itoa(i), Name: "l" + strconv.Itoa(i)})
+		}
+		if offset+size < total {
+			next := strconv.Itoa(offset + size)
+			resp.NextCursor = &next
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestClient(t *testing.T, baseURL string) *Client {
+	t.Helper()
+	c, err := NewClient("test-token", WithBaseURL(baseURL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return c
+}
+
+func TestIterator_followsCursor(t *testing.T) {
+	var requests []string
+	srv := newPagedServer(t, 7, 3, &requests)
+	c := newTestClient(t, srv.URL)
+
+	labels, err := c.GetLabels(context.Background())
+	if err != nil {
+		t.Fatalf("GetLabels() error = %v", err)
+	}
+	if len(labels) != 7 {
+		t.Fatalf("GetLabels() returned %d labels, want 7", len(labels))
+	}
+	for i, l := range labels {
+		if l.ID != strconv.Itoa(i) {
+			t.Errorf("labels[%d].ID = %q, want %q", i, l.ID, strconv.Itoa(i))
+		}
+	}
+	if len(requests) != 3 {
+		t.Errorf("made %d requests, want 3: %v", len(requests), requests)
+	}
+}
+
+func TestIterator_limitAndPageSize(t *testing.T) {
+	var requests []string
+	srv := newPagedServer(t, 50, 10, &requests)
+	c := newTestClient(t, srv.URL)
+
+	it := c.IterLabels(context.Background(), ListOptions{Limit: 5, PageSize: 2})
+	pages := 0
+	total := 0
+	for it.Next() {
+		pages++
+		total += len(it.Page())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+	if total != 5 {
+		t.Errorf("got %d results, want 5", total)
+	}
+	if pages != 3 {
+		t.Errorf("got %d pages, want 3", pages)
+	}
+	// The last request should only ask for what is left under the limit.
+	if last := requests[len(requests)-1]; last != "cursor=4&limit=1" {
+		t.Errorf("last request query = %q, want %q", last, "cursor=4&limit=1")
+	}
+}
+
+func TestIterator_emptyEncodesAsArray(t *testing.T) {
+	var requests []string
+	srv := newPagedServer(t, 0, 10, &requests)
+	c := newTestClient(t, srv.URL)
+
+	labels, err := c.GetLabels(context.Background())
+	if err != nil {
+		t.Fatalf("GetLabels() error = %v", err)
+	}
+	data, _ := json.Marshal(labels)
+	if string(data) != "[]" {
+		t.Errorf("json.Marshal(labels) = %s, want []", data)
+	}
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultRequestsPerMinute is the default client-side request rate. Todoist
+// allows 1000 requests per user per 15 minutes; a full bucket of 60 plus one
+// request per second afterwards stays under that.
+const DefaultRequestsPerMinute = 60
+
+// rateLimiter is a token bucket shared by every request a Client makes.
+// The bucket holds up to one minute's worth of tokens, so short bursts go
+// out immediately and sustained loops settle at the configured rate.
+// It is safe for concurrent use.
+type rateLimiter struct {
+	mu       sync.Mutex
+	rate     float64 // tokens per second
+	capacity float64
+	tokens   float64
+	last     time.Time
+	now      func() time.Time
+}
+
+// newRateLimiter returns a limiter allowing perMinute requests per minute,
+// starting with a full bucket. It returns nil if perMinute is not positive;
+// a nil limiter never waits.
+func newRateLimiter(perMinute int) *rateLimiter {
+	if perMinute <= 0 {
+		return nil
+	}
+	l := &rateLimiter{
+		rate:     float64(perMinute) / 60,
+		capacity: float64(perMinute),
+		tokens:   float64(perMinute),
+		now:      time.Now,
+	}
+	l.last = l.now()
+	return l
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	d := l.reserve()
+	if d <= 0 {
+		return nil
+	}
+	if err := sleep(ctx, d); err != nil {
+		l.cancel()
+		return err
+	}
+	return nil
+}
+
+// reserve takes a token and returns how long the caller must wait before
+// using it. The bucket may go negative, which queues later callers behind
+// earlier ones instead of letting them race for the next token.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.capacity {
+		l.tokens = l.capacity
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token that was never used.
+func (l *rateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens++
+	if l.tokens > l.capacity {
+		l.tokens = l.capacity
+	}
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock returns a limiter clock that only moves when advanced.
+func fakeClock(l *rateLimiter) *time.Time {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+	l.last = now
+	return &now
+}
+
+func TestRateLimiter_burstThenPace(t *testing.T) {
+	l := newRateLimiter(60)
+	now := fakeClock(l)
+
+	for i := 0; i < 60; i++ {
+		if d := l.reserve(); d != 0 {
+			t.Fatalf("reserve() #%d = %v, want 0 while bucket is full", i, d)
+		}
+	}
+	if d := l.reserve(); d != time.Second {
+		t.Errorf("reserve() past burst = %v, want 1s", d)
+	}
+	if d := l.reserve(); d != 2*time.Second {
+		t.Errorf("second reserve() past burst = %v, want 2s (queued behind the first)", d)
+	}
+
+	*now = now.Add(10 * time.Second)
+	if d := l.reserve(); d != 0 {
+		t.Errorf("reserve() after refill = %v, want 0", d)
+	}
+}
+
+func TestRateLimiter_refillCapped(t *testing.T) {
+	l := newRateLimiter(2)
+	now := fakeClock(l)
+
+	*now = now.Add(time.Hour)
+	l.reserve()
+	l.reserve()
+	if d := l.reserve(); d != 30*time.Second {
+		t.Errorf("reserve() = %v, want 30s; bucket must not fill beyond capacity", d)
+	}
+}
+
+func TestRateLimiter_concurrent(t *testing.T) {
+	l := newRateLimiter(60)
+	fakeClock(l)
+
+	const n = 100
+	waits := make(chan time.Duration, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			waits <- l.reserve()
+		}()
+	}
+	wg.Wait()
+	close(waits)
+
+	// 60 requests go immediately; the other 40 are spread one second apart.
+	seen := map[time.Duration]bool{}
+	immediate := 0
+	for d := range waits {
+		if d == 0 {
+			immediate++
+			continue
+		}
+		if seen[d] {
+			t.Errorf("two callers got the same slot %v", d)
+		}
+		seen[d] = true
+	}
+	if immediate != 60 || len(seen) != 40 {
+		t.Errorf("got %d immediate and %d paced, want 60 and 40", immediate, len(seen))
+	}
+}
+
+func TestRateLimiter_cancelReturnsToken(t *testing.T) {
+	l := newRateLimiter(1)
+	fakeClock(l)
+	l.reserve()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Wait() error = %v, want context.Canceled", err)
+	}
+	if d := l.reserve(); d != time.Minute {
+		t.Errorf("reserve() after cancelled Wait = %v, want 1m", d)
+	}
+}
+
+func TestRateLimiter_nilNeverWaits(t *testing.T) {
+	var l *rateLimiter
+	if err := l.Wait(context.Background()); err != nil {
+		t.Errorf("Wait() on nil limiter = %v, want nil", err)
+	}
+	if newRateLimiter(0) != nil {
+		t.Error("newRateLimiter(0) should disable limiting")
+	}
+}
@@ -0,0 +1,403 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/api/fakeserver"
+)
+
+// fastRetries keeps backoff short so retry tests run in milliseconds.
+var fastRetries = api.WithRetryPolicy(api.RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond})
+
+func TestRequest_retries(t *testing.T) {
+	tests := []struct {
+		name      string
+		faults    []fakeserver.Response
+		wantCalls int
+		wantErr   func(error) bool
+	}{
+		{
+			name:      "429 then success",
+			faults:    []fakeserver.Response{fakeserver.RateLimited(0)},
+			wantCalls: 2,
+		},
+		{
+			name:      "429 with body hint then success",
+			faults:    []fakeserver.Response{fakeserver.RateLimitedBody(10 * time.Millisecond)},
+			wantCalls: 2,
+		},
+		{
+			name:      "5xx twice then success",
+			faults:    []fakeserver.Response{fakeserver.ServerError(502), fakeserver.ServerError(503)},
+			wantCalls: 3,
+		},
+		{
+			name: "5xx until retries run out",
+			faults: []fakeserver.Response{
+				fakeserver.ServerError(500), fakeserver.ServerError(500),
+				fakeserver.ServerError(500), fakeserver.ServerError(500),
+			},
+			wantCalls: 4,
+			wantErr:   api.IsServerError,
+		},
+		{
+			name:      "429 beyond budget fails fast",
+			faults:    []fakeserver.Response{fakeserver.RateLimited(2 * time.Minute)},
+			wantCalls: 1,
+			wantErr:   api.IsRateLimitError,
+		},
+		{
+			name:      "401 fails fast",
+			faults:    []fakeserver.Response{fakeserver.ErrorEmpty(http.StatusUnauthorized)},
+			wantCalls: 1,
+			wantErr:   api.IsAuthError,
+		},
+		{
+			name:      "404 fails fast",
+			faults:    []fakeserver.Response{fakeserver.ErrorJSON(http.StatusNotFound, "Task not found")},
+			wantCalls: 1,
+			wantErr:   api.IsNotFoundError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := fakeserver.New(t)
+			srv.Fake.AddLabel(api.Label{Name: "home"})
+			srv.Fail(http.MethodGet, "/labels", tt.faults...)
+			c := srv.Client(t, fastRetries)
+
+			labels, err := c.GetLabels(context.Background())
+			if calls := len(srv.Requests()); calls != tt.wantCalls {
+				t.Errorf("server saw %d requests, want %d", calls, tt.wantCalls)
+			}
+			if tt.wantErr != nil {
+				if !tt.wantErr(err) {
+					t.Fatalf("GetLabels() error = %v, want a matching error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetLabels() error = %v", err)
+			}
+			if len(labels) != 1 || labels[0].Name != "home" {
+				t.Errorf("GetLabels() = %v, want [home]", labels)
+			}
+		})
+	}
+}
+
+func TestRequest_errorBodyShapes(t *testing.T) {
+	tests := []struct {
+		name string
+		resp fakeserver.Response
+		want string
+	}{
+		{"json object", fakeserver.ErrorJSON(http.StatusBadRequest, "Invalid argument"), "Invalid argument"},
+		{"json string", fakeserver.ErrorString(http.StatusBadRequest, "Invalid argument"), "Invalid argument"},
+		{"plain text", fakeserver.ErrorText(http.StatusBadRequest, "Invalid argument"), "Invalid argument"},
+		{"empty", fakeserver.ErrorEmpty(http.StatusForbidden), "Forbidden"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := fakeserver.New(t)
+			srv.Fail("", "", tt.resp)
+			c := srv.Client(t, fastRetries)
+
+			_, err := c.GetTask(context.Background(), "1")
+			var apiErr *api.TodoistError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("GetTask() error = %v, want *TodoistError", err)
+			}
+			if apiErr.Message != tt.want || apiErr.StatusCode != tt.resp.Status {
+				t.Errorf("error = %q (HTTP %d), want %q (HTTP %d)", apiErr.Message, apiErr.StatusCode, tt.want, tt.resp.Status)
+			}
+			if n := len(srv.Requests()); n != 1 {
+				t.Errorf("server saw %d requests, want 1 (no retry on 4xx)", n)
+			}
+		})
+	}
+}
+
+func TestRequest_badToken(t *testing.T) {
+	srv := fakeserver.New(t)
+	c, err := api.NewClient("wrong-token", api.WithBaseURL(srv.URL()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetProjects(context.Background()); !api.IsAuthError(err) {
+		t.Errorf("GetProjects() error = %v, want auth error", err)
+	}
+}
+
+func TestClient_againstFakeServer(t *testing.T) {
+	srv := fakeserver.New(t)
+	c := srv.Client(t)
+	ctx := context.Background()
+
+	work, err := c.CreateProject(ctx, &api.CreateProjectRequest{Name: "Work"})
+	if err != nil {
+		t.Fatalf("CreateProject() error = %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		if _, err := c.CreateTask(ctx, &api.CreateTaskRequest{Content: "task", ProjectID: work.ID}); err != nil {
+			t.Fatalf("CreateTask() error = %v", err)
+		}
+	}
+
+	tasks, err := c.IterTasks(ctx, "", work.ID, "", api.ListOptions{PageSize: 2}).All()
+	if err != nil {
+		t.Fatalf("IterTasks() error = %v", err)
+	}
+	if len(tasks) != 5 {
+		t.Fatalf("IterTasks() returned %d tasks, want 5", len(tasks))
+	}
+
+	var pages int
+	for _, r := range srv.Requests() {
+		if r.Method == http.MethodGet && r.Path == "/tasks" {
+			pages++
+		}
+	}
+	if pages != 3 {
+		t.Errorf("fetched %d pages, want 3", pages)
+	}
+
+	if err := c.CloseTask(ctx, tasks[0].ID); err != nil {
+		t.Fatalf("CloseTask() error = %v", err)
+	}
+	done, err := c.GetCompletedTasks(ctx, work.ID, "", 0)
+	if err != nil {
+		t.Fatalf("GetCompletedTasks() error = %v", err)
+	}
+	if len(done.Items) != 1 || done.Items[0].TaskID != tasks[0].ID {
+		t.Errorf("GetCompletedTasks() = %+v, want the closed task", done.Items)
+	}
+
+	if _, err := c.GetTask(ctx, tasks[0].ID); !api.IsNotFoundError(err) || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("GetTask(closed) error = %v, want 404", err)
+	}
+}
+
+func TestRunCommands_batches(t *testing.T) {
+	srv := fakeserver.New(t)
+	ctx := context.Background()
+
+	var cmds []api.SyncCommand
+	for range api.MaxSyncCommands + 20 {
+		task := srv.Fake.AddTask(api.Task{Content: "task"})
+		cmds = append(cmds, api.CloseTaskCommand(task.ID))
+	}
+	cmds = append(cmds, api.CloseTaskCommand("missing"))
+
+	resp, err := api.RunCommands(ctx, srv.Client(t), cmds)
+	if err != nil {
+		t.Fatalf("RunCommands() error = %v", err)
+	}
+	if n := len(srv.Requests()); n != 2 {
+		t.Errorf("server saw %d requests, want 2", n)
+	}
+	for _, c := range cmds[:len(cmds)-1] {
+		if err := resp.Err(c); err != nil {
+			t.Fatalf("Err(%s) = %v", c.Args["id"], err)
+		}
+	}
+	if err := resp.Err(cmds[len(cmds)-1]); !api.IsNotFoundError(err) {
+		t.Errorf("Err(missing) = %v, want 404", err)
+	}
+}
+
+func TestRunCommands_tempIDs(t *testing.T) {
+	srv := fakeserver.New(t)
+	ctx := context.Background()
+
+	parent := api.AddTaskCommand(&api.CreateTaskRequest{Content: "Parent"})
+	child := api.AddTaskCommand(&api.CreateTaskRequest{Content: "Child", ParentID: parent.TempID})
+	resp, err := api.RunCommands(ctx, srv.Client(t), []api.SyncCommand{parent, child})
+	if err != nil {
+		t.Fatalf("RunCommands() error = %v", err)
+	}
+
+	parentID, childID := resp.TempIDMapping[parent.TempID], resp.TempIDMapping[child.TempID]
+	task, ok := srv.Fake.Task(childID)
+	if !ok || parentID == "" || task.ParentID != parentID {
+		t.Errorf("child = %+v, want parent %q", task, parentID)
+	}
+}
+
+func TestRunCommands_taskFields(t *testing.T) {
+	srv := fakeserver.New(t)
+	ctx := context.Background()
+	str := func(s string) *string { return &s }
+	amount := 45
+
+	add := api.AddTaskCommand(&api.CreateTaskRequest{Content: "Report", DueDate: "2026-03-12",
+		DeadlineDate: "2026-03-13", AssigneeID: "u1"})
+	update := api.UpdateTaskCommand(add.TempID, &api.UpdateTaskRequest{DueDatetime: str("2026-03-12T15:00:00Z"),
+		DeadlineDate: str("2026-03-20"), Duration: &amount, DurationUnit: str("minute")})
+	resp, err := api.RunCommands(ctx, srv.Client(t), []api.SyncCommand{add, update})
+	if err != nil {
+		t.Fatalf("RunCommands() error = %v", err)
+	}
+	for _, c := range []api.SyncCommand{add, update} {
+		if err := resp.Err(c); err != nil {
+			t.Fatalf("Err(%s) = %v", c.Type, err)
+		}
+	}
+
+	task, _ := srv.Fake.Task(resp.TempIDMapping[add.TempID])
+	if task.Due == nil || task.Due.Datetime != "2026-03-12T15:00:00Z" {
+		t.Errorf("due = %+v, want 2026-03-12T15:00:00Z", task.Due)
+	}
+	if task.Deadline == nil || task.Deadline.Date != "2026-03-20" {
+		t.Errorf("deadline = %+v, want 2026-03-20", task.Deadline)
+	}
+	if task.Duration == nil || *task.Duration != (api.Duration{Amount: 45, Unit: "minute"}) {
+		t.Errorf("duration = %+v, want 45 minutes", task.Duration)
+	}
+	if task.AssigneeID != "u1" {
+		t.Errorf("assignee = %q, want u1", task.AssigneeID)
+	}
+
+	// REST field names aren't part of the sync schema.
+	rest := api.NewCommand(api.CommandItemUpdate, map[string]any{"id": task.ID, "due_string": "tomorrow"})
+	resp, err = api.RunCommands(ctx, srv.Client(t), []api.SyncCommand{rest})
+	if err != nil {
+		t.Fatalf("RunCommands() error = %v", err)
+	}
+	if err := resp.Err(rest); err == nil || !strings.Contains(err.Error(), "due_string") {
+		t.Errorf("Err(due_string) = %v, want an unknown argument error", err)
+	}
+}
+
+func TestGetCompletedTasks_followsCursor(t *testing.T) {
+	ctx := context.Background()
+	srv := fakeserver.New(t)
+	home := srv.Fake.AddProject(api.Project{Name: "Home"})
+	work := srv.Fake.AddProject(api.Project{Name: "Work"})
+	total := fakeserver.DefaultPageSize + 10
+	for i := 0; i < total; i++ {
+		p := home
+		if i < 5 {
+			p = work // completed first, so listed on the last page
+		}
+		srv.Fake.AddCompletedTask(api.Task{ProjectID: p.ID, Content: fmt.Sprintf("task %d", i)})
+	}
+	c := srv.Client(t)
+
+	done, err := c.GetCompletedTasks(ctx, "", "", 0)
+	if err != nil {
+		t.Fatalf("GetCompletedTasks() error = %v", err)
+	}
+	if len(done.Items) != total {
+		t.Errorf("GetCompletedTasks() returned %d tasks, want %d", len(done.Items), total)
+	}
+	if _, ok := done.Projects[work.ID]; !ok || len(done.Projects) != 2 {
+		t.Errorf("GetCompletedTasks() projects = %v, want both pages' projects", done.Projects)
+	}
+
+	limited, err := c.GetCompletedTasks(ctx, "", "", fakeserver.DefaultPageSize+1)
+	if err != nil {
+		t.Fatalf("GetCompletedTasks(limit) error = %v", err)
+	}
+	if len(limited.Items) != fakeserver.DefaultPageSize+1 {
+		t.Errorf("GetCompletedTasks(limit) returned %d tasks, want %d", len(limited.Items), fakeserver.DefaultPageSize+1)
+	}
+}
+
+func TestClient_UpdateTask(t *testing.T) {
+	str := func(s string) *string { return &s }
+	num := func(n int) *int { return &n }
+	tests := []struct {
+		name     string
+		req      *api.UpdateTaskRequest
+		wantBody string
+		check    func(t *testing.T, task *api.Task)
+		wantErr  string
+	}{
+		{
+			name:     "only set fields are sent",
+			req:      &api.UpdateTaskRequest{Content: str("Renamed"), Priority: num(4)},
+			wantBody: `{"content":"Renamed","priority":4}`,
+			check: func(t *testing.T, task *api.Task) {
+				if task.Content != "Renamed" || task.Priority != 4 || len(task.Labels) != 1 {
+					t.Errorf("task = %+v, want renamed p4 with its label kept", task)
+				}
+			},
+		},
+		{
+			name:     "empty labels clear them",
+			req:      &api.UpdateTaskRequest{Labels: &[]string{}},
+			wantBody: `{"labels":[]}`,
+			check: func(t *testing.T, task *api.Task) {
+				if len(task.Labels) != 0 {
+					t.Errorf("Labels = %v, want none", task.Labels)
+				}
+			},
+		},
+		{
+			name:     "due deadline and duration",
+			req:      &api.UpdateTaskRequest{DueDate: str("2026-03-12"), DeadlineDate: str("2026-03-20"), Duration: num(2), DurationUnit: str("day")},
+			wantBody: `{"due_date":"2026-03-12","deadline_date":"2026-03-20","duration":2,"duration_unit":"day"}`,
+			check: func(t *testing.T, task *api.Task) {
+				if task.Due == nil || task.Due.Date != "2026-03-12" || task.Deadline == nil || task.Deadline.Date != "2026-03-20" {
+					t.Errorf("due = %+v deadline = %+v", task.Due, task.Deadline)
+				}
+				if task.Duration == nil || *task.Duration != (api.Duration{Amount: 2, Unit: "day"}) {
+					t.Errorf("Duration = %+v, want 2 days", task.Duration)
+				}
+			},
+		},
+		{
+			name:     "empty deadline clears it",
+			req:      &api.UpdateTaskRequest{DeadlineDate: str("")},
+			wantBody: `{"deadline_date":""}`,
+			check: func(t *testing.T, task *api.Task) {
+				if task.Deadline != nil {
+					t.Errorf("Deadline = %+v, want none", task.Deadline)
+				}
+			},
+		},
+		{
+			name:     "invalid priority",
+			req:      &api.UpdateTaskRequest{Priority: num(7)},
+			wantBody: `{"priority":7}`,
+			wantErr:  "priority",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := fakeserver.New(t)
+			seeded := srv.Fake.AddTask(api.Task{Content: "Draft", Labels: []string{"home"},
+				Deadline: &api.Deadline{Date: "2026-03-15"}})
+
+			task, err := srv.Client(t).UpdateTask(context.Background(), seeded.ID, tt.req)
+			reqs := srv.Requests()
+			if len(reqs) != 1 || reqs[0].Method != http.MethodPost || reqs[0].Path != "/tasks/"+seeded.ID {
+				t.Fatalf("requests = %+v, want one POST /tasks/%s", reqs, seeded.ID)
+			}
+			if reqs[0].Body != tt.wantBody {
+				t.Errorf("body = %s, want %s", reqs[0].Body, tt.wantBody)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("UpdateTask() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateTask() error = %v", err)
+			}
+			tt.check(t, task)
+		})
+	}
+}
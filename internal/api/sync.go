@@ -0,0 +1,646 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxSyncCommands is the most commands the sync endpoint accepts in a
+	// single request. RunCommands splits longer batches.
+	MaxSyncCommands = 100
+
+	// FullSyncToken requests every object instead of changes since a
+	// previous sync.
+	FullSyncToken = "*"
+)
+
+// Resource types that can be requested from the sync endpoint.
+const (
+	ResourceItems         = "items"
+	ResourceProjects      = "projects"
+	ResourceSections      = "sections"
+	ResourceLabels        = "labels"
+	ResourceUser          = "user"
+	ResourceCollaborators = "collaborators"
+)
+
+// Sync command types.
+const (
+	CommandItemAdd        = "item_add"
+	CommandItemUpdate     = "item_update"
+	CommandItemMove       = "item_move"
+	CommandItemClose      = "item_close"
+	CommandItemUncomplete = "item_uncomplete"
+	CommandItemDelete     = "item_delete"
+)
+
+// SyncCommand is one write in a sync batch. UUID identifies the command in
+// the response's sync_status; TempID names an object created by the
+// command so later commands in the same batch can refer to it.
+type SyncCommand struct {
+	Type   string         `json:"type"`
+	UUID   string         `json:"uuid"`
+	TempID string         `json:"temp_id,omitempty"`
+	Args   map[string]any `json:"args"`
+}
+
+// SyncRequest is the payload for POST /sync. An empty SyncToken with
+// resource types asks for a full sync.
+type SyncRequest struct {
+	SyncToken     string
+	ResourceTypes []string
+	Commands      []SyncCommand
+}
+
+// SyncResponse is the result of a sync. Incremental responses contain only
+// objects changed since the request's sync token; deleted objects come back
+// with is_deleted set.
+type SyncResponse struct {
+	SyncToken     string                    `json:"sync_token"`
+	FullSync      bool                      `json:"full_sync"`
+	Items         []*Task                   `json:"items,omitempty"`
+	Projects      []*Project                `json:"projects,omitempty"`
+	Sections      []*Section                `json:"sections,omitempty"`
+	Labels        []*Label                  `json:"labels,omitempty"`
+	User          *User                     `json:"user,omitempty"`
+	Collaborators []*Collaborator           `json:"collaborators,omitempty"`
+	SyncStatus    map[string]*CommandStatus `json:"sync_status,omitempty"`
+	TempIDMapping map[string]string         `json:"temp_id_mapping,omitempty"`
+}
+
+// CommandStatus is the outcome of one command. The API reports success as
+// the string "ok" and failure as an error object.
+type CommandStatus struct {
+	OK        bool   `json:"-"`
+	ErrorCode int    `json:"error_code,omitempty"`
+	Error     string `json:"error,omitempty"`
+	HTTPCode  int    `json:"http_code,omitempty"`
+}
+
+// UnmarshalJSON accepts either "ok" or an error object.
+func (s *CommandStatus) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		*s = CommandStatus{OK: str == "ok"}
+		if !s.OK {
+			s.Error = str
+		}
+		return nil
+	}
+	type status CommandStatus
+	var v status
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*s = CommandStatus(v)
+	return nil
+}
+
+// MarshalJSON writes "ok" for successful commands and the error object
+// otherwise, mirroring the API.
+func (s CommandStatus) MarshalJSON() ([]byte, error) {
+	if s.OK {
+		return []byte(`"ok"`), nil
+	}
+	type status CommandStatus
+	return json.Marshal(status(s))
+}
+
+// Err returns the outcome of cmd as a *TodoistError, or nil if it
+// succeeded. A command missing from sync_status is reported as an error.
+func (r *SyncResponse) Err(cmd SyncCommand) error {
+	st, ok := r.SyncStatus[cmd.UUID]
+	if !ok || st == nil {
+		return fmt.Errorf("no status returned for %s command %s", cmd.Type, cmd.UUID)
+	}
+	if st.OK {
+		return nil
+	}
+	code := st.HTTPCode
+	if code == 0 {
+		code = http.StatusBadRequest
+	}
+	return &TodoistError{Message: st.Error, StatusCode: code}
+}
+
+// Sync sends a sync request: reads of the given resource types since
+// req.SyncToken, writes in req.Commands, or both. Use RunCommands for
+// batches that may exceed MaxSyncCommands.
+func (c *Client) Sync(ctx context.Context, req *SyncRequest) (*SyncResponse, error) {
+	if len(req.Commands) > MaxSyncCommands {
+		return nil, fmt.Errorf("sync accepts at most %d commands, got %d", MaxSyncCommands, len(req.Commands))
+	}
+
+	form := url.Values{}
+	if len(req.ResourceTypes) > 0 {
+		token := req.SyncToken
+		if token == "" {
+			token = FullSyncToken
+		}
+		types, err := json.Marshal(req.ResourceTypes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal resource types: %w", err)
+		}
+		form.Set("sync_token", token)
+		form.Set("resource_types", string(types))
+	}
+	if len(req.Commands) > 0 {
+		commands, err := json.Marshal(req.Commands)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal commands: %w", err)
+		}
+		form.Set("commands", string(commands))
+	}
+
+	body, _, err := c.requestWithContentType(ctx, http.MethodPost, "/sync", "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync: %w", err)
+	}
+
+	var resp SyncResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse sync response: %w", err)
+	}
+	return &resp, nil
+}
+
+// RunCommands sends cmds through client.Sync in batches of at most
+// MaxSyncCommands, in order, and merges the statuses and temp ID mappings
+// into one response. A transport error stops the run; per-command failures
+// are reported through the response's Err method.
+func RunCommands(ctx context.Context, client TodoistAPI, cmds []SyncCommand) (*SyncResponse, error) {
+	merged := &SyncResponse{
+		SyncStatus:    map[string]*CommandStatus{},
+		TempIDMapping: map[string]string{},
+	}
+	for batch := range slices.Chunk(cmds, MaxSyncCommands) {
+		resp, err := client.Sync(ctx, &SyncRequest{Commands: batch})
+		if err != nil {
+			return merged, err
+		}
+		for k, v := range resp.SyncStatus {
+			merged.SyncStatus[k] = v
+		}
+		for k, v := range resp.TempIDMapping {
+			merged.TempIDMapping[k] = v
+		}
+	}
+	return merged, nil
+}
+
+// NewCommand returns a command of the given type with a fresh UUID.
+func NewCommand(typ string, args map[string]any) SyncCommand {
+	return SyncCommand{Type: typ, UUID: newUUID(), Args: args}
+}
+
+// AddTaskCommand returns an item_add command with a temp ID, which later
+// commands in the batch can use in place of the new task's ID.
+func AddTaskCommand(req *CreateTaskRequest) SyncCommand {
+	args := map[string]any{"content": req.Content}
+	setString(args, "description", req.Description)
+	setString(args, "project_id", req.ProjectID)
+	setString(args, "section_id", req.SectionID)
+	setString(args, "parent_id", req.ParentID)
+	setString(args, "responsible_uid", req.AssigneeID)
+	if req.Order != 0 {
+		args["child_order"] = req.Order
+	}
+	if len(req.Labels) > 0 {
+		args["labels"] = req.Labels
+	}
+	if req.Priority != 0 {
+		args["priority"] = req.Priority
+	}
+	if due := dueArg(req.DueString, req.DueDate, req.DueDatetime, req.DueLang); due != nil {
+		args["due"] = due
+	}
+	if req.DeadlineDate != "" {
+		args["deadline"] = map[string]any{"date": req.DeadlineDate}
+	}
+
+	cmd := NewCommand(CommandItemAdd, args)
+	cmd.TempID = newUUID()
+	return cmd
+}
+
+// UpdateTaskCommand returns an item_update command for taskID. Unlike the
+// REST endpoint, the sync schema nests the due date, deadline and duration
+// in objects and clears them with null; an empty string in req clears the
+// field.
+func UpdateTaskCommand(taskID string, req *UpdateTaskRequest) SyncCommand {
+	args := map[string]any{"id": taskID}
+	if req.Content != nil {
+		args["content"] = *req.Content
+	}
+	if req.Description != nil {
+		args["description"] = *req.Description
+	}
+	if req.Labels != nil {
+		args["labels"] = *req.Labels
+	}
+	if req.Priority != nil {
+		args["priority"] = *req.Priority
+	}
+	if req.DueString != nil || req.DueDate != nil || req.DueDatetime != nil {
+		if due := dueArg(deref(req.DueString), deref(req.DueDate), deref(req.DueDatetime), deref(req.DueLang)); due != nil {
+			args["due"] = due
+		} else {
+			args["due"] = nil
+		}
+	}
+	if req.AssigneeID != nil {
+		args["responsible_uid"] = nilIfEmpty(*req.AssigneeID)
+	}
+	if req.DeadlineDate != nil {
+		args["deadline"] = nil
+		if *req.DeadlineDate != "" {
+			args["deadline"] = map[string]any{"date": *req.DeadlineDate}
+		}
+	}
+	if req.Duration != nil {
+		args["duration"] = nil
+		if *req.Duration > 0 {
+			args["duration"] = map[string]any{"amount": *req.Duration, "unit": deref(req.DurationUnit)}
+		}
+	}
+	return NewCommand(CommandItemUpdate, args)
+}
+
+// taskArgKeys are the arguments item_add and item_update accept. The REST
+// names (due_string, deadline_date, assignee_id, duration_unit, ...) are
+// not among them.
+var taskArgKeys = map[string]bool{
+	"id": true, "content": true, "description": true, "project_id": true, "section_id": true,
+	"parent_id": true, "child_order": true, "labels": true, "priority": true,
+	"due": true, "deadline": true, "duration": true, "responsible_uid": true,
+}
+
+// TaskArgs are the fields of an item_add or item_update command, read back
+// into request form.
+type TaskArgs struct {
+	// Update holds the task fields. As in UpdateTaskCommand, a field
+	// cleared with null is an empty string, or a zero duration.
+	Update UpdateTaskRequest
+
+	ProjectID, SectionID, ParentID string
+	Order                          int
+}
+
+// ParseTaskArgs reads the args of an item_add or item_update command. It
+// is the inverse of AddTaskCommand and UpdateTaskCommand, and rejects
+// arguments outside the sync item schema.
+func ParseTaskArgs(args map[string]any) (*TaskArgs, error) {
+	for k := range args {
+		if !taskArgKeys[k] {
+			return nil, fmt.Errorf("unknown argument: %s", k)
+		}
+	}
+	var v struct {
+		Content     *string   `json:"content"`
+		Description *string   `json:"description"`
+		ProjectID   string    `json:"project_id"`
+		SectionID   string    `json:"section_id"`
+		ParentID    string    `json:"parent_id"`
+		ChildOrder  int       `json:"child_order"`
+		Labels      *[]string `json:"labels"`
+		Priority    *int      `json:"priority"`
+		Due         *struct {
+			Date   string `json:"date"`
+			String string `json:"string"`
+			Lang   string `json:"lang"`
+		} `json:"due"`
+		Deadline *struct {
+			Date string `json:"date"`
+		} `json:"deadline"`
+		Duration       *Duration `json:"duration"`
+		ResponsibleUID *string   `json:"responsible_uid"`
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode args: %w", err)
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("invalid args: %w", err)
+	}
+
+	ta := &TaskArgs{ProjectID: v.ProjectID, SectionID: v.SectionID, ParentID: v.ParentID, Order: v.ChildOrder}
+	u := &ta.Update
+	u.Content, u.Description, u.Labels, u.Priority = v.Content, v.Description, v.Labels, v.Priority
+	empty := ""
+	if _, ok := args["due"]; ok {
+		switch {
+		case v.Due == nil:
+			u.DueString = &empty
+		case strings.Contains(v.Due.Date, "T"):
+			u.DueDatetime = &v.Due.Date
+		case v.Due.Date != "":
+			u.DueDate = &v.Due.Date
+		case v.Due.String != "":
+			u.DueString = &v.Due.String
+		default:
+			return nil, fmt.Errorf("due needs a date or string")
+		}
+		if v.Due != nil && v.Due.Lang != "" {
+			u.DueLang = &v.Due.Lang
+		}
+	}
+	if _, ok := args["deadline"]; ok {
+		u.DeadlineDate = &empty
+		if v.Deadline != nil {
+			if v.Deadline.Date == "" {
+				return nil, fmt.Errorf("deadline needs a date")
+			}
+			u.DeadlineDate = &v.Deadline.Date
+		}
+	}
+	if _, ok := args["duration"]; ok {
+		amount, unit := 0, ""
+		if v.Duration != nil {
+			amount, unit = v.Duration.Amount, v.Duration.Unit
+		}
+		u.Duration, u.DurationUnit = &amount, &unit
+	}
+	if _, ok := args["responsible_uid"]; ok {
+		u.AssigneeID = &empty
+		if v.ResponsibleUID != nil {
+			u.AssigneeID = v.ResponsibleUID
+		}
+	}
+	return ta, nil
+}
+
+// MoveTaskCommand returns an item_move command for taskID. Exactly one
+// destination in req should be set.
+func MoveTaskCommand(taskID string, req *MoveTaskRequest) SyncCommand {
+	args := toArgs(req)
+	args["id"] = taskID
+	return NewCommand(CommandItemMove, args)
+}
+
+// CloseTaskCommand returns an item_close command for taskID.
+func CloseTaskCommand(taskID string) SyncCommand {
+	return NewCommand(CommandItemClose, map[string]any{"id": taskID})
+}
+
+// ReopenTaskCommand returns an item_uncomplete command for taskID.
+func ReopenTaskCommand(taskID string) SyncCommand {
+	return NewCommand(CommandItemUncomplete, map[string]any{"id": taskID})
+}
+
+// DeleteTaskCommand returns an item_delete command for taskID.
+func DeleteTaskCommand(taskID string) SyncCommand {
+	return NewCommand(CommandItemDelete, map[string]any{"id": taskID})
+}
+
+// toArgs converts a request struct to command args using its JSON tags,
+// so omitted fields stay omitted. Only requests whose tags match the sync
+// schema, such as MoveTaskRequest, can go through it.
+func toArgs(v any) map[string]any {
+	args := map[string]any{}
+	data, err := json.Marshal(v)
+	if err == nil {
+		json.Unmarshal(data, &args)
+	}
+	return args
+}
+
+// dueArg returns the sync due object for the REST due fields: a date or
+// datetime if given, otherwise a string to parse. It returns nil if all
+// are empty.
+func dueArg(str, date, datetime, lang string) map[string]any {
+	due := map[string]any{}
+	switch {
+	case datetime != "":
+		due["date"] = datetime
+	case date != "":
+		due["date"] = date
+	case str != "":
+		due["string"] = str
+	default:
+		return nil
+	}
+	if lang != "" {
+		due["lang"] = lang
+	}
+	return due
+}
+
+// setString sets args[key] to v unless v is empty.
+func setString(args map[string]any, key, v string) {
+	if v != "" {
+		args[key] = v
+	}
+}
+
+// nilIfEmpty returns nil, which marshals as null, for an empty string.
+func nilIfEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// deref returns *s, or "" if s is nil.
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// newUUID returns a random version 4 UUID.
+func newUUID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// SyncResources are the resource types a SyncState keeps.
+var SyncResources = []string{ResourceItems, ResourceProjects, ResourceSections, ResourceLabels, ResourceUser, ResourceCollaborators}
+
+// SyncState is a local copy of an account kept current by incremental
+// syncs. The zero value is empty and does a full sync first.
+type SyncState struct {
+	SyncToken string              `json:"sync_token"`
+	SyncedAt  time.Time           `json:"synced_at"`
+	Items     map[string]*Task    `json:"items"`
+	Projects  map[string]*Project `json:"projects"`
+	Sections  map[string]*Section `json:"sections"`
+	Labels    map[string]*Label   `json:"labels"`
+
+	// User and Collaborators answer "assigned to:" filters offline.
+	User          *User                    `json:"user,omitempty"`
+	Collaborators map[string]*Collaborator `json:"collaborators,omitempty"`
+}
+
+// Sync fetches changes since the last sync (everything, the first time)
+// and applies them. It reports whether the server sent a full sync.
+func (s *SyncState) Sync(ctx context.Context, client TodoistAPI) (full bool, err error) {
+	resp, err := client.Sync(ctx, &SyncRequest{SyncToken: s.SyncToken, ResourceTypes: SyncResources})
+	if err != nil {
+		return false, err
+	}
+	s.Apply(resp)
+	s.SyncedAt = time.Now().UTC()
+	return resp.FullSync, nil
+}
+
+// Apply merges a sync response into the state. A full sync replaces every
+// collection; an incremental one updates changed objects and drops deleted
+// ones. Completed tasks are dropped too, since the state holds active tasks.
+func (s *SyncState) Apply(resp *SyncResponse) {
+	if resp.FullSync || s.Items == nil {
+		s.Items = map[string]*Task{}
+		s.Projects = map[string]*Project{}
+		s.Sections = map[string]*Section{}
+		s.Labels = map[string]*Label{}
+		s.Collaborators = map[string]*Collaborator{}
+	}
+	if s.Collaborators == nil {
+		// A state saved before collaborators were kept.
+		s.Collaborators = map[string]*Collaborator{}
+	}
+	for _, t := range resp.Items {
+		if t.IsDeleted || t.IsCompleted {
+			delete(s.Items, t.ID)
+		} else {
+			s.Items[t.ID] = t
+		}
+	}
+	for _, p := range resp.Projects {
+		if p.IsDeleted || p.IsArchived {
+			delete(s.Projects, p.ID)
+		} else {
+			s.Projects[p.ID] = p
+		}
+	}
+	for _, sec := range resp.Sections {
+		if sec.IsDeleted || sec.IsArchived {
+			delete(s.Sections, sec.ID)
+		} else {
+			s.Sections[sec.ID] = sec
+		}
+	}
+	for _, l := range resp.Labels {
+		if l.IsDeleted {
+			delete(s.Labels, l.ID)
+		} else {
+			s.Labels[l.ID] = l
+		}
+	}
+	for _, c := range resp.Collaborators {
+		s.Collaborators[c.ID] = c
+	}
+	if resp.User != nil {
+		s.User = resp.User
+	}
+	s.SyncToken = resp.SyncToken
+}
+
+// Tasks returns the active tasks in project and child order.
+func (s *SyncState) Tasks() []*Task {
+	tasks := mapValues(s.Items)
+	slices.SortStableFunc(tasks, func(a, b *Task) int {
+		if c := strings.Compare(a.ProjectID, b.ProjectID); c != 0 {
+			return c
+		}
+		return a.Order - b.Order
+	})
+	return tasks
+}
+
+// ProjectList returns the active projects in child order.
+func (s *SyncState) ProjectList() []*Project {
+	projects := mapValues(s.Projects)
+	slices.SortStableFunc(projects, func(a, b *Project) int { return a.Order - b.Order })
+	return projects
+}
+
+// SectionList returns the active sections in section order.
+func (s *SyncState) SectionList() []*Section {
+	sections := mapValues(s.Sections)
+	slices.SortStableFunc(sections, func(a, b *Section) int { return a.Order - b.Order })
+	return sections
+}
+
+// LabelList returns the personal labels in label order.
+func (s *SyncState) LabelList() []*Label {
+	labels := mapValues(s.Labels)
+	slices.SortStableFunc(labels, func(a, b *Label) int { return a.Order - b.Order })
+	return labels
+}
+
+// CollaboratorList returns everyone the account shares a project with,
+// by ID.
+func (s *SyncState) CollaboratorList() []*Collaborator {
+	return mapValues(s.Collaborators)
+}
+
+// UserID returns the ID of the account's user, or "" if no sync has
+// reported it yet.
+func (s *SyncState) UserID() string {
+	if s.User == nil {
+		return ""
+	}
+	return s.User.ID
+}
+
+// mapValues returns the values of m sorted by key, so ties in the
+// callers' stable sorts resolve the same way every time.
+func mapValues[T any](m map[string]T) []T {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	values := make([]T, 0, len(m))
+	for _, k := range keys {
+		values = append(values, m[k])
+	}
+	return values
+}
+
+// LoadSyncState reads a state saved by Save. A missing file yields an
+// empty state, which does a full sync.
+func LoadSyncState(path string) (*SyncState, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &SyncState{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync state: %w", err)
+	}
+	var s SyncState
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse sync state: %w", err)
+	}
+	return &s, nil
+}
+
+// Save writes the state to path with owner-only permissions, since it
+// holds the account's tasks.
+func (s *SyncState) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create sync state directory: %w", err)
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to encode sync state: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write sync state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,259 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestCommandStatus_UnmarshalJSON(t *testing.T) {
+	var resp SyncResponse
+	data := `{"sync_status": {"a": "ok", "b": {"error_code": 20, "error": "Item not found", "http_code": 404}}}`
+	if err := json.Unmarshal([]byte(data), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := resp.Err(SyncCommand{UUID: "a"}); err != nil {
+		t.Errorf("Err(a) = %v, want nil", err)
+	}
+	if err := resp.Err(SyncCommand{UUID: "b"}); !IsNotFoundError(err) {
+		t.Errorf("Err(b) = %v, want 404", err)
+	}
+	if err := resp.Err(SyncCommand{UUID: "c"}); err == nil {
+		t.Error("Err(c) = nil, want an error for a missing status")
+	}
+
+	out, err := json.Marshal(resp.SyncStatus["a"])
+	if err != nil || string(out) != `"ok"` {
+		t.Errorf("Marshal(ok) = %s, %v", out, err)
+	}
+}
+
+func TestClient_Sync(t *testing.T) {
+	var form url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sync" || r.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+			t.Errorf("got %s %s (%s)", r.Method, r.URL.Path, r.Header.Get("Content-Type"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		form, _ = url.ParseQuery(string(body))
+
+		var cmds []SyncCommand
+		json.Unmarshal([]byte(form.Get("commands")), &cmds)
+		status := map[string]string{}
+		for _, c := range cmds {
+			status[c.UUID] = "ok"
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"sync_token":      "tok-2",
+			"full_sync":       true,
+			"items":           []map[string]any{{"id": "1", "content": "Buy milk"}},
+			"sync_status":     status,
+			"temp_id_mapping": map[string]string{cmds[0].TempID: "99"},
+		})
+	}))
+	defer srv.Close()
+
+	c, err := NewClient("test-token", WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	add := AddTaskCommand(&CreateTaskRequest{Content: "Buy milk"})
+	resp, err := c.Sync(context.Background(), &SyncRequest{ResourceTypes: []string{ResourceItems}, Commands: []SyncCommand{add}})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	if got := form.Get("sync_token"); got != FullSyncToken {
+		t.Errorf("sync_token = %q, want %q", got, FullSyncToken)
+	}
+	if got := form.Get("resource_types"); got != `["items"]` {
+		t.Errorf("resource_types = %q", got)
+	}
+	if !resp.FullSync || resp.SyncToken != "tok-2" || len(resp.Items) != 1 {
+		t.Errorf("response = %+v", resp)
+	}
+	if err := resp.Err(add); err != nil {
+		t.Errorf("Err(add) = %v", err)
+	}
+	if got := resp.TempIDMapping[add.TempID]; got != "99" {
+		t.Errorf("temp id mapped to %q, want 99", got)
+	}
+
+	tooMany := make([]SyncCommand, MaxSyncCommands+1)
+	if _, err := c.Sync(context.Background(), &SyncRequest{Commands: tooMany}); err == nil {
+		t.Error("Sync() with too many commands succeeded")
+	}
+}
+
+func TestCommandArgs(t *testing.T) {
+	move := MoveTaskCommand("42", &MoveTaskRequest{SectionID: "7"})
+	if move.Type != CommandItemMove || move.Args["id"] != "42" || move.Args["section_id"] != "7" {
+		t.Errorf("MoveTaskCommand() = %+v", move)
+	}
+	if _, ok := move.Args["project_id"]; ok {
+		t.Error("unset destination was sent")
+	}
+
+	content := "New"
+	update := UpdateTaskCommand("42", &UpdateTaskRequest{Content: &content})
+	if update.Args["content"] != "New" || len(update.Args) != 2 {
+		t.Errorf("UpdateTaskCommand() args = %v", update.Args)
+	}
+
+	a, b := CloseTaskCommand("1"), CloseTaskCommand("1")
+	if a.UUID == b.UUID || len(a.UUID) != 36 {
+		t.Errorf("UUIDs %q and %q are not distinct v4 UUIDs", a.UUID, b.UUID)
+	}
+}
+
+func TestTaskCommandArgs(t *testing.T) {
+	str := func(s string) *string { return &s }
+	amount := 90
+
+	tests := []struct {
+		name string
+		cmd  SyncCommand
+		want string
+	}{
+		{
+			name: "add with due string, deadline and assignee",
+			cmd: AddTaskCommand(&CreateTaskRequest{Content: "Report", ProjectID: "7", Order: 2, Priority: 4,
+				DueString: "tomorrow", DueLang: "en", DeadlineDate: "2026-03-13", AssigneeID: "u1"}),
+			want: `{"child_order":2,"content":"Report","deadline":{"date":"2026-03-13"},"due":{"lang":"en","string":"tomorrow"},"priority":4,"project_id":"7","responsible_uid":"u1"}`,
+		},
+		{
+			name: "add with due datetime",
+			cmd:  AddTaskCommand(&CreateTaskRequest{Content: "Call", DueDatetime: "2026-03-10T15:00:00Z"}),
+			want: `{"content":"Call","due":{"date":"2026-03-10T15:00:00Z"}}`,
+		},
+		{
+			name: "update due date, deadline, duration and assignee",
+			cmd: UpdateTaskCommand("42", &UpdateTaskRequest{DueDate: str("2026-03-12"), DeadlineDate: str("2026-03-20"),
+				Duration: &amount, DurationUnit: str("minute"), AssigneeID: str("u2")}),
+			want: `{"deadline":{"date":"2026-03-20"},"due":{"date":"2026-03-12"},"duration":{"amount":90,"unit":"minute"},"id":"42","responsible_uid":"u2"}`,
+		},
+		{
+			name: "update clears",
+			cmd:  UpdateTaskCommand("42", &UpdateTaskRequest{DueString: str(""), DeadlineDate: str(""), AssigneeID: str("")}),
+			want: `{"deadline":null,"due":null,"id":"42","responsible_uid":null}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.cmd.Args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("args = %s\nwant   %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTaskArgs(t *testing.T) {
+	str := func(s string) *string { return &s }
+	amount := 2
+
+	add := AddTaskCommand(&CreateTaskRequest{Content: "Report", SectionID: "s1", Order: 3, DueString: "tomorrow", DeadlineDate: "2026-03-13"})
+	got, err := ParseTaskArgs(add.Args)
+	if err != nil {
+		t.Fatalf("ParseTaskArgs(add) error = %v", err)
+	}
+	if *got.Update.Content != "Report" || got.SectionID != "s1" || got.Order != 3 ||
+		*got.Update.DueString != "tomorrow" || *got.Update.DeadlineDate != "2026-03-13" {
+		t.Errorf("ParseTaskArgs(add) = %+v", got)
+	}
+
+	update := UpdateTaskCommand("42", &UpdateTaskRequest{DueDatetime: str("2026-03-12T15:00:00Z"), DeadlineDate: str(""),
+		Duration: &amount, DurationUnit: str("day"), AssigneeID: str("")})
+	// Args go through the journal as JSON; read them back the same way.
+	var args map[string]any
+	data, _ := json.Marshal(update.Args)
+	json.Unmarshal(data, &args)
+	got, err = ParseTaskArgs(args)
+	if err != nil {
+		t.Fatalf("ParseTaskArgs(update) error = %v", err)
+	}
+	u := got.Update
+	if *u.DueDatetime != "2026-03-12T15:00:00Z" || *u.DeadlineDate != "" || *u.Duration != 2 || *u.DurationUnit != "day" || *u.AssigneeID != "" {
+		t.Errorf("ParseTaskArgs(update) = %+v", u)
+	}
+
+	if _, err := ParseTaskArgs(map[string]any{"content": "x", "due_string": "today"}); err == nil {
+		t.Error("ParseTaskArgs() accepted the REST due_string")
+	}
+}
+
+func TestSyncState_Apply(t *testing.T) {
+	var s SyncState
+	s.Apply(&SyncResponse{
+		SyncToken: "t1",
+		FullSync:  true,
+		Items:     []*Task{{ID: "1", Content: "a", Order: 2}, {ID: "2", Content: "b", Order: 1}},
+		Projects:  []*Project{{ID: "p1", Name: "Inbox"}},
+		Sections:  []*Section{{ID: "s1", Name: "Later"}},
+		Labels:    []*Label{{ID: "l1", Name: "home"}},
+	})
+
+	// Incremental: one task edited, one deleted, one completed, one new.
+	s.Apply(&SyncResponse{
+		SyncToken: "t2",
+		Items: []*Task{
+			{ID: "1", Content: "a2", Order: 2},
+			{ID: "2", IsDeleted: true},
+			{ID: "3", Content: "c", Order: 3},
+			{ID: "4", Content: "d", IsCompleted: true},
+		},
+		Projects: []*Project{{ID: "p2", Name: "Work"}},
+		Sections: []*Section{{ID: "s1", IsArchived: true}},
+	})
+
+	if s.SyncToken != "t2" {
+		t.Errorf("SyncToken = %q, want t2", s.SyncToken)
+	}
+	var contents []string
+	for _, task := range s.Tasks() {
+		contents = append(contents, task.Content)
+	}
+	if !slices.Equal(contents, []string{"a2", "c"}) {
+		t.Errorf("tasks = %v, want [a2 c]", contents)
+	}
+	if len(s.Projects) != 2 || len(s.Sections) != 0 || len(s.Labels) != 1 {
+		t.Errorf("projects=%d sections=%d labels=%d, want 2 0 1", len(s.Projects), len(s.Sections), len(s.Labels))
+	}
+
+	// A full sync replaces everything.
+	s.Apply(&SyncResponse{SyncToken: "t3", FullSync: true, Items: []*Task{{ID: "9"}}})
+	if len(s.Items) != 1 || len(s.Projects) != 0 || len(s.Labels) != 0 {
+		t.Errorf("full sync kept stale objects: %+v", s)
+	}
+}
+
+func TestSyncState_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "sync.json")
+
+	s, err := LoadSyncState(path)
+	if err != nil || s.SyncToken != "" {
+		t.Fatalf("LoadSyncState(missing) = %+v, %v, want empty state", s, err)
+	}
+
+	s.Apply(&SyncResponse{SyncToken: "t1", FullSync: true, Items: []*Task{{ID: "1", Content: "a"}}})
+	if err := s.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := LoadSyncState(path)
+	if err != nil {
+		t.Fatalf("LoadSyncState() error = %v", err)
+	}
+	if loaded.SyncToken != "t1" || loaded.Items["1"].Content != "a" {
+		t.Errorf("loaded = %+v", loaded)
+	}
+}
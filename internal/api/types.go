@@ -1,5 +1,7 @@
 package api
 
+import "encoding/json"
+
 // Task represents a Todoist task (API v1).
 type Task struct {
 	ID          string    `json:"id"`
@@ -21,6 +23,7 @@ type Task struct {
 	AssigneeID  string    `json:"responsible_uid,omitempty"`
 	AssignerID  string    `json:"assigned_by_uid,omitempty"`
 	Duration    *Duration `json:"duration,omitempty"`
+	IsDeleted   bool      `json:"is_deleted,omitempty"`
 }
 
 // Due represents a task's due date.
@@ -57,6 +60,19 @@ type Project struct {
 	ViewStyle      string `json:"view_style"` // "list", "board", or "calendar"
 	ParentID       string `json:"parent_id,omitempty"`
 	CreatorID      string `json:"creator_uid,omitempty"`
+	IsArchived     bool   `json:"is_archived,omitempty"`
+	IsDeleted      bool   `json:"is_deleted,omitempty"`
+}
+
+// Section represents a Todoist project section (API v1).
+type Section struct {
+	ID          string `json:"id"`
+	ProjectID   string `json:"project_id"`
+	Name        string `json:"name"`
+	Order       int    `json:"section_order"`
+	IsArchived  bool   `json:"is_archived"`
+	IsCollapsed bool   `json:"is_collapsed"`
+	IsDeleted   bool   `json:"is_deleted,omitempty"`
 }
 
 // CompletedTask represents a completed task from the Todoist API v1.
@@ -84,10 +100,145 @@ type Label struct {
 	Color      string `json:"color"`
 	Order      int    `json:"order"`
 	IsFavorite bool   `json:"is_favorite"`
+	IsDeleted  bool   `json:"is_deleted,omitempty"`
+}
+
+// Collaborator represents a user with access to a shared project.
+type Collaborator struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// UnmarshalJSON also accepts the sync endpoint's form, which names the
+// user's name full_name.
+func (c *Collaborator) UnmarshalJSON(data []byte) error {
+	type collaborator Collaborator
+	var v struct {
+		collaborator
+		FullName string `json:"full_name"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*c = Collaborator(v.collaborator)
+	if c.Name == "" {
+		c.Name = v.FullName
+	}
+	return nil
+}
+
+// User is the account the API token belongs to.
+type User struct {
+	ID       string `json:"id"`
+	FullName string `json:"full_name"`
+	Email    string `json:"email"`
+}
+
+// Comment represents a comment on a task or project (API v1).
+// Exactly one of TaskID and ProjectID is set.
+type Comment struct {
+	ID         string      `json:"id"`
+	TaskID     string      `json:"item_id,omitempty"`
+	ProjectID  string      `json:"project_id,omitempty"`
+	Content    string      `json:"content"`
+	PostedAt   string      `json:"posted_at"`
+	PostedUID  string      `json:"posted_uid"`
+	Attachment *Attachment `json:"file_attachment,omitempty"`
+}
+
+// Attachment represents a file attached to a comment, as returned by the uploads endpoint.
+type Attachment struct {
+	FileName     string `json:"file_name"`
+	FileSize     int64  `json:"file_size,omitempty"`
+	FileType     string `json:"file_type,omitempty"`
+	FileURL      string `json:"file_url"`
+	ResourceType string `json:"resource_type,omitempty"`
+	UploadState  string `json:"upload_state,omitempty"`
+}
+
+// CreateCommentRequest represents the payload for creating a comment.
+// Set exactly one of TaskID and ProjectID.
+type CreateCommentRequest struct {
+	Content    string      `json:"content"`
+	TaskID     string      `json:"task_id,omitempty"`
+	ProjectID  string      `json:"project_id,omitempty"`
+	Attachment *Attachment `json:"attachment,omitempty"`
+}
+
+// Colors lists the color names accepted by the API for projects and labels.
+var Colors = []string{
+	"berry_red", "red", "orange", "yellow", "olive_green",
+	"lime_green", "green", "mint_green", "teal", "sky_blue",
+	"light_blue", "blue", "grape", "violet", "lavender",
+	"magenta", "salmon", "charcoal", "grey", "taupe",
+}
+
+// IsValidColor returns true if name is one of the API color names.
+func IsValidColor(name string) bool {
+	for _, c := range Colors {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateLabelRequest represents the payload for creating a personal label.
+type CreateLabelRequest struct {
+	Name       string `json:"name"`
+	Color      string `json:"color,omitempty"`
+	Order      int    `json:"order,omitempty"`
+	IsFavorite bool   `json:"is_favorite,omitempty"`
+}
+
+// UpdateLabelRequest represents the payload for updating a personal label.
+// Nil fields are omitted, so only the fields that were set are sent.
+type UpdateLabelRequest struct {
+	Name       *string `json:"name,omitempty"`
+	Color      *string `json:"color,omitempty"`
+	Order      *int    `json:"order,omitempty"`
+	IsFavorite *bool   `json:"is_favorite,omitempty"`
 }
 
 // CreateProjectRequest represents the payload for creating a project.
 type CreateProjectRequest struct {
+	Name       string `json:"name"`
+	ParentID   string `json:"parent_id,omitempty"`
+	Color      string `json:"color,omitempty"`
+	IsFavorite bool   `json:"is_favorite,omitempty"`
+	ViewStyle  string `json:"view_style,omitempty"` // "list", "board", or "calendar"
+}
+
+// UpdateProjectRequest represents the payload for updating a project.
+// Nil fields are omitted, so only the fields that were set are sent.
+type UpdateProjectRequest struct {
+	Name       *string `json:"name,omitempty"`
+	Color      *string `json:"color,omitempty"`
+	IsFavorite *bool   `json:"is_favorite,omitempty"`
+	ViewStyle  *string `json:"view_style,omitempty"`
+}
+
+// ViewStyles lists the project view styles accepted by the API.
+var ViewStyles = []string{"list", "board", "calendar"}
+
+// MoveTaskRequest represents the payload for moving a task.
+// Set exactly one destination field.
+type MoveTaskRequest struct {
+	ProjectID string `json:"project_id,omitempty"`
+	SectionID string `json:"section_id,omitempty"`
+	ParentID  string `json:"parent_id,omitempty"`
+}
+
+// CreateSectionRequest represents the payload for creating a section.
+type CreateSectionRequest struct {
+	Name      string `json:"name"`
+	ProjectID string `json:"project_id"`
+	Order     int    `json:"order,omitempty"`
+}
+
+// UpdateSectionRequest represents the payload for renaming a section.
+type UpdateSectionRequest struct {
 	Name string `json:"name"`
 }
 
@@ -108,3 +259,25 @@ type CreateTaskRequest struct {
 	AssigneeID   string   `json:"assignee_id,omitempty"`
 	DeadlineDate string   `json:"deadline_date,omitempty"`
 }
+
+// UpdateTaskRequest represents the payload for updating a task.
+// Nil fields are omitted, so only the fields that were set are sent.
+type UpdateTaskRequest struct {
+	Content      *string   `json:"content,omitempty"`
+	Description  *string   `json:"description,omitempty"`
+	Labels       *[]string `json:"labels,omitempty"`
+	Priority     *int      `json:"priority,omitempty"`
+	DueString    *string   `json:"due_string,omitempty"`
+	DueDate      *string   `json:"due_date,omitempty"`
+	DueDatetime  *string   `json:"due_datetime,omitempty"`
+	DueLang      *string   `json:"due_lang,omitempty"`
+	AssigneeID   *string   `json:"assignee_id,omitempty"`
+	DeadlineDate *string   `json:"deadline_date,omitempty"`
+	Duration     *int      `json:"duration,omitempty"`
+	DurationUnit *string   `json:"duration_unit,omitempty"` // "minute" or "day"
+}
+
+// IsEmpty returns true if no field is set.
+func (r *UpdateTaskRequest) IsEmpty() bool {
+	return *r == UpdateTaskRequest{}
+}
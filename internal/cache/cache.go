@@ -0,0 +1,392 @@
+// Package cache keeps projects, sections, labels and collaborators on disk
+// so commands that only need to turn a name into an ID don't pay an API
+// round trip on every run.
+//
+// Client wraps an api.TodoistAPI: the lookup methods read through the
+// cache, and every method that creates, changes or deletes a cached object
+// drops the affected entries. Everything else passes straight through.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+const (
+	// Dir is the cache directory name inside the config directory.
+	Dir = "cache"
+
+	// DefaultTTL is how long entries stay fresh unless configured otherwise.
+	DefaultTTL = time.Hour
+)
+
+// Cached resources, usable with Invalidate.
+const (
+	Projects      = "projects"
+	Sections      = "sections"
+	Labels        = "labels"
+	Collaborators = "collaborators"
+)
+
+// entry is the on-disk form of one cached list.
+type entry struct {
+	FetchedAt time.Time       `json:"fetched_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Entry describes one cached list, as reported by Entries.
+type Entry struct {
+	Name      string    `json:"name"`
+	Count     int       `json:"count"`
+	FetchedAt time.Time `json:"fetched_at"`
+	Expired   bool      `json:"expired"`
+}
+
+// Client is an api.TodoistAPI that caches lookups in a directory.
+type Client struct {
+	api.TodoistAPI
+
+	// Now is the clock used to age entries. It defaults to time.Now.
+	Now func() time.Time
+
+	dir string
+	ttl time.Duration
+
+	mu   sync.Mutex
+	hits map[string]bool // entries last served from disk rather than the API
+}
+
+var _ api.TodoistAPI = (*Client)(nil)
+
+// New returns a Client that caches next's lookups in dir for ttl. A ttl of
+// zero or less disables caching; the Client then only passes calls through.
+func New(next api.TodoistAPI, dir string, ttl time.Duration) *Client {
+	return &Client{TodoistAPI: next, Now: time.Now, dir: dir, ttl: ttl, hits: map[string]bool{}}
+}
+
+// Dir returns the cache directory.
+func (c *Client) Dir() string {
+	return c.dir
+}
+
+// TTL returns how long entries stay fresh. Zero means caching is disabled.
+func (c *Client) TTL() time.Duration {
+	return max(c.ttl, 0)
+}
+
+// --- cached lookups ---
+
+// GetProjects returns all active projects, from the cache when fresh.
+func (c *Client) GetProjects(ctx context.Context) ([]*api.Project, error) {
+	return load(ctx, c, Projects, c.TodoistAPI.GetProjects)
+}
+
+// GetSections returns sections, optionally limited to one project. The
+// cache holds every section and filters locally.
+func (c *Client) GetSections(ctx context.Context, projectID string) ([]*api.Section, error) {
+	all, err := load(ctx, c, Sections, func(ctx context.Context) ([]*api.Section, error) {
+		return c.TodoistAPI.GetSections(ctx, "")
+	})
+	if err != nil || projectID == "" {
+		return all, err
+	}
+	sections := []*api.Section{}
+	for _, s := range all {
+		if s.ProjectID == projectID {
+			sections = append(sections, s)
+		}
+	}
+	return sections, nil
+}
+
+// GetLabels returns all personal labels, from the cache when fresh.
+func (c *Client) GetLabels(ctx context.Context) ([]*api.Label, error) {
+	return load(ctx, c, Labels, c.TodoistAPI.GetLabels)
+}
+
+// GetCollaborators returns the users a project is shared with, from the
+// cache when fresh.
+func (c *Client) GetCollaborators(ctx context.Context, projectID string) ([]*api.Collaborator, error) {
+	return load(ctx, c, Collaborators+"-"+projectID, func(ctx context.Context) ([]*api.Collaborator, error) {
+		return c.TodoistAPI.GetCollaborators(ctx, projectID)
+	})
+}
+
+// --- invalidating writes ---
+
+// CreateProject creates a project and drops cached projects.
+func (c *Client) CreateProject(ctx context.Context, req *api.CreateProjectRequest) (*api.Project, error) {
+	defer c.Invalidate(Projects)
+	return c.TodoistAPI.CreateProject(ctx, req)
+}
+
+// UpdateProject updates a project and drops cached projects.
+func (c *Client) UpdateProject(ctx context.Context, projectID string, req *api.UpdateProjectRequest) (*api.Project, error) {
+	defer c.Invalidate(Projects)
+	return c.TodoistAPI.UpdateProject(ctx, projectID, req)
+}
+
+// ArchiveProject archives a project and drops cached projects.
+func (c *Client) ArchiveProject(ctx context.Context, projectID string) (*api.Project, error) {
+	defer c.Invalidate(Projects)
+	return c.TodoistAPI.ArchiveProject(ctx, projectID)
+}
+
+// UnarchiveProject unarchives a project and drops cached projects.
+func (c *Client) UnarchiveProject(ctx context.Context, projectID string) (*api.Project, error) {
+	defer c.Invalidate(Projects)
+	return c.TodoistAPI.UnarchiveProject(ctx, projectID)
+}
+
+// DeleteProject deletes a project and drops cached projects, along with
+// the sections and collaborators that went with it.
+func (c *Client) DeleteProject(ctx context.Context, projectID string) error {
+	defer c.Invalidate(Projects, Sections, Collaborators)
+	return c.TodoistAPI.DeleteProject(ctx, projectID)
+}
+
+// CreateSection creates a section and drops cached sections.
+func (c *Client) CreateSection(ctx context.Context, req *api.CreateSectionRequest) (*api.Section, error) {
+	defer c.Invalidate(Sections)
+	return c.TodoistAPI.CreateSection(ctx, req)
+}
+
+// UpdateSection renames a section and drops cached sections.
+func (c *Client) UpdateSection(ctx context.Context, sectionID string, req *api.UpdateSectionRequest) (*api.Section, error) {
+	defer c.Invalidate(Sections)
+	return c.TodoistAPI.UpdateSection(ctx, sectionID, req)
+}
+
+// DeleteSection deletes a section and drops cached sections.
+func (c *Client) DeleteSection(ctx context.Context, sectionID string) error {
+	defer c.Invalidate(Sections)
+	return c.TodoistAPI.DeleteSection(ctx, sectionID)
+}
+
+// CreateLabel creates a label and drops cached labels.
+func (c *Client) CreateLabel(ctx context.Context, req *api.CreateLabelRequest) (*api.Label, error) {
+	defer c.Invalidate(Labels)
+	return c.TodoistAPI.CreateLabel(ctx, req)
+}
+
+// UpdateLabel updates a label and drops cached labels.
+func (c *Client) UpdateLabel(ctx context.Context, labelID string, req *api.UpdateLabelRequest) (*api.Label, error) {
+	defer c.Invalidate(Labels)
+	return c.TodoistAPI.UpdateLabel(ctx, labelID, req)
+}
+
+// DeleteLabel deletes a label and drops cached labels.
+func (c *Client) DeleteLabel(ctx context.Context, labelID string) error {
+	defer c.Invalidate(Labels)
+	return c.TodoistAPI.DeleteLabel(ctx, labelID)
+}
+
+// RenameSharedLabel renames a label everywhere and drops cached labels,
+// since a personal label of the same name is renamed too.
+func (c *Client) RenameSharedLabel(ctx context.Context, name, newName string) error {
+	defer c.Invalidate(Labels)
+	return c.TodoistAPI.RenameSharedLabel(ctx, name, newName)
+}
+
+// RemoveSharedLabel removes a label everywhere and drops cached labels.
+func (c *Client) RemoveSharedLabel(ctx context.Context, name string) error {
+	defer c.Invalidate(Labels)
+	return c.TodoistAPI.RemoveSharedLabel(ctx, name)
+}
+
+// Sync passes the request through and drops whatever its commands may
+// have changed. Task commands don't touch cached objects.
+func (c *Client) Sync(ctx context.Context, req *api.SyncRequest) (*api.SyncResponse, error) {
+	var stale []string
+	for _, cmd := range req.Commands {
+		switch {
+		case strings.HasPrefix(cmd.Type, "project_"):
+			stale = append(stale, Projects, Sections, Collaborators)
+		case strings.HasPrefix(cmd.Type, "section_"):
+			stale = append(stale, Sections)
+		case strings.HasPrefix(cmd.Type, "label_"):
+			stale = append(stale, Labels)
+		}
+	}
+	if len(stale) > 0 {
+		defer c.Invalidate(stale...)
+	}
+	return c.TodoistAPI.Sync(ctx, req)
+}
+
+// --- cache management ---
+
+// Invalidate drops the named entries. Collaborators drops the entries of
+// every project.
+func (c *Client) Invalidate(names ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, name := range names {
+		paths := []string{c.path(name)}
+		if name == Collaborators {
+			paths, _ = filepath.Glob(filepath.Join(c.dir, Collaborators+"-*.json"))
+		}
+		for _, p := range paths {
+			if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to invalidate cache: %w", err)
+			}
+		}
+		for k := range c.hits {
+			if k == name || strings.HasPrefix(k, name+"-") {
+				delete(c.hits, k)
+			}
+		}
+	}
+	return nil
+}
+
+// Clear removes every entry.
+func (c *Client) Clear() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := os.RemoveAll(c.dir); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+	clear(c.hits)
+	return nil
+}
+
+// Refresh clears the cache and fetches projects, sections and labels
+// again. Collaborators are fetched per project when next needed.
+func (c *Client) Refresh(ctx context.Context) error {
+	if err := c.Clear(); err != nil {
+		return err
+	}
+	if _, err := c.GetProjects(ctx); err != nil {
+		return err
+	}
+	if _, err := c.GetSections(ctx, ""); err != nil {
+		return err
+	}
+	_, err := c.GetLabels(ctx)
+	return err
+}
+
+// Entries lists the cached lists by name. Unreadable files are skipped.
+func (c *Client) Entries() ([]Entry, error) {
+	files, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cache: %w", err)
+	}
+
+	entries := []Entry{}
+	for _, f := range files {
+		e, err := readEntry(f)
+		if err != nil {
+			continue
+		}
+		var items []json.RawMessage
+		json.Unmarshal(e.Data, &items)
+		entries = append(entries, Entry{
+			Name:      strings.TrimSuffix(filepath.Base(f), ".json"),
+			Count:     len(items),
+			FetchedAt: e.FetchedAt,
+			Expired:   !c.fresh(e),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries, nil
+}
+
+// Hit reports whether the last lookup of name was answered from disk. A
+// name that isn't found in a hit may just be newer than the cache.
+func (c *Client) Hit(name string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits[name]
+}
+
+// load returns the named entry when it is fresh, and otherwise calls fetch
+// and stores the result. Cache files that can't be read or written are
+// treated as misses; the cache never turns a working call into a failure.
+func load[T any](ctx context.Context, c *Client, name string, fetch func(context.Context) (T, error)) (T, error) {
+	if c.ttl > 0 {
+		if e, err := readEntry(c.path(name)); err == nil && c.fresh(e) {
+			var v T
+			if err := json.Unmarshal(e.Data, &v); err == nil {
+				c.setHit(name, true)
+				return v, nil
+			}
+		}
+	}
+
+	v, err := fetch(ctx)
+	if err != nil {
+		return v, err
+	}
+	c.setHit(name, false)
+	if c.ttl > 0 {
+		c.write(name, v)
+	}
+	return v, nil
+}
+
+func (c *Client) setHit(name string, hit bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hits[name] = hit
+}
+
+func (c *Client) fresh(e *entry) bool {
+	age := c.Now().Sub(e.FetchedAt)
+	return age >= 0 && age < c.ttl
+}
+
+func (c *Client) path(name string) string {
+	return filepath.Join(c.dir, name+".json")
+}
+
+// write stores v under name, replacing the file atomically so a
+// concurrent run never reads half an entry.
+func (c *Client) write(name string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	data, err = json.Marshal(entry{FetchedAt: c.Now().UTC(), Data: data})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(c.dir, name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(name))
+}
+
+func readEntry(path string) (*entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
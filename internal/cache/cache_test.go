@@ -0,0 +1,247 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/api/apitest"
+)
+
+// newTestCache returns a cache over a fake account, a clock the test can
+// advance, and a counter of list calls that reached the fake.
+func newTestCache(t *testing.T, ttl time.Duration) (*Client, *apitest.Fake, *time.Time, func(method string) int) {
+	t.Helper()
+	f := apitest.New()
+	c := New(f, filepath.Join(t.TempDir(), Dir), ttl)
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	c.Now = func() time.Time { return now }
+	calls := func(method string) int {
+		n := 0
+		for _, call := range f.Calls() {
+			if strings.HasPrefix(call, method) {
+				n++
+			}
+		}
+		return n
+	}
+	return c, f, &now, calls
+}
+
+func TestClient_readThrough(t *testing.T) {
+	ctx := context.Background()
+	c, f, now, calls := newTestCache(t, time.Hour)
+	f.AddProject(api.Project{Name: "Work"})
+
+	for range 3 {
+		projects, err := c.GetProjects(ctx)
+		if err != nil {
+			t.Fatalf("GetProjects() error = %v", err)
+		}
+		if len(projects) != 2 {
+			t.Fatalf("GetProjects() returned %d projects, want 2", len(projects))
+		}
+	}
+	if n := calls("GetProjects"); n != 1 {
+		t.Errorf("API called %d times, want 1", n)
+	}
+	if !c.Hit(Projects) {
+		t.Error("Hit(projects) = false after a cached read")
+	}
+
+	// A project created elsewhere shows up once the entry expires.
+	f.AddProject(api.Project{Name: "Home"})
+	*now = now.Add(time.Hour)
+	projects, _ := c.GetProjects(ctx)
+	if len(projects) != 3 || calls("GetProjects") != 2 {
+		t.Errorf("after TTL: %d projects, %d calls, want 3 and 2", len(projects), calls("GetProjects"))
+	}
+	if c.Hit(Projects) {
+		t.Error("Hit(projects) = true after a fetch")
+	}
+}
+
+func TestClient_sectionsFilteredLocally(t *testing.T) {
+	ctx := context.Background()
+	c, f, _, calls := newTestCache(t, time.Hour)
+	work := f.AddProject(api.Project{Name: "Work"})
+	f.AddSection(api.Section{Name: "Backlog", ProjectID: work.ID})
+	f.AddSection(api.Section{Name: "Later", ProjectID: f.InboxID()})
+
+	all, _ := c.GetSections(ctx, "")
+	inWork, _ := c.GetSections(ctx, work.ID)
+	if len(all) != 2 || len(inWork) != 1 || inWork[0].Name != "Backlog" {
+		t.Errorf("sections = %d all, %v in Work", len(all), inWork)
+	}
+	if n := calls("GetSections"); n != 1 {
+		t.Errorf("API called %d times, want 1", n)
+	}
+}
+
+func TestClient_invalidatesOnWrites(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name  string
+		read  func(c *Client) error
+		write func(c *Client, f *apitest.Fake) error
+	}{
+		{
+			name: "create project",
+			read: func(c *Client) error { _, err := c.GetProjects(ctx); return err },
+			write: func(c *Client, f *apitest.Fake) error {
+				_, err := c.CreateProject(ctx, &api.CreateProjectRequest{Name: "New"})
+				return err
+			},
+		},
+		{
+			name: "delete project drops sections",
+			read: func(c *Client) error { _, err := c.GetSections(ctx, ""); return err },
+			write: func(c *Client, f *apitest.Fake) error {
+				p := f.AddProject(api.Project{Name: "Old"})
+				return c.DeleteProject(ctx, p.ID)
+			},
+		},
+		{
+			name: "create section",
+			read: func(c *Client) error { _, err := c.GetSections(ctx, ""); return err },
+			write: func(c *Client, f *apitest.Fake) error {
+				_, err := c.CreateSection(ctx, &api.CreateSectionRequest{Name: "New", ProjectID: f.InboxID()})
+				return err
+			},
+		},
+		{
+			name: "delete label",
+			read: func(c *Client) error { _, err := c.GetLabels(ctx); return err },
+			write: func(c *Client, f *apitest.Fake) error {
+				return c.DeleteLabel(ctx, f.AddLabel(api.Label{Name: "old"}).ID)
+			},
+		},
+		{
+			name: "rename shared label",
+			read: func(c *Client) error { _, err := c.GetLabels(ctx); return err },
+			write: func(c *Client, f *apitest.Fake) error {
+				f.AddLabel(api.Label{Name: "old"})
+				return c.RenameSharedLabel(ctx, "old", "new")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, f, _, _ := newTestCache(t, time.Hour)
+			if err := tt.read(c); err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.read(c); err != nil || !hit(c) {
+				t.Fatalf("second read was not cached (err = %v)", err)
+			}
+			if err := tt.write(c, f); err != nil {
+				t.Fatalf("write error = %v", err)
+			}
+			if err := tt.read(c); err != nil || hit(c) {
+				t.Errorf("read after write was served from the cache (err = %v)", err)
+			}
+		})
+	}
+}
+
+// hit reports whether any lookup was last served from disk.
+func hit(c *Client) bool {
+	return c.Hit(Projects) || c.Hit(Sections) || c.Hit(Labels)
+}
+
+func TestClient_collaborators(t *testing.T) {
+	ctx := context.Background()
+	c, f, _, calls := newTestCache(t, time.Hour)
+	work := f.AddProject(api.Project{Name: "Work"})
+	f.AddCollaborator(work.ID, api.Collaborator{Name: "Ana", Email: "ana@example.com"})
+
+	for range 2 {
+		if got, err := c.GetCollaborators(ctx, work.ID); err != nil || len(got) != 1 {
+			t.Fatalf("GetCollaborators() = %v, %v", got, err)
+		}
+	}
+	if n := calls("GetCollaborators"); n != 1 {
+		t.Errorf("API called %d times, want 1", n)
+	}
+
+	if err := c.Invalidate(Collaborators); err != nil {
+		t.Fatal(err)
+	}
+	c.GetCollaborators(ctx, work.ID)
+	if n := calls("GetCollaborators"); n != 2 {
+		t.Errorf("API called %d times after Invalidate, want 2", n)
+	}
+}
+
+func TestClient_disabled(t *testing.T) {
+	ctx := context.Background()
+	c, _, _, calls := newTestCache(t, 0)
+
+	c.GetLabels(ctx)
+	c.GetLabels(ctx)
+	if n := calls("GetLabels"); n != 2 {
+		t.Errorf("API called %d times, want 2", n)
+	}
+	if _, err := os.Stat(c.Dir()); !os.IsNotExist(err) {
+		t.Errorf("disabled cache wrote to %s", c.Dir())
+	}
+}
+
+func TestClient_refreshEntriesClear(t *testing.T) {
+	ctx := context.Background()
+	c, f, now, _ := newTestCache(t, time.Hour)
+	f.AddLabel(api.Label{Name: "home"})
+
+	if err := c.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	*now = now.Add(2 * time.Hour)
+	entries, err := c.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name)
+		if !e.Expired {
+			t.Errorf("entry %s not expired after 2h", e.Name)
+		}
+	}
+	if got := strings.Join(names, ","); got != "labels,projects,sections" {
+		t.Errorf("entries = %s, want labels,projects,sections", got)
+	}
+	if entries[0].Count != 1 {
+		t.Errorf("labels count = %d, want 1", entries[0].Count)
+	}
+
+	info, err := os.Stat(filepath.Join(c.Dir(), "labels.json"))
+	if err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("entry file mode = %v, %v, want 0600", info.Mode().Perm(), err)
+	}
+
+	if err := c.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := c.Entries(); len(entries) != 0 {
+		t.Errorf("entries after Clear = %v", entries)
+	}
+}
+
+func TestClient_corruptEntryIsAMiss(t *testing.T) {
+	ctx := context.Background()
+	c, f, _, _ := newTestCache(t, time.Hour)
+	f.AddLabel(api.Label{Name: "home"})
+
+	os.MkdirAll(c.Dir(), 0700)
+	os.WriteFile(filepath.Join(c.Dir(), "labels.json"), []byte("{not json"), 0600)
+
+	labels, err := c.GetLabels(ctx)
+	if err != nil || len(labels) != 1 {
+		t.Errorf("GetLabels() = %v, %v, want the API result", labels, err)
+	}
+}
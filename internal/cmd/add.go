@@ -1,25 +1,28 @@
 package cmd
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"os"
-	"strings"
+	"io"
 
 	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/offline"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
 	"github.com/joeyhipolito/todoist-cli/internal/transform"
 )
 
 // AddCmd creates a new task.
-func AddCmd(token string, args []string, jsonOutput bool) error {
+func AddCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
 	for _, arg := range args {
 		if arg == "--help" || arg == "-h" {
-			fmt.Print(`Usage: todoist add <task name> [options]
+			fmt.Fprint(w, `Usage: todoist add <task name> [options]
 
 Options:
   --date <date>          Due date (today, tomorrow, YYYY-MM-DD)
   --priority <1-4>       Priority (1=urgent, 4=normal)
   --project <name>       Target project
+  --section <name>       Target section (within --project if given)
+  --parent <task-id>     Create as a subtask of this task
   --labels <l1,l2>       Comma-separated labels
   --json                 Output result as JSON
   --help, -h             Show this help
@@ -29,12 +32,7 @@ Options:
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("add requires a task name\n\nUsage: todoist add <task name> [--date <date>] [--priority <1-4>] [--project <name>] [--labels <l1,l2>]")
-	}
-
-	client, err := api.NewClient(token)
-	if err != nil {
-		return err
+		return fmt.Errorf("add requires a task name\n\nUsage: todoist add <task name> [--date <date>] [--priority <1-4>] [--project <name>] [--section <name>] [--labels <l1,l2>]")
 	}
 
 	// First non-flag argument is the task content
@@ -44,6 +42,7 @@ Options:
 	req := &api.CreateTaskRequest{
 		Content: content,
 	}
+	var projectName, sectionName string
 
 	// Parse flags
 	for i := 0; i < len(args); i++ {
@@ -68,46 +67,59 @@ Options:
 			if i+1 >= len(args) {
 				return fmt.Errorf("--project requires an argument")
 			}
-			// Resolve project name to ID
-			projects, err := client.GetProjects()
-			if err != nil {
-				return fmt.Errorf("failed to fetch projects: %w", err)
-			}
-			projectName := args[i+1]
-			found := false
-			for _, p := range projects {
-				if strings.EqualFold(p.Name, projectName) {
-					req.ProjectID = p.ID
-					found = true
-					break
-				}
+			projectName = args[i+1]
+			i++
+		case "--section":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--section requires an argument")
 			}
-			if !found {
-				return fmt.Errorf("project not found: %s", projectName)
+			sectionName = args[i+1]
+			i++
+		case "--parent":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--parent requires a task ID")
 			}
+			req.ParentID = args[i+1]
 			i++
 		case "--labels":
 			if i+1 >= len(args) {
 				return fmt.Errorf("--labels requires an argument")
 			}
-			req.Labels = strings.Split(args[i+1], ",")
+			req.Labels = splitLabels(args[i+1])
 			i++
 		default:
 			return fmt.Errorf("unknown flag: %s", args[i])
 		}
 	}
 
-	task, err := client.CreateTask(req)
+	// Resolve names to IDs; the section is looked up within the chosen project.
+	if projectName != "" {
+		projectID, err := resolveProjectID(ctx, client, projectName)
+		if err != nil {
+			return err
+		}
+		req.ProjectID = projectID
+	}
+	if sectionName != "" {
+		section, err := resolveSection(ctx, client, req.ProjectID, sectionName)
+		if err != nil {
+			return err
+		}
+		req.SectionID = section.ID
+	}
+
+	task, err := client.CreateTask(ctx, req)
+	if q, ok := offline.AsQueued(err); ok {
+		return reportQueued(w, q.TaskID(), "Queued task: "+req.Content, format)
+	}
 	if err != nil {
 		return err
 	}
 
-	if jsonOutput {
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(task)
+	if format != output.Text {
+		return output.Value(w, format, task)
 	}
 
-	fmt.Printf("Created task: %s (ID: %s)\n", task.Content, task.ID)
+	fmt.Fprintf(w, "Created task: %s (ID: %s)\n", task.Content, task.ID)
 	return nil
 }
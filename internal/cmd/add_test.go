@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+func TestAddCmd(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		check   func(t *testing.T, task api.Task, work *api.Project, section *api.Section, parent *api.Task)
+		wantErr string
+	}{
+		{
+			name: "inbox by default",
+			args: []string{"Buy milk"},
+			check: func(t *testing.T, task api.Task, _ *api.Project, _ *api.Section, _ *api.Task) {
+				if task.Content != "Buy milk" || task.Priority != 1 {
+					t.Errorf("task = %q p%d, want Buy milk p1", task.Content, task.Priority)
+				}
+			},
+		},
+		{
+			name: "project priority labels and date",
+			args: []string{"Ship it", "--project", "work", "--priority", "1", "--labels", "deep, focus", "--date", "tomorrow"},
+			check: func(t *testing.T, task api.Task, work *api.Project, _ *api.Section, _ *api.Task) {
+				if task.ProjectID != work.ID {
+					t.Errorf("ProjectID = %q, want %q", task.ProjectID, work.ID)
+				}
+				if task.Priority != 4 {
+					t.Errorf("Priority = %d, want 4 (UI p1 is API 4)", task.Priority)
+				}
+				if !slices.Equal(task.Labels, []string{"deep", "focus"}) {
+					t.Errorf("Labels = %v, want [deep focus]", task.Labels)
+				}
+				if task.Due == nil || task.Due.Date != "2026-03-11" {
+					t.Errorf("Due = %+v, want 2026-03-11", task.Due)
+				}
+			},
+		},
+		{
+			name: "section within project",
+			args: []string{"Draft", "--project", "Work", "--section", "Doing"},
+			check: func(t *testing.T, task api.Task, work *api.Project, section *api.Section, _ *api.Task) {
+				if task.ProjectID != work.ID || task.SectionID != section.ID {
+					t.Errorf("task in %s/%s, want %s/%s", task.ProjectID, task.SectionID, work.ID, section.ID)
+				}
+			},
+		},
+		{
+			name: "subtask",
+			args: []string{"Child", "--parent", "PARENT"},
+			check: func(t *testing.T, task api.Task, work *api.Project, _ *api.Section, parent *api.Task) {
+				if task.ParentID != parent.ID || task.ProjectID != work.ID {
+					t.Errorf("task parent/project = %s/%s, want %s/%s", task.ParentID, task.ProjectID, parent.ID, work.ID)
+				}
+			},
+		},
+		{name: "missing name", args: nil, wantErr: "requires a task name"},
+		{name: "unknown project", args: []string{"x", "--project", "Nope"}, wantErr: "not found"},
+		{name: "bad priority", args: []string{"x", "--priority", "9"}, wantErr: "priority"},
+		{name: "unknown flag", args: []string{"x", "--bogus"}, wantErr: "unknown flag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFake()
+			work := f.AddProject(api.Project{Name: "Work"})
+			section := f.AddSection(api.Section{Name: "Doing", ProjectID: work.ID})
+			parent := f.AddTask(api.Task{Content: "Parent", ProjectID: work.ID})
+
+			args := slices.Clone(tt.args)
+			for i, a := range args {
+				if a == "PARENT" {
+					args[i] = parent.ID
+				}
+			}
+
+			out, err := run(t, AddCmd, f, true, args...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("AddCmd() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AddCmd() error = %v", err)
+			}
+
+			var created api.Task
+			if err := json.Unmarshal([]byte(out), &created); err != nil {
+				t.Fatalf("output is not a task: %v\n%s", err, out)
+			}
+			stored, ok := f.Task(created.ID)
+			if !ok {
+				t.Fatalf("task %s was not stored", created.ID)
+			}
+			tt.check(t, stored, work, section, parent)
+		})
+	}
+}
+
+func TestAddCmd_text(t *testing.T) {
+	f := newFake()
+	out, err := run(t, AddCmd, f, false, "Buy milk")
+	if err != nil {
+		t.Fatalf("AddCmd() error = %v", err)
+	}
+	if !strings.HasPrefix(out, "Created task: Buy milk (ID: ") {
+		t.Errorf("output = %q", out)
+	}
+}
@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
+	"github.com/joeyhipolito/todoist-cli/internal/transform"
+)
+
+// agenda is the planning view AgendaCmd prints: overdue tasks, then each
+// day in the range with the tasks due on it.
+type agenda struct {
+	From    string       `json:"from"`
+	To      string       `json:"to"`
+	Overdue []*api.Task  `json:"overdue"`
+	Days    []*agendaDay `json:"days"`
+}
+
+type agendaDay struct {
+	Date           string      `json:"date"`
+	PlannedMinutes int         `json:"planned_minutes"`
+	Overbooked     bool        `json:"overbooked"`
+	Tasks          []*api.Task `json:"tasks"`
+}
+
+// AgendaCmd shows tasks due over a range of days, grouped under a heading
+// for each day, with overdue tasks pinned above.
+func AgendaCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
+	days, hours := 7, 8
+	var fromArg, toArg, projectName string
+	daysSet := false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--days", "--hours":
+			if i+1 >= len(args) {
+				return fmt.Errorf("%s requires a number", args[i])
+			}
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil || n <= 0 {
+				return fmt.Errorf("%s must be a positive integer", args[i])
+			}
+			if args[i] == "--days" {
+				days, daysSet = n, true
+			} else {
+				hours = n
+			}
+			i++
+		case "--from":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--from requires a date (YYYY-MM-DD)")
+			}
+			fromArg = args[i+1]
+			i++
+		case "--to":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--to requires a date (YYYY-MM-DD)")
+			}
+			toArg = args[i+1]
+			i++
+		case "--project":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--project requires a name")
+			}
+			projectName = args[i+1]
+			i++
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+
+	current := now()
+	loc := current.Location()
+	today := time.Date(current.Year(), current.Month(), current.Day(), 0, 0, 0, 0, loc)
+
+	from := today
+	if fromArg != "" {
+		d, err := time.ParseInLocation("2006-01-02", fromArg, loc)
+		if err != nil {
+			return fmt.Errorf("--from must be a date (YYYY-MM-DD): %s", fromArg)
+		}
+		from = d
+	}
+	to := from.AddDate(0, 0, days-1)
+	if toArg != "" {
+		if daysSet {
+			return fmt.Errorf("use --days or --to, not both")
+		}
+		d, err := time.ParseInLocation("2006-01-02", toArg, loc)
+		if err != nil {
+			return fmt.Errorf("--to must be a date (YYYY-MM-DD): %s", toArg)
+		}
+		to = d
+	}
+	if to.Before(from) {
+		return fmt.Errorf("--to must not be before --from")
+	}
+
+	var projectID string
+	if projectName != "" {
+		id, err := resolveProjectID(ctx, client, projectName)
+		if err != nil {
+			return err
+		}
+		projectID = id
+	}
+
+	// Days before today are covered by the overdue section.
+	start := from
+	if start.Before(today) {
+		start = today
+	}
+	query := "overdue"
+	if !to.Before(start) {
+		query = fmt.Sprintf("overdue | (due after: %s & due before: %s)",
+			start.AddDate(0, 0, -1).Format("2006-01-02"), to.AddDate(0, 0, 1).Format("2006-01-02"))
+	}
+	tasks, err := client.GetTasks(ctx, query, projectID, "")
+	if err != nil {
+		return err
+	}
+
+	a := buildAgenda(tasks, today, start, to, hours)
+	a.From, a.To = from.Format("2006-01-02"), to.Format("2006-01-02")
+
+	if format == output.JSON || format == output.YAML {
+		return output.Value(w, format, a)
+	}
+	if format != output.Text {
+		var all []*api.Task
+		all = append(all, a.Overdue...)
+		for _, d := range a.Days {
+			all = append(all, d.Tasks...)
+		}
+		return output.List(w, format, all, agendaColumns(loc))
+	}
+
+	projects, err := client.GetProjects(ctx)
+	if err != nil {
+		return err
+	}
+	line, err := newTaskLine(w, projects)
+	if err != nil {
+		return err
+	}
+
+	if len(a.Overdue) > 0 {
+		fmt.Fprintf(w, "Overdue: %s\n", plural(len(a.Overdue), "task"))
+		for _, t := range a.Overdue {
+			fmt.Fprintln(w, "       "+line.Format(t))
+		}
+		fmt.Fprintln(w)
+	}
+
+	undated := *line
+	undated.NoDue = true
+	for i, d := range a.Days {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		day, _ := time.ParseInLocation("2006-01-02", d.Date, loc)
+		heading := day.Format("Mon Jan 2")
+		if label := transform.FormatDueDateAt(d.Date, "", current); label == "Today" || label == "Tomorrow" {
+			heading += " (" + label + ")"
+		}
+		if len(d.Tasks) == 0 {
+			fmt.Fprintf(w, "%s: nothing due\n", heading)
+			continue
+		}
+
+		summary := plural(len(d.Tasks), "task")
+		if d.PlannedMinutes > 0 {
+			summary += ", " + transform.FormatDuration(d.PlannedMinutes, "minute") + " planned"
+		}
+		if d.Overbooked {
+			summary += ", overbooked by " + transform.FormatDuration(d.PlannedMinutes-hours*60, "minute")
+		}
+		fmt.Fprintf(w, "%s: %s\n", heading, summary)
+
+		for _, t := range d.Tasks {
+			fmt.Fprintln(w, dayLine(&undated, t, loc))
+		}
+	}
+
+	printSnapshotNote(w, client)
+	return nil
+}
+
+// buildAgenda sorts tasks into the overdue section (due before today) and
+// one day for each date from start to end. Each day lists all-day tasks
+// first, in the API's order, then timed tasks by time. A day is
+// overbooked when its task durations add up to more than hours; a task
+// lasting whole days is listed on its due day but books hours on each
+// day it covers.
+func buildAgenda(tasks []*api.Task, today, start, end time.Time, hours int) *agenda {
+	loc := today.Location()
+	a := &agenda{Overdue: []*api.Task{}, Days: []*agendaDay{}}
+	index := map[string]*agendaDay{}
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		day := &agendaDay{Date: d.Format("2006-01-02"), Tasks: []*api.Task{}}
+		a.Days = append(a.Days, day)
+		index[day.Date] = day
+	}
+
+	for _, t := range tasks {
+		due, ok := transform.DueDay(t.Due, loc)
+		if !ok {
+			continue
+		}
+		if due.Before(today) {
+			a.Overdue = append(a.Overdue, t)
+			continue
+		}
+		day, ok := index[due.Format("2006-01-02")]
+		if !ok {
+			continue
+		}
+		day.Tasks = append(day.Tasks, t)
+		switch {
+		case t.Duration == nil:
+		case t.Duration.Unit == "day":
+			for n := 0; n < t.Duration.Amount; n++ {
+				if d, ok := index[due.AddDate(0, 0, n).Format("2006-01-02")]; ok {
+					d.PlannedMinutes += hours * 60
+				}
+			}
+		default:
+			day.PlannedMinutes += t.Duration.Amount
+		}
+	}
+
+	byTime := func(tasks []*api.Task) {
+		sort.SliceStable(tasks, func(i, j int) bool {
+			di, _ := transform.DueDay(tasks[i].Due, loc)
+			dj, _ := transform.DueDay(tasks[j].Due, loc)
+			if !di.Equal(dj) {
+				return di.Before(dj)
+			}
+			ti, timedI := transform.DueTime(tasks[i].Due, loc)
+			tj, timedJ := transform.DueTime(tasks[j].Due, loc)
+			if timedI != timedJ {
+				return !timedI // all-day first
+			}
+			return ti.Before(tj)
+		})
+	}
+	byTime(a.Overdue)
+	for _, d := range a.Days {
+		byTime(d.Tasks)
+		d.Overbooked = d.PlannedMinutes > hours*60
+	}
+	return a
+}
+
+// dayLine renders t under a day heading: its due time, if any, in a
+// column before the task, and its duration after it.
+func dayLine(line *transform.TaskLine, t *api.Task, loc *time.Location) string {
+	clock := "     "
+	if at, ok := transform.DueTime(t.Due, loc); ok {
+		clock = at.Format("15:04")
+	}
+	duration := ""
+	if t.Duration != nil {
+		duration = " [" + transform.FormatDuration(t.Duration.Amount, t.Duration.Unit) + "]"
+	}
+	return "  " + clock + line.Format(t) + duration
+}
+
+// plural returns "1 task" or "n tasks".
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/api/apitest"
+	"github.com/joeyhipolito/todoist-cli/internal/filter"
+)
+
+// filterFake returns a fake that evaluates filters with the filter package
+// on the test clock.
+func filterFake() *apitest.Fake {
+	f := newFake()
+	f.Filter = func(query string, t *api.Task) (bool, error) {
+		q, err := filter.Parse(query)
+		if err != nil {
+			return false, err
+		}
+		return q.Match(t, &filter.Env{Now: testNow}), nil
+	}
+	return f
+}
+
+func TestAgendaCmd(t *testing.T) {
+	f := filterFake()
+	work := f.AddProject(api.Project{Name: "Work"})
+	rent := f.AddTask(api.Task{Content: "Pay rent", Due: &api.Due{Date: "2026-03-08"}})
+	standup := f.AddTask(api.Task{Content: "Standup", Due: &api.Due{Date: "2026-03-10", Datetime: "2026-03-10T15:00:00Z"},
+		Duration: &api.Duration{Amount: 15, Unit: "minute"}})
+	deep := f.AddTask(api.Task{Content: "Deep work", Due: &api.Due{Date: "2026-03-10T09:00:00"},
+		Duration: &api.Duration{Amount: 480, Unit: "minute"}})
+	plan := f.AddTask(api.Task{Content: "Plan week", Priority: 4, Due: &api.Due{Date: "2026-03-10"},
+		Duration: &api.Duration{Amount: 30, Unit: "minute"}})
+	report := f.AddTask(api.Task{Content: "Write report", ProjectID: work.ID, Due: &api.Due{Date: "2026-03-12"}})
+	f.AddTask(api.Task{Content: "Someday"})
+	f.AddTask(api.Task{Content: "Later", Due: &api.Due{Date: "2026-03-20"}})
+
+	out, err := run(t, AgendaCmd, f, false, "--days", "3")
+	if err != nil {
+		t.Fatalf("AgendaCmd() error = %v", err)
+	}
+	want := strings.Join([]string{
+		"Overdue: 1 task",
+		"         " + rent.ID + " [P4] Pay rent (2026-03-08 (overdue)) #Inbox",
+		"",
+		"Tue Mar 10 (Today): 3 tasks, 8h45m planned, overbooked by 45m",
+		"         " + plan.ID + " [P1] Plan week #Inbox [30m]",
+		"  09:00  " + deep.ID + " [P4] Deep work #Inbox [8h]",
+		"  15:00  " + standup.ID + " [P4] Standup #Inbox [15m]",
+		"",
+		"Wed Mar 11 (Tomorrow): nothing due",
+		"",
+		"Thu Mar 12: 1 task",
+		"         " + report.ID + " [P4] Write report #Work",
+	}, "\n") + "\n"
+	if out != want {
+		t.Errorf("AgendaCmd() output:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestAgendaCmd_json(t *testing.T) {
+	f := filterFake()
+	f.AddTask(api.Task{Content: "Pay rent", Due: &api.Due{Date: "2026-03-08"}})
+	trip := f.AddTask(api.Task{Content: "Offsite", Due: &api.Due{Date: "2026-03-11"}, Duration: &api.Duration{Amount: 2, Unit: "day"}})
+
+	out, err := run(t, AgendaCmd, f, true, "--from", "2026-03-11", "--to", "2026-03-12", "--hours", "6")
+	if err != nil {
+		t.Fatalf("AgendaCmd() error = %v", err)
+	}
+	var got agenda
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if got.From != "2026-03-11" || got.To != "2026-03-12" || len(got.Overdue) != 1 || len(got.Days) != 2 {
+		t.Fatalf("AgendaCmd() = %+v", got)
+	}
+	// The two-day offsite is listed once but books 6h on each day.
+	first, second := got.Days[0], got.Days[1]
+	if len(first.Tasks) != 1 || first.Tasks[0].ID != trip.ID || first.PlannedMinutes != 360 || first.Overbooked {
+		t.Errorf("first day = %+v, want the offsite booking 6h, not overbooked", first)
+	}
+	if len(second.Tasks) != 0 || second.PlannedMinutes != 360 {
+		t.Errorf("second day = %+v, want no tasks and 6h booked", second)
+	}
+}
+
+func TestAgendaCmd_errors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"days and to", []string{"--days", "3", "--to", "2026-03-12"}, "not both"},
+		{"to before from", []string{"--from", "2026-03-12", "--to", "2026-03-11"}, "before --from"},
+		{"bad days", []string{"--days", "0"}, "positive integer"},
+		{"bad date", []string{"--from", "next week"}, "YYYY-MM-DD"},
+		{"unknown flag", []string{"--weeks", "2"}, "unknown flag: --weeks"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := run(t, AgendaCmd, filterFake(), false, tt.args...)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("AgendaCmd(%v) error = %v, want %q", tt.args, err, tt.want)
+			}
+		})
+	}
+}
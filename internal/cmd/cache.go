@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/joeyhipolito/todoist-cli/internal/cache"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
+)
+
+const cacheUsage = `Usage:
+  todoist cache              Show what is cached and how old it is
+  todoist cache refresh      Fetch projects, sections and labels again
+  todoist cache clear        Delete the cache`
+
+// CacheCmd handles the "cache" command and its subcommands.
+func CacheCmd(ctx context.Context, c *cache.Client, w io.Writer, args []string, format output.Format) error {
+	if len(args) == 0 {
+		return cacheStatusCmd(c, w, format)
+	}
+
+	switch args[0] {
+	case "refresh":
+		if c.TTL() == 0 {
+			return fmt.Errorf("cache is disabled (cache_ttl is 0)")
+		}
+		if err := c.Refresh(ctx); err != nil {
+			return err
+		}
+		return cacheStatusCmd(c, w, format)
+	case "clear":
+		if err := c.Clear(); err != nil {
+			return err
+		}
+		if format != output.Text {
+			return output.Value(w, format, map[string]string{
+				"status":  "ok",
+				"message": "Cache cleared",
+			})
+		}
+		fmt.Fprintln(w, "Cache cleared.")
+		return nil
+	case "--help", "-h":
+		fmt.Fprintln(w, cacheUsage)
+		return nil
+	default:
+		return fmt.Errorf("unknown cache subcommand: %s\n\n%s", args[0], cacheUsage)
+	}
+}
+
+// cacheStatusCmd lists the cached entries with their size and age.
+func cacheStatusCmd(c *cache.Client, w io.Writer, format output.Format) error {
+	entries, err := c.Entries()
+	if err != nil {
+		return err
+	}
+
+	if format != output.Text {
+		return output.Value(w, format, map[string]any{
+			"dir":         c.Dir(),
+			"ttl_seconds": int(c.TTL().Seconds()),
+			"entries":     entries,
+		})
+	}
+
+	if c.TTL() == 0 {
+		fmt.Fprintf(w, "Cache: %s (disabled)\n", c.Dir())
+	} else {
+		fmt.Fprintf(w, "Cache: %s (TTL %s)\n", c.Dir(), c.TTL())
+	}
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "  (empty)")
+		return nil
+	}
+	now := c.Now()
+	for _, e := range entries {
+		state := ""
+		if e.Expired {
+			state = ", expired"
+		}
+		fmt.Fprintf(w, "  %-24s %4d item(s), fetched %s ago%s\n", e.Name, e.Count, now.Sub(e.FetchedAt).Truncate(time.Second), state)
+	}
+	return nil
+}
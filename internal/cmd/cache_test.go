@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/cache"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
+)
+
+func TestCacheCmd(t *testing.T) {
+	f := newFake()
+	f.AddProject(api.Project{Name: "Work"})
+	c := cache.New(f, filepath.Join(t.TempDir(), cache.Dir), time.Hour)
+	c.Now = func() time.Time { return testNow }
+	ctx := context.Background()
+
+	var out bytes.Buffer
+	if err := CacheCmd(ctx, c, &out, nil, output.Text); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "(empty)") {
+		t.Errorf("status of empty cache = %q", out.String())
+	}
+
+	out.Reset()
+	if err := CacheCmd(ctx, c, &out, []string{"refresh"}, output.Text); err != nil {
+		t.Fatalf("cache refresh error = %v", err)
+	}
+	for _, want := range []string{"TTL 1h0m0s", "projects", "2 item(s), fetched 0s ago"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("cache refresh output = %q, want %q", out.String(), want)
+		}
+	}
+
+	out.Reset()
+	if err := CacheCmd(ctx, c, &out, []string{"clear"}, output.Text); err != nil || out.String() != "Cache cleared.\n" {
+		t.Errorf("cache clear = %q, %v", out.String(), err)
+	}
+	if entries, _ := c.Entries(); len(entries) != 0 {
+		t.Errorf("entries after clear = %v", entries)
+	}
+
+	if err := CacheCmd(ctx, c, &out, []string{"bogus"}, output.Text); err == nil {
+		t.Error("unknown subcommand: want error")
+	}
+	if err := CacheCmd(ctx, cache.New(f, t.TempDir(), 0), &out, []string{"refresh"}, output.Text); err == nil {
+		t.Error("refresh with cache disabled: want error")
+	}
+}
+
+func TestResolve_staleCacheRetries(t *testing.T) {
+	f := newFake()
+	c := cache.New(f, filepath.Join(t.TempDir(), cache.Dir), time.Hour)
+	ctx := context.Background()
+
+	// Warm the cache, then create a project behind its back.
+	if _, err := c.GetProjects(ctx); err != nil {
+		t.Fatal(err)
+	}
+	work := f.AddProject(api.Project{Name: "Work"})
+
+	var out bytes.Buffer
+	if err := AddCmd(ctx, c, &out, []string{"Write report", "--project", "Work"}, output.Text); err != nil {
+		t.Fatalf("AddCmd() error = %v", err)
+	}
+	tasks, _ := f.GetTasks(ctx, "", work.ID, "")
+	if len(tasks) != 1 {
+		t.Errorf("Work has %d tasks, want 1", len(tasks))
+	}
+
+	// A name that really doesn't exist is fetched once more, not forever.
+	if err := AddCmd(ctx, c, &out, []string{"Nope", "--project", "Missing"}, output.Text); err == nil || !strings.Contains(err.Error(), "project not found") {
+		t.Errorf("AddCmd(missing project) error = %v", err)
+	}
+}
+
+func TestEditCmd_assigneeByName(t *testing.T) {
+	f := newFake()
+	work := f.AddProject(api.Project{Name: "Work"})
+	ana := f.AddCollaborator(work.ID, api.Collaborator{Name: "Ana Lima", Email: "ana@example.com"})
+	task := f.AddTask(api.Task{Content: "Review", ProjectID: work.ID})
+
+	for _, who := range []string{"ana lima", "ANA@example.com", ana.ID} {
+		if _, err := run(t, EditCmd, f, false, task.ID, "--assignee", who); err != nil {
+			t.Fatalf("EditCmd(--assignee %s) error = %v", who, err)
+		}
+		if got, _ := f.Task(task.ID); got.AssigneeID != ana.ID {
+			t.Errorf("--assignee %s: assignee = %q, want %q", who, got.AssigneeID, ana.ID)
+		}
+	}
+
+	// Unknown values are passed through for the API to judge.
+	if _, err := run(t, EditCmd, f, false, task.ID, "--assignee", "424242"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := f.Task(task.ID); got.AssigneeID != "424242" {
+		t.Errorf("assignee = %q, want 424242", got.AssigneeID)
+	}
+}
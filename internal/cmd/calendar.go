@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
+	"github.com/joeyhipolito/todoist-cli/internal/transform"
+)
+
+// calendar is the machine-readable form of the grid CalendarCmd draws.
+type calendar struct {
+	From string        `json:"from"`
+	To   string        `json:"to"`
+	Days []calendarDay `json:"days"`
+}
+
+type calendarDay struct {
+	Date      string      `json:"date"`
+	Tasks     []*api.Task `json:"tasks"`
+	Deadlines []*api.Task `json:"deadlines"`
+}
+
+// CalendarCmd draws a month, or with --week a week, as a grid of days
+// showing how many tasks are due on each and where deadlines fall. --day
+// lists one day's tasks below the grid.
+func CalendarCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
+	var monthArg, dayArg, projectName string
+	week := false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--month":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--month requires a month (YYYY-MM)")
+			}
+			monthArg = args[i+1]
+			i++
+		case "--day":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--day requires a date (YYYY-MM-DD)")
+			}
+			dayArg = args[i+1]
+			i++
+		case "--week":
+			week = true
+		case "--project":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--project requires a name")
+			}
+			projectName = args[i+1]
+			i++
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+	if week && monthArg != "" {
+		return fmt.Errorf("use --month or --week, not both")
+	}
+
+	current := now()
+	loc := current.Location()
+	today := time.Date(current.Year(), current.Month(), current.Day(), 0, 0, 0, 0, loc)
+
+	// The grid shows --month, or the month or week around --day, or
+	// around today. day stays the day to list even when --month moves
+	// the anchor to the first of the month.
+	var day time.Time
+	if dayArg != "" {
+		d, err := time.ParseInLocation("2006-01-02", dayArg, loc)
+		if err != nil {
+			return fmt.Errorf("--day must be a date (YYYY-MM-DD): %s", dayArg)
+		}
+		day = d
+	}
+	anchor := today
+	if dayArg != "" {
+		anchor = day
+	}
+	var first, last time.Time
+	var title string
+	switch {
+	case week:
+		first = anchor.AddDate(0, 0, -((int(anchor.Weekday()) + 6) % 7))
+		last = first.AddDate(0, 0, 6)
+		title = "Week of " + first.Format("Jan 2, 2006")
+	default:
+		if monthArg != "" {
+			m, err := time.ParseInLocation("2006-01", monthArg, loc)
+			if err != nil {
+				return fmt.Errorf("--month must be a month (YYYY-MM): %s", monthArg)
+			}
+			anchor = m
+		}
+		first = time.Date(anchor.Year(), anchor.Month(), 1, 0, 0, 0, 0, loc)
+		last = first.AddDate(0, 1, -1)
+		title = first.Format("January 2006")
+	}
+	// A week is drawn around --day, so only --month can leave it out.
+	if dayArg != "" && (day.Before(first) || day.After(last)) {
+		return fmt.Errorf("--day %s is not in %s", dayArg, title)
+	}
+
+	var projectID string
+	if projectName != "" {
+		id, err := resolveProjectID(ctx, client, projectName)
+		if err != nil {
+			return err
+		}
+		projectID = id
+	}
+
+	// Deadlines can fall on days the task isn't due, so take every task
+	// and place it locally rather than filtering by due date.
+	tasks, err := client.GetTasks(ctx, "", projectID, "")
+	if err != nil {
+		return err
+	}
+	days := calendarDays(tasks, first, last)
+
+	if format != output.Text {
+		if dayArg != "" {
+			first, last = day, day
+			days = calendarDays(tasks, day, day)
+		}
+		c := calendar{From: first.Format("2006-01-02"), To: last.Format("2006-01-02"), Days: []calendarDay{}}
+		for _, d := range days {
+			c.Days = append(c.Days, calendarDay{Date: d.Date.Format("2006-01-02"), Tasks: d.Tasks, Deadlines: d.Deadlines})
+		}
+		if format == output.JSON || format == output.YAML {
+			return output.Value(w, format, c)
+		}
+		return output.List(w, format, c.Days, calendarColumns)
+	}
+
+	projects, err := client.GetProjects(ctx)
+	if err != nil {
+		return err
+	}
+	line, err := newTaskLine(w, projects)
+	if err != nil {
+		return err
+	}
+
+	grid := &transform.Calendar{Theme: line.Theme, Today: today}
+	fmt.Fprintln(w, title)
+	fmt.Fprint(w, grid.Grid(days))
+	fmt.Fprintln(w, "n = tasks due, ◆ = deadline, [n] = today")
+
+	if dayArg != "" {
+		listed := calendarDays(tasks, day, day)[0]
+		fmt.Fprintln(w)
+		summary := plural(len(listed.Tasks), "task") + " due"
+		if n := len(listed.Deadlines); n > 0 {
+			summary += ", " + plural(n, "deadline")
+		}
+		fmt.Fprintf(w, "%s: %s\n", day.Format("Mon Jan 2"), summary)
+
+		undated := *line
+		undated.NoDue = true
+		for _, t := range listed.Tasks {
+			fmt.Fprintln(w, dayLine(&undated, t, loc))
+		}
+		// Tasks due on another day show their due date with the deadline.
+		for _, t := range listed.Deadlines {
+			if !containsTask(listed.Tasks, t) {
+				fmt.Fprintln(w, dayLine(line, t, loc))
+			}
+		}
+	}
+
+	printSnapshotNote(w, client)
+	return nil
+}
+
+// calendarDays places tasks on the days from first to last by due date
+// and by deadline. Within a day, tasks keep the API's order, with timed
+// tasks after all-day ones in time order.
+func calendarDays(tasks []*api.Task, first, last time.Time) []transform.CalendarDay {
+	loc := first.Location()
+	var days []transform.CalendarDay
+	index := map[string]int{}
+	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+		index[d.Format("2006-01-02")] = len(days)
+		days = append(days, transform.CalendarDay{Date: d, Tasks: []*api.Task{}, Deadlines: []*api.Task{}})
+	}
+
+	for _, t := range tasks {
+		if due, ok := transform.DueDay(t.Due, loc); ok {
+			if i, ok := index[due.Format("2006-01-02")]; ok {
+				days[i].Tasks = append(days[i].Tasks, t)
+			}
+		}
+		if t.Deadline != nil {
+			if i, ok := index[t.Deadline.Date]; ok {
+				days[i].Deadlines = append(days[i].Deadlines, t)
+			}
+		}
+	}
+
+	for _, d := range days {
+		sort.SliceStable(d.Tasks, func(i, j int) bool {
+			ti, timedI := transform.DueTime(d.Tasks[i].Due, loc)
+			tj, timedJ := transform.DueTime(d.Tasks[j].Due, loc)
+			if timedI != timedJ {
+				return !timedI
+			}
+			return ti.Before(tj)
+		})
+	}
+	return days
+}
+
+// containsTask reports whether tasks includes t.
+func containsTask(tasks []*api.Task, t *api.Task) bool {
+	for _, other := range tasks {
+		if other.ID == t.ID {
+			return true
+		}
+	}
+	return false
+}
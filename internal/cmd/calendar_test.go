@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+func TestCalendarCmd(t *testing.T) {
+	f := newFake()
+	work := f.AddProject(api.Project{Name: "Work"})
+	f.AddTask(api.Task{Content: "Pay rent", Due: &api.Due{Date: "2026-03-02"}})
+	standup := f.AddTask(api.Task{Content: "Standup", Due: &api.Due{Date: "2026-03-12", Datetime: "2026-03-12T09:00:00Z"}})
+	plan := f.AddTask(api.Task{Content: "Plan week", Priority: 4, Due: &api.Due{Date: "2026-03-12"}})
+	report := f.AddTask(api.Task{Content: "Write report", ProjectID: work.ID, Due: &api.Due{Date: "2026-03-11"},
+		Deadline: &api.Deadline{Date: "2026-03-12"}})
+	f.AddTask(api.Task{Content: "Taxes", Deadline: &api.Deadline{Date: "2026-03-31"}})
+	f.AddTask(api.Task{Content: "Later", Due: &api.Due{Date: "2026-04-01"}})
+
+	out, err := run(t, CalendarCmd, f, false, "--day", "2026-03-12")
+	if err != nil {
+		t.Fatalf("CalendarCmd() error = %v", err)
+	}
+	want := strings.Join([]string{
+		"March 2026",
+		" Mon     Tue     Wed     Thu     Fri     Sat     Sun",
+		"┌───────┬───────┬───────┬───────┬───────┬───────┬───────┐",
+		"│       │       │       │       │       │       │ 1     │",
+		"│       │       │       │       │       │       │       │",
+		"├───────┼───────┼───────┼───────┼───────┼───────┼───────┤",
+		"│ 2     │ 3     │ 4     │ 5     │ 6     │ 7     │ 8     │",
+		"│ 1     │       │       │       │       │       │       │",
+		"├───────┼───────┼───────┼───────┼───────┼───────┼───────┤",
+		"│ 9     │[10]   │ 11    │ 12    │ 13    │ 14    │ 15    │",
+		"│       │       │ 1     │ 2 ◆   │       │       │       │",
+		"├───────┼───────┼───────┼───────┼───────┼───────┼───────┤",
+		"│ 16    │ 17    │ 18    │ 19    │ 20    │ 21    │ 22    │",
+		"│       │       │       │       │       │       │       │",
+		"├───────┼───────┼───────┼───────┼───────┼───────┼───────┤",
+		"│ 23    │ 24    │ 25    │ 26    │ 27    │ 28    │ 29    │",
+		"│       │       │       │       │       │       │       │",
+		"├───────┼───────┼───────┼───────┼───────┼───────┼───────┤",
+		"│ 30    │ 31    │       │       │       │       │       │",
+		"│       │ ◆     │       │       │       │       │       │",
+		"└───────┴───────┴───────┴───────┴───────┴───────┴───────┘",
+		"n = tasks due, ◆ = deadline, [n] = today",
+		"",
+		"Thu Mar 12: 2 tasks due, 1 deadline",
+		"         " + plan.ID + " [P1] Plan week #Inbox",
+		"  09:00  " + standup.ID + " [P4] Standup #Inbox",
+		"         " + report.ID + " [P4] Write report (Tomorrow) #Work (deadline 2026-03-12)",
+	}, "\n") + "\n"
+	if out != want {
+		t.Errorf("CalendarCmd() output:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestCalendarCmd_week(t *testing.T) {
+	f := newFake()
+	f.AddTask(api.Task{Content: "Standup", Due: &api.Due{Date: "2026-03-15"}})
+
+	out, err := run(t, CalendarCmd, f, false, "--week")
+	if err != nil {
+		t.Fatalf("CalendarCmd() error = %v", err)
+	}
+	if !strings.HasPrefix(out, "Week of Mar 9, 2026\n") || !strings.Contains(out, "│ 9     │[10]   │") ||
+		!strings.Contains(out, "│       │       │       │       │       │       │ 1     │") {
+		t.Errorf("CalendarCmd(--week) output:\n%s", out)
+	}
+}
+
+func TestCalendarCmd_json(t *testing.T) {
+	f := newFake()
+	task := f.AddTask(api.Task{Content: "Taxes", Due: &api.Due{Date: "2026-11-02"}, Deadline: &api.Deadline{Date: "2026-11-30"}})
+
+	out, err := run(t, CalendarCmd, f, true, "--month", "2026-11")
+	if err != nil {
+		t.Fatalf("CalendarCmd() error = %v", err)
+	}
+	var got calendar
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if got.From != "2026-11-01" || got.To != "2026-11-30" || len(got.Days) != 30 {
+		t.Fatalf("CalendarCmd() = %s to %s with %d days, want November", got.From, got.To, len(got.Days))
+	}
+	if d := got.Days[1]; len(d.Tasks) != 1 || d.Tasks[0].ID != task.ID {
+		t.Errorf("Nov 2 = %+v, want the task due", d)
+	}
+	if d := got.Days[29]; len(d.Deadlines) != 1 || d.Deadlines[0].ID != task.ID {
+		t.Errorf("Nov 30 = %+v, want the deadline", d)
+	}
+}
+
+func TestCalendarCmd_monthAndDay(t *testing.T) {
+	f := newFake()
+	vote := f.AddTask(api.Task{Content: "Vote", Due: &api.Due{Date: "2026-11-05"}})
+	f.AddTask(api.Task{Content: "Rent", Due: &api.Due{Date: "2026-11-01"}})
+
+	out, err := run(t, CalendarCmd, f, false, "--month", "2026-11", "--day", "2026-11-05")
+	if err != nil {
+		t.Fatalf("CalendarCmd() error = %v", err)
+	}
+	if !strings.HasPrefix(out, "November 2026\n") {
+		t.Errorf("CalendarCmd() output:\n%s\nwant November 2026", out)
+	}
+	want := "Thu Nov 5: 1 task due\n         " + vote.ID + " [P4] Vote #Inbox\n"
+	if !strings.HasSuffix(out, want) {
+		t.Errorf("CalendarCmd() output:\n%s\nwant it to end with:\n%s", out, want)
+	}
+
+	out, err = run(t, CalendarCmd, f, true, "--month", "2026-11", "--day", "2026-11-05")
+	if err != nil {
+		t.Fatalf("CalendarCmd() error = %v", err)
+	}
+	var got calendar
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if got.From != "2026-11-05" || got.To != "2026-11-05" || len(got.Days) != 1 ||
+		len(got.Days[0].Tasks) != 1 || got.Days[0].Tasks[0].ID != vote.ID {
+		t.Errorf("CalendarCmd() = %+v, want Nov 5 with the vote", got)
+	}
+}
+
+func TestCalendarCmd_errors(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--month", "2026-11", "--week"}, "not both"},
+		{[]string{"--month", "November"}, "YYYY-MM"},
+		{[]string{"--day", "2026-11"}, "YYYY-MM-DD"},
+		{[]string{"--month", "2026-11", "--day", "2026-12-05"}, "--day 2026-12-05 is not in November 2026"},
+		{[]string{"--moth", "2026-11"}, "unknown flag: --moth"},
+		{[]string{"2026-11"}, "unknown flag: 2026-11"},
+	}
+	for _, tt := range tests {
+		_, err := run(t, CalendarCmd, newFake(), false, tt.args...)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("CalendarCmd(%v) error = %v, want %q", tt.args, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/cache"
+	"github.com/joeyhipolito/todoist-cli/internal/config"
+	"github.com/joeyhipolito/todoist-cli/internal/offline"
+)
+
+// EnvDebug is the environment variable that turns on request logging to stderr.
+const EnvDebug = "TODOIST_DEBUG"
+
+// EnvOffline is the environment variable that turns on offline mode, like
+// the global --offline flag.
+const EnvOffline = "TODOIST_OFFLINE"
+
+// JournalFile is the file in the config directory that holds changes
+// queued while offline.
+const JournalFile = "journal.jsonl"
+
+// NewClient creates an API client configured from the config file and
+// environment on top of the access token.
+func NewClient(token string) (*api.Client, error) {
+	var opts []api.Option
+
+	apiURL, err := config.ResolveAPIURL()
+	if err != nil {
+		return nil, err
+	}
+	if apiURL != "" {
+		opts = append(opts, api.WithBaseURL(apiURL))
+	}
+
+	retries, ok, err := config.ResolveMaxRetries()
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, api.WithRetryPolicy(api.RetryPolicy{
+			MaxRetries:     retries,
+			InitialBackoff: api.InitialBackoff,
+		}))
+	}
+
+	perMinute, ok, err := config.ResolveRateLimit()
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, api.WithRateLimit(perMinute))
+	}
+
+	budget, ok, err := config.ResolveRateLimitBudget()
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, api.WithRateLimitBudget(budget))
+	}
+
+	if os.Getenv(EnvDebug) != "" {
+		opts = append(opts, api.WithLogger(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+	}
+
+	return api.NewClient(token, opts...)
+}
+
+// NewCache wraps client in the on-disk lookup cache under the config
+// directory, with the TTL from the config file or environment.
+func NewCache(client api.TodoistAPI) (*cache.Client, error) {
+	ttl, ok, err := config.ResolveCacheTTL()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		ttl = cache.DefaultTTL
+	}
+	return cache.New(client, filepath.Join(config.Dir(), cache.Dir), ttl), nil
+}
+
+// NewOffline wraps client so task changes are queued in the journal, and
+// reads answered from the last sync, when the API can't be reached. With
+// forced set it doesn't try the API at all.
+func NewOffline(client api.TodoistAPI, forced bool) *offline.Client {
+	return offline.New(client, openJournal(), syncStatePath(), forced)
+}
+
+// openJournal returns the offline journal in the config directory.
+func openJournal() *offline.Journal {
+	return offline.OpenJournal(filepath.Join(config.Dir(), JournalFile))
+}
+
+// findClient returns the first client of type T in the chain of wrappers
+// starting at client, such as the cache under the offline client.
+func findClient[T any](client api.TodoistAPI) (T, bool) {
+	for client != nil {
+		if c, ok := client.(T); ok {
+			return c, true
+		}
+		u, ok := client.(interface{ Unwrap() api.TodoistAPI })
+		if !ok {
+			break
+		}
+		client = u.Unwrap()
+	}
+	var zero T
+	return zero, false
+}
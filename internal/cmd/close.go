@@ -1,39 +1,90 @@
 package cmd
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"os"
+	"io"
+	"slices"
 
 	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/offline"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
+	"github.com/joeyhipolito/todoist-cli/internal/transform"
 )
 
-// CloseCmd marks a task as complete.
-func CloseCmd(token string, args []string, jsonOutput bool) error {
-	if len(args) < 1 {
-		return fmt.Errorf("close requires a task ID\n\nUsage: todoist close <task-id>")
+// CloseCmd marks a task as complete, optionally with all its subtasks.
+func CloseCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
+	recursive := false
+	var rest []string
+	for _, arg := range args {
+		if arg == "--recursive" || arg == "-r" {
+			recursive = true
+		} else {
+			rest = append(rest, arg)
+		}
 	}
 
-	client, err := api.NewClient(token)
-	if err != nil {
-		return err
+	if len(rest) < 1 {
+		return fmt.Errorf("close requires a task ID\n\nUsage: todoist close <task-id> [--recursive]")
 	}
 
-	taskID := args[0]
-	if err := client.CloseTask(taskID); err != nil {
+	taskID := rest[0]
+
+	// Close subtasks deepest first so no parent is closed before its
+	// children, all in one sync request.
+	var subtasks []string
+	if recursive {
+		task, err := client.GetTask(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		tasks, err := client.GetTasks(ctx, "", task.ProjectID, "")
+		if err != nil {
+			return err
+		}
+		subtasks = transform.Descendants(tasks, taskID)
+
+		ids := append(slices.Clone(subtasks), taskID)
+		cmds := make([]api.SyncCommand, len(ids))
+		for i, id := range ids {
+			cmds[i] = api.CloseTaskCommand(id)
+		}
+		resp, err := api.RunCommands(ctx, client, cmds)
+		if _, ok := offline.AsQueued(err); ok {
+			return reportQueued(w, taskID, fmt.Sprintf("Task completion queued with %d subtask(s)", len(subtasks)), format)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to close tasks: %w", err)
+		}
+		for i, id := range ids {
+			if err := resp.Err(cmds[i]); err != nil {
+				return fmt.Errorf("failed to close task %s: %w", id, err)
+			}
+		}
+	} else if err := client.CloseTask(ctx, taskID); err != nil {
+		if _, ok := offline.AsQueued(err); ok {
+			return reportQueued(w, taskID, "Task completion queued", format)
+		}
 		return err
 	}
 
-	if jsonOutput {
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(map[string]string{
+	message := "Task completed"
+	if len(subtasks) > 0 {
+		message = fmt.Sprintf("Task completed with %d subtask(s)", len(subtasks))
+	}
+
+	if format != output.Text {
+		return output.Value(w, format, map[string]string{
 			"status":  "ok",
 			"task_id": taskID,
-			"message": "Task completed",
+			"message": message,
 		})
 	}
 
-	fmt.Printf("Task %s completed.\n", taskID)
+	if len(subtasks) > 0 {
+		fmt.Fprintf(w, "Task %s completed with %d subtask(s).\n", taskID, len(subtasks))
+		return nil
+	}
+	fmt.Fprintf(w, "Task %s completed.\n", taskID)
 	return nil
 }
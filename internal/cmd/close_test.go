@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+func TestCloseCmd(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		json       bool
+		wantOut    string
+		wantClosed []string // task keys, in close order
+		wantErr    string
+	}{
+		{
+			name:       "single task",
+			args:       []string{"PARENT"},
+			wantOut:    "Task PARENT completed.\n",
+			wantClosed: []string{"PARENT"},
+		},
+		{
+			name:       "recursive closes subtasks first",
+			args:       []string{"PARENT", "--recursive"},
+			wantOut:    "Task PARENT completed with 2 subtask(s).\n",
+			wantClosed: []string{"GRANDCHILD", "CHILD", "PARENT"},
+		},
+		{
+			name:       "json",
+			args:       []string{"CHILD"},
+			json:       true,
+			wantOut:    "{\n  \"message\": \"Task completed\",\n  \"status\": \"ok\",\n  \"task_id\": \"CHILD\"\n}\n",
+			wantClosed: []string{"CHILD"},
+		},
+		{name: "missing id", wantErr: "requires a task ID"},
+		{name: "unknown task", args: []string{"999"}, wantErr: "not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFake()
+			parent := f.AddTask(api.Task{Content: "Parent"})
+			child := f.AddTask(api.Task{Content: "Child", ParentID: parent.ID})
+			grandchild := f.AddTask(api.Task{Content: "Grandchild", ParentID: child.ID})
+			other := f.AddTask(api.Task{Content: "Other"})
+			ids := strings.NewReplacer("PARENT", parent.ID, "GRANDCHILD", grandchild.ID, "CHILD", child.ID)
+
+			var args []string
+			for _, a := range tt.args {
+				args = append(args, ids.Replace(a))
+			}
+
+			out, err := run(t, CloseCmd, f, tt.json, args...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("CloseCmd() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CloseCmd() error = %v", err)
+			}
+			if want := ids.Replace(tt.wantOut); out != want {
+				t.Errorf("CloseCmd() output = %q, want %q", out, want)
+			}
+
+			var closed []string
+			for _, c := range f.Calls() {
+				if id, ok := strings.CutPrefix(c, "CloseTask "); ok {
+					closed = append(closed, id)
+				}
+			}
+			var want []string
+			for _, k := range tt.wantClosed {
+				want = append(want, ids.Replace(k))
+			}
+			if !slices.Equal(closed, want) {
+				t.Errorf("closed %v, want %v", closed, want)
+			}
+			if task, _ := f.Task(other.ID); task.IsCompleted {
+				t.Error("unrelated task was closed")
+			}
+		})
+	}
+}
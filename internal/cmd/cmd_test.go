@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/api/apitest"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
+)
+
+// testNow is the fixed clock used by the fake in command tests.
+var testNow = time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func init() {
+	now = func() time.Time { return testNow }
+}
+
+type cmdFunc func(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error
+
+// newFake returns an empty fake account on the test clock.
+func newFake() *apitest.Fake {
+	f := apitest.New()
+	f.Now = func() time.Time { return testNow }
+	return f
+}
+
+// run invokes a command against f and returns what it printed.
+func run(t *testing.T, fn cmdFunc, f *apitest.Fake, jsonOutput bool, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	format := output.Text
+	if jsonOutput {
+		format = output.JSON
+	}
+	err := fn(context.Background(), f, &out, args, format)
+	return out.String(), err
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"time"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/config"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
+	"github.com/joeyhipolito/todoist-cli/internal/transform"
+)
+
+// EnvNoColor turns color off when set to anything, per https://no-color.org.
+const EnvNoColor = "NO_COLOR"
+
+// now is the clock due dates are shown relative to. Tests replace it.
+var now = time.Now
+
+// taskTheme returns the color theme for task lines written to w: nil, for
+// plain text, unless w is a terminal and NO_COLOR is unset.
+func taskTheme(w io.Writer) (*transform.Theme, error) {
+	if os.Getenv(EnvNoColor) != "" || !output.IsTerminal(w) {
+		return nil, nil
+	}
+	name, colors, err := config.ResolveTheme()
+	if err != nil {
+		return nil, err
+	}
+	return transform.LookupTheme(name, colors)
+}
+
+// newTaskLine returns the line renderer for task lists written to w, with
+// project names from projects.
+func newTaskLine(w io.Writer, projects []*api.Project) (*transform.TaskLine, error) {
+	theme, err := taskTheme(w)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(projects))
+	for _, p := range projects {
+		names[p.ID] = p.Name
+	}
+	return &transform.TaskLine{Theme: theme, Projects: names, Now: now}, nil
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
+	"github.com/joeyhipolito/todoist-cli/internal/transform"
+)
+
+// Columns shown for lists in the tabular output formats. The structured
+// formats (json, jsonl, yaml) include every field instead.
+
+var taskColumns = []output.Column[*api.Task]{
+	{Header: "ID", Value: func(t *api.Task) string { return t.ID }},
+	{Header: "PRIORITY", Value: func(t *api.Task) string { return transform.FormatPriority(t.Priority) }},
+	{Header: "CONTENT", Value: func(t *api.Task) string { return t.Content }},
+	{Header: "DUE", Value: func(t *api.Task) string {
+		if t.Due == nil {
+			return ""
+		}
+		return t.Due.Date
+	}},
+	{Header: "LABELS", Value: func(t *api.Task) string { return strings.Join(t.Labels, " ") }},
+	{Header: "PROJECT_ID", Value: func(t *api.Task) string { return t.ProjectID }},
+}
+
+var projectColumns = []output.Column[*api.Project]{
+	{Header: "ID", Value: func(p *api.Project) string { return p.ID }},
+	{Header: "NAME", Value: func(p *api.Project) string { return p.Name }},
+	{Header: "PARENT_ID", Value: func(p *api.Project) string { return p.ParentID }},
+	{Header: "COLOR", Value: func(p *api.Project) string { return p.Color }},
+	{Header: "FAVORITE", Value: func(p *api.Project) string { return strconv.FormatBool(p.IsFavorite) }},
+	{Header: "SHARED", Value: func(p *api.Project) string { return strconv.FormatBool(p.IsShared) }},
+}
+
+var labelColumns = []output.Column[*api.Label]{
+	{Header: "ID", Value: func(l *api.Label) string { return l.ID }},
+	{Header: "NAME", Value: func(l *api.Label) string { return l.Name }},
+	{Header: "COLOR", Value: func(l *api.Label) string { return l.Color }},
+	{Header: "FAVORITE", Value: func(l *api.Label) string { return strconv.FormatBool(l.IsFavorite) }},
+}
+
+// completedColumns shows project names from the response's projects.
+func completedColumns(projects map[string]api.Project) []output.Column[api.CompletedTask] {
+	return []output.Column[api.CompletedTask]{
+		{Header: "COMPLETED_AT", Value: func(t api.CompletedTask) string { return t.CompletedAt }},
+		{Header: "TASK_ID", Value: func(t api.CompletedTask) string { return t.TaskID }},
+		{Header: "CONTENT", Value: func(t api.CompletedTask) string { return t.Content }},
+		{Header: "PROJECT", Value: func(t api.CompletedTask) string {
+			if p, ok := projects[t.ProjectID]; ok {
+				return p.Name
+			}
+			return t.ProjectID
+		}},
+	}
+}
+
+// agendaColumns are the columns for tabular agenda output.
+func agendaColumns(loc *time.Location) []output.Column[*api.Task] {
+	return []output.Column[*api.Task]{
+		{Header: "DATE", Value: func(t *api.Task) string {
+			day, _ := transform.DueDay(t.Due, loc)
+			return day.Format("2006-01-02")
+		}},
+		{Header: "TIME", Value: func(t *api.Task) string {
+			if at, ok := transform.DueTime(t.Due, loc); ok {
+				return at.Format("15:04")
+			}
+			return ""
+		}},
+		taskColumns[0], // ID
+		taskColumns[1], // PRIORITY
+		taskColumns[2], // CONTENT
+		{Header: "DURATION", Value: func(t *api.Task) string {
+			if t.Duration == nil {
+				return ""
+			}
+			return transform.FormatDuration(t.Duration.Amount, t.Duration.Unit)
+		}},
+		taskColumns[4], // LABELS
+	}
+}
+
+var calendarColumns = []output.Column[calendarDay]{
+	{Header: "DATE", Value: func(d calendarDay) string { return d.Date }},
+	{Header: "TASKS", Value: func(d calendarDay) string { return strconv.Itoa(len(d.Tasks)) }},
+	{Header: "DEADLINES", Value: func(d calendarDay) string { return strconv.Itoa(len(d.Deadlines)) }},
+	{Header: "TOP_PRIORITY", Value: func(d calendarDay) string {
+		top := 0
+		for _, t := range d.Tasks {
+			top = max(top, t.Priority)
+		}
+		if top == 0 {
+			return ""
+		}
+		return transform.FormatPriority(top)
+	}},
+}
+
+var doctorColumns = []output.Column[doctorCheck]{
+	{Header: "CHECK", Value: func(c doctorCheck) string { return c.Name }},
+	{Header: "STATUS", Value: func(c doctorCheck) string { return c.Status }},
+	{Header: "MESSAGE", Value: func(c doctorCheck) string { return c.Message }},
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
+)
+
+const commentsUsage = `Usage:
+  todoist comments <task-id>                             List comments on a task
+  todoist comments --project <name>                      List comments on a project
+  todoist comments add <task-id> "text" [--attach <file>]
+  todoist comments add --project <name> "text" [--attach <file>]
+  todoist comments delete <comment-id>`
+
+// CommentsCmd handles the "comments" command and its subcommands.
+func CommentsCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
+	var projectName, attachPath string
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--project":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--project requires a name")
+			}
+			projectName = args[i+1]
+			i++
+		case "--attach":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--attach requires a file path")
+			}
+			attachPath = args[i+1]
+			i++
+		case "--help", "-h":
+			fmt.Fprintln(w, commentsUsage)
+			return nil
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+
+	if len(rest) == 0 && projectName == "" {
+		return fmt.Errorf("comments requires a task ID or --project\n\n%s", commentsUsage)
+	}
+
+	var projectID string
+	if projectName != "" {
+		id, err := resolveProjectID(ctx, client, projectName)
+		if err != nil {
+			return err
+		}
+		projectID = id
+	}
+
+	if len(rest) > 0 {
+		switch rest[0] {
+		case "add":
+			return commentsAddCmd(ctx, client, w, projectID, attachPath, rest[1:], format)
+		case "delete":
+			return commentsDeleteCmd(ctx, client, w, rest[1:], format)
+		}
+	}
+
+	if attachPath != "" {
+		return fmt.Errorf("--attach is only valid with comments add")
+	}
+
+	taskID := ""
+	if projectID == "" {
+		taskID = rest[0]
+	}
+	return commentsListCmd(ctx, client, w, taskID, projectID, format)
+}
+
+// commentsListCmd lists the comments on a task or project, oldest first.
+func commentsListCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, taskID, projectID string, format output.Format) error {
+	comments, err := client.GetComments(ctx, taskID, projectID)
+	if err != nil {
+		return err
+	}
+
+	if format != output.Text {
+		return output.Value(w, format, comments)
+	}
+
+	if len(comments) == 0 {
+		fmt.Fprintln(w, "No comments found.")
+		return nil
+	}
+
+	for _, c := range comments {
+		posted := c.PostedAt
+		if len(posted) >= 16 {
+			posted = posted[:10] + " " + posted[11:16]
+		}
+		fmt.Fprintf(w, "  %s | %s | %s\n", c.ID, posted, c.Content)
+		if c.Attachment != nil {
+			fmt.Fprintf(w, "      attachment: %s (%s)\n", c.Attachment.FileName, c.Attachment.FileURL)
+		}
+	}
+
+	return nil
+}
+
+// commentsAddCmd adds a comment to a task or project, optionally uploading an attachment first.
+func commentsAddCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, projectID, attachPath string, args []string, format output.Format) error {
+	req := &api.CreateCommentRequest{ProjectID: projectID}
+	switch {
+	case projectID != "" && len(args) == 1:
+		req.Content = args[0]
+	case projectID == "" && len(args) == 2:
+		req.TaskID = args[0]
+		req.Content = args[1]
+	default:
+		return fmt.Errorf("comments add requires a task ID (or --project) and the comment text\n\nUsage: todoist comments add <task-id> \"text\" [--attach <file>]")
+	}
+
+	if attachPath != "" {
+		f, err := os.Open(attachPath)
+		if err != nil {
+			return fmt.Errorf("failed to open attachment: %w", err)
+		}
+		defer f.Close()
+
+		req.Attachment, err = client.UploadFile(ctx, filepath.Base(attachPath), f)
+		if err != nil {
+			return err
+		}
+	}
+
+	comment, err := client.CreateComment(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if format != output.Text {
+		return output.Value(w, format, comment)
+	}
+
+	fmt.Fprintf(w, "Added comment (ID: %s)\n", comment.ID)
+	return nil
+}
+
+// commentsDeleteCmd permanently deletes a comment.
+func commentsDeleteCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
+	if len(args) < 1 {
+		return fmt.Errorf("comments delete requires a comment ID\n\nUsage: todoist comments delete <comment-id>")
+	}
+
+	commentID := args[0]
+	if err := client.DeleteComment(ctx, commentID); err != nil {
+		return err
+	}
+
+	if format != output.Text {
+		return output.Value(w, format, map[string]string{
+			"status":     "ok",
+			"comment_id": commentID,
+			"message":    "Comment deleted",
+		})
+	}
+
+	fmt.Fprintf(w, "Comment %s deleted.\n", commentID)
+	return nil
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+func TestCommentsCmd_addWithAttachment(t *testing.T) {
+	f := newFake()
+	task := f.AddTask(api.Task{Content: "Report"})
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(path, []byte("buy milk"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := run(t, CommentsCmd, f, true, "add", task.ID, "see file", "--attach", path)
+	if err != nil {
+		t.Fatalf("CommentsCmd() error = %v", err)
+	}
+	var comment api.Comment
+	if err := json.Unmarshal([]byte(out), &comment); err != nil {
+		t.Fatalf("output is not a comment: %v\n%s", err, out)
+	}
+	if comment.TaskID != task.ID || comment.Content != "see file" {
+		t.Errorf("comment = %+v, want %q on task %s", comment, "see file", task.ID)
+	}
+	att := comment.Attachment
+	if att == nil || att.FileName != "notes.txt" || att.FileSize != 8 || !strings.HasPrefix(att.FileType, "text/plain") {
+		t.Errorf("Attachment = %+v, want the uploaded notes.txt", att)
+	}
+	if want := []string{"UploadFile notes.txt", "CreateComment " + task.ID}; !slices.Equal(f.Calls(), want) {
+		t.Errorf("calls = %q, want %q", f.Calls(), want)
+	}
+	if stored, _ := f.Task(task.ID); stored.NoteCount != 1 {
+		t.Errorf("NoteCount = %d, want 1", stored.NoteCount)
+	}
+}
+
+func TestCommentsCmd_attachMissingFile(t *testing.T) {
+	f := newFake()
+	task := f.AddTask(api.Task{Content: "Report"})
+
+	_, err := run(t, CommentsCmd, f, false, "add", task.ID, "x", "--attach", filepath.Join(t.TempDir(), "nope.txt"))
+	if err == nil || !strings.Contains(err.Error(), "failed to open attachment") {
+		t.Fatalf("CommentsCmd() error = %v, want an open failure", err)
+	}
+	if calls := f.Calls(); len(calls) != 0 {
+		t.Errorf("calls = %q, want none", calls)
+	}
+}
+
+func TestCommentsCmd_list(t *testing.T) {
+	f := newFake()
+	work := f.AddProject(api.Project{Name: "Work"})
+	task := f.AddTask(api.Task{Content: "Report", ProjectID: work.ID})
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(path, []byte("buy milk"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"add", task.ID, "first"},
+		{"add", task.ID, "second", "--attach", path},
+		{"add", "--project", "Work", "kickoff notes"},
+	} {
+		if _, err := run(t, CommentsCmd, f, false, args...); err != nil {
+			t.Fatalf("CommentsCmd(%q) error = %v", args, err)
+		}
+	}
+
+	t.Run("by task", func(t *testing.T) {
+		out, err := run(t, CommentsCmd, f, false, task.ID)
+		if err != nil {
+			t.Fatalf("CommentsCmd() error = %v", err)
+		}
+		lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+		if len(lines) != 3 {
+			t.Fatalf("CommentsCmd() output = %q, want two comments and one attachment line", out)
+		}
+		if !strings.HasSuffix(lines[0], " | first") || !strings.HasSuffix(lines[1], " | second") {
+			t.Errorf("CommentsCmd() output = %q, want first then second", out)
+		}
+		if !strings.HasPrefix(lines[2], "      attachment: notes.txt (https://files.example.com/") {
+			t.Errorf("attachment line = %q", lines[2])
+		}
+		if strings.Contains(out, "kickoff") {
+			t.Errorf("CommentsCmd() listed a project comment for a task: %q", out)
+		}
+	})
+
+	t.Run("by project", func(t *testing.T) {
+		out, err := run(t, CommentsCmd, f, true, "--project", "work")
+		if err != nil {
+			t.Fatalf("CommentsCmd() error = %v", err)
+		}
+		var comments []api.Comment
+		if err := json.Unmarshal([]byte(out), &comments); err != nil {
+			t.Fatalf("output is not a comment list: %v\n%s", err, out)
+		}
+		if len(comments) != 1 || comments[0].Content != "kickoff notes" || comments[0].ProjectID != work.ID {
+			t.Errorf("CommentsCmd(--project) = %+v, want only the project comment", comments)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		other := f.AddTask(api.Task{Content: "Other"})
+		out, err := run(t, CommentsCmd, f, false, other.ID)
+		if err != nil {
+			t.Fatalf("CommentsCmd() error = %v", err)
+		}
+		if out != "No comments found.\n" {
+			t.Errorf("CommentsCmd() output = %q", out)
+		}
+	})
+}
+
+func TestCommentsCmd_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"nothing to list", nil, "requires a task ID or --project"},
+		{"attach while listing", []string{"123", "--attach", "x.txt"}, "only valid with comments add"},
+		{"add without text", []string{"add", "123"}, "requires a task ID (or --project) and the comment text"},
+		{"unknown project", []string{"--project", "Nope"}, "project not found: Nope"},
+		{"delete without id", []string{"delete"}, "requires a comment ID"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := run(t, CommentsCmd, newFake(), false, tt.args...)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CommentsCmd() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
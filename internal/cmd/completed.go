@@ -1,22 +1,17 @@
 package cmd
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"os"
-	"strings"
+	"io"
 
 	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
 	"github.com/joeyhipolito/todoist-cli/internal/transform"
 )
 
 // CompletedCmd lists completed tasks with optional filtering.
-func CompletedCmd(token string, args []string, jsonOutput bool) error {
-	client, err := api.NewClient(token)
-	if err != nil {
-		return err
-	}
-
+func CompletedCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
 	// Parse flags
 	var projectName, since string
 	limit := 50
@@ -50,19 +45,11 @@ func CompletedCmd(token string, args []string, jsonOutput bool) error {
 	// Resolve project name to ID
 	var projectID string
 	if projectName != "" {
-		projects, err := client.GetProjects()
+		id, err := resolveProjectID(ctx, client, projectName)
 		if err != nil {
-			return fmt.Errorf("failed to resolve project: %w", err)
-		}
-		for _, p := range projects {
-			if strings.EqualFold(p.Name, projectName) {
-				projectID = p.ID
-				break
-			}
-		}
-		if projectID == "" {
-			return fmt.Errorf("project not found: %s", projectName)
+			return err
 		}
+		projectID = id
 	}
 
 	// Convert YYYY-MM-DD to ISO datetime for the API
@@ -71,24 +58,25 @@ func CompletedCmd(token string, args []string, jsonOutput bool) error {
 		sinceParam = since + "T00:00:00"
 	}
 
-	resp, err := client.GetCompletedTasks(projectID, sinceParam, limit)
+	resp, err := client.GetCompletedTasks(ctx, projectID, sinceParam, limit)
 	if err != nil {
 		return err
 	}
 
-	if jsonOutput {
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(resp)
+	if format != output.Text {
+		if format == output.JSON || format == output.YAML {
+			return output.Value(w, format, resp)
+		}
+		return output.List(w, format, resp.Items, completedColumns(resp.Projects))
 	}
 
 	if len(resp.Items) == 0 {
-		fmt.Println("No completed tasks found.")
+		fmt.Fprintln(w, "No completed tasks found.")
 		return nil
 	}
 
 	for _, t := range resp.Items {
-		fmt.Println(transform.FormatCompletedTaskLine(&t))
+		fmt.Fprintln(w, transform.FormatCompletedTaskLine(&t))
 	}
 
 	return nil
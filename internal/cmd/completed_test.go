@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+func TestCompletedCmd(t *testing.T) {
+	f := newFake()
+	work := f.AddProject(api.Project{Name: "Work"})
+	f.AddCompletedTask(api.Task{Content: "Old errand", CompletedAt: "2026-03-01T09:00:00.000000Z"})
+	f.AddCompletedTask(api.Task{Content: "Filed report", ProjectID: work.ID, CompletedAt: "2026-03-08T17:30:00.000000Z"})
+	f.AddCompletedTask(api.Task{Content: "Bought milk", CompletedAt: "2026-03-09T08:15:00.000000Z"})
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "newest first",
+			want: "  2026-03-09 | Bought milk\n  2026-03-08 | Filed report\n  2026-03-01 | Old errand\n",
+		},
+		{
+			name: "by project",
+			args: []string{"--project", "Work"},
+			want: "  2026-03-08 | Filed report\n",
+		},
+		{
+			name: "since",
+			args: []string{"--since", "2026-03-05"},
+			want: "  2026-03-09 | Bought milk\n  2026-03-08 | Filed report\n",
+		},
+		{
+			name: "limit",
+			args: []string{"--limit", "1"},
+			want: "  2026-03-09 | Bought milk\n",
+		},
+		{
+			name: "none",
+			args: []string{"--since", "2026-03-10"},
+			want: "No completed tasks found.\n",
+		},
+		{name: "bad limit", args: []string{"--limit", "x"}, wantErr: "--limit"},
+		{name: "unknown project", args: []string{"--project", "Home"}, wantErr: "not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := run(t, CompletedCmd, f, false, tt.args...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("CompletedCmd() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CompletedCmd() error = %v", err)
+			}
+			if out != tt.want {
+				t.Errorf("CompletedCmd() output:\n%s\nwant:\n%s", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompletedCmd_json(t *testing.T) {
+	f := newFake()
+	task := f.AddCompletedTask(api.Task{Content: "Done"})
+
+	out, err := run(t, CompletedCmd, f, true)
+	if err != nil {
+		t.Fatalf("CompletedCmd() error = %v", err)
+	}
+	var resp api.CompletedResponse
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	if len(resp.Items) != 1 || resp.Items[0].TaskID != task.ID {
+		t.Errorf("items = %+v, want task %s", resp.Items, task.ID)
+	}
+	if _, ok := resp.Projects[f.InboxID()]; !ok {
+		t.Errorf("projects = %v, want the Inbox included", resp.Projects)
+	}
+}
@@ -2,12 +2,13 @@ package cmd
 
 import (
 	"bufio"
-	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
 	"github.com/joeyhipolito/todoist-cli/internal/config"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
 )
 
 // ConfigureCmd runs an interactive configuration setup.
@@ -43,10 +44,12 @@ func ConfigureCmd() error {
 		return fmt.Errorf("access token is required")
 	}
 
-	// Save configuration
-	cfg := &config.Config{
-		AccessToken: token,
+	// Save configuration, keeping any other settings already in the file
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = &config.Config{}
 	}
+	cfg.AccessToken = token
 
 	if err := config.Save(cfg); err != nil {
 		return fmt.Errorf("failed to save configuration: %w", err)
@@ -66,10 +69,21 @@ func ConfigureCmd() error {
 }
 
 // ConfigureShowCmd prints the current configuration (with token masked).
-func ConfigureShowCmd(jsonOutput bool) error {
+func ConfigureShowCmd(format output.Format) error {
+	return configureShow(os.Stdout, format)
+}
+
+// configureShow writes the current configuration to w.
+func configureShow(w io.Writer, format output.Format) error {
 	if !config.Exists() {
-		fmt.Println("No configuration file found.")
-		fmt.Println("Run 'todoist configure' to set up.")
+		if format != output.Text {
+			return output.Value(w, format, map[string]any{
+				"configured":  false,
+				"config_path": config.Path(),
+			})
+		}
+		fmt.Fprintln(w, "No configuration file found.")
+		fmt.Fprintln(w, "Run 'todoist configure' to set up.")
 		return nil
 	}
 
@@ -88,17 +102,15 @@ func ConfigureShowCmd(jsonOutput bool) error {
 		}
 	}
 
-	if jsonOutput {
-		output := map[string]string{
+	if format != output.Text {
+		return output.Value(w, format, map[string]any{
+			"configured":   true,
 			"config_path":  config.Path(),
 			"access_token": maskedToken,
-		}
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(output)
+		})
 	}
 
-	fmt.Printf("Config file: %s\n", config.Path())
-	fmt.Printf("Access token: %s\n", maskedToken)
+	fmt.Fprintf(w, "Config file: %s\n", config.Path())
+	fmt.Fprintf(w, "Access token: %s\n", maskedToken)
 	return nil
 }
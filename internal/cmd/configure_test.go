@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/joeyhipolito/todoist-cli/internal/config"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
+)
+
+func TestConfigureShow(t *testing.T) {
+	t.Setenv(config.EnvConfigDir, t.TempDir())
+
+	show := func(format output.Format) string {
+		t.Helper()
+		var out bytes.Buffer
+		if err := configureShow(&out, format); err != nil {
+			t.Fatalf("configureShow() error = %v", err)
+		}
+		return out.String()
+	}
+	decode := func(s string) map[string]any {
+		t.Helper()
+		var got map[string]any
+		if err := json.Unmarshal([]byte(s), &got); err != nil {
+			t.Fatalf("output is not a JSON object: %v\n%s", err, s)
+		}
+		return got
+	}
+
+	if got := show(output.Text); got != "No configuration file found.\nRun 'todoist configure' to set up.\n" {
+		t.Errorf("configureShow() text = %q", got)
+	}
+	got := decode(show(output.JSON))
+	if got["configured"] != false || got["config_path"] != config.Path() {
+		t.Errorf("configureShow() json = %v, want configured false", got)
+	}
+	if _, ok := got["access_token"]; ok {
+		t.Errorf("configureShow() json = %v, want no token when unconfigured", got)
+	}
+
+	if err := config.Save(&config.Config{AccessToken: "0123456789abcdef"}); err != nil {
+		t.Fatal(err)
+	}
+	got = decode(show(output.JSON))
+	if got["configured"] != true || got["access_token"] != "0123...cdef" {
+		t.Errorf("configureShow() json = %v, want a masked token", got)
+	}
+}
@@ -1,39 +1,38 @@
 package cmd
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"os"
+	"io"
 
 	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/offline"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
 )
 
 // DeleteCmd permanently deletes a task.
-func DeleteCmd(token string, args []string, jsonOutput bool) error {
+func DeleteCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
 	if len(args) < 1 {
 		return fmt.Errorf("delete requires a task ID\n\nUsage: todoist delete <task-id>")
 	}
 
-	client, err := api.NewClient(token)
-	if err != nil {
-		return err
-	}
-
 	taskID := args[0]
-	if err := client.DeleteTask(taskID); err != nil {
+	err := client.DeleteTask(ctx, taskID)
+	if _, ok := offline.AsQueued(err); ok {
+		return reportQueued(w, taskID, "Task deletion queued", format)
+	}
+	if err != nil {
 		return err
 	}
 
-	if jsonOutput {
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(map[string]string{
+	if format != output.Text {
+		return output.Value(w, format, map[string]string{
 			"status":  "ok",
 			"task_id": taskID,
 			"message": "Task deleted",
 		})
 	}
 
-	fmt.Printf("Task %s deleted.\n", taskID)
+	fmt.Fprintf(w, "Task %s deleted.\n", taskID)
 	return nil
 }
@@ -1,19 +1,48 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/joeyhipolito/publishing-shared/doctor"
-	"github.com/joeyhipolito/todoist-cli/internal/api"
 	"github.com/joeyhipolito/todoist-cli/internal/config"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
 )
 
+// doctorCheck is the form of a doctor.Check written by the output formats
+// the shared renderer doesn't provide.
+type doctorCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
 // DoctorCmd validates the Todoist CLI installation and configuration.
-func DoctorCmd(jsonOutput bool) error {
+func DoctorCmd(ctx context.Context, w io.Writer, format output.Format) error {
 	var checks []doctor.Check
 	allOK := true
 
+	// Text and json use the renderer shared with the other CLIs; every
+	// other format lists the checks like any other command's results.
+	render := func(ok bool) error {
+		if format == output.Text || format == output.JSON {
+			return doctor.Render(w, checks, ok, format == output.JSON, "Todoist Doctor", true)
+		}
+		rows := make([]doctorCheck, len(checks))
+		for i, c := range checks {
+			rows[i] = doctorCheck{Name: c.Name, Status: c.Status, Message: c.Message}
+		}
+		if err := output.List(w, format, rows, doctorColumns); err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("doctor found problems")
+		}
+		return nil
+	}
+
 	add := func(name, status, msg string) {
 		checks = append(checks, doctor.Check{Name: name, Status: status, Message: msg})
 		if status == "fail" {
@@ -30,14 +59,14 @@ func DoctorCmd(jsonOutput bool) error {
 	checks = append(checks, doctor.ConfigPermissions(configPath)())
 
 	if !config.Exists() {
-		return doctor.Render(os.Stdout, checks, false, jsonOutput, "Todoist Doctor", true)
+		return render(false)
 	}
 
 	// 4. Config parseable + access token
 	cfg, err := config.Load()
 	if err != nil {
 		add("Config format", "fail", fmt.Sprintf("failed to parse config: %v", err))
-		return doctor.Render(os.Stdout, checks, false, jsonOutput, "Todoist Doctor", true)
+		return render(false)
 	}
 
 	token := cfg.AccessToken
@@ -46,7 +75,7 @@ func DoctorCmd(jsonOutput bool) error {
 	}
 	if token == "" {
 		add("Access token", "fail", "not found in config or TODOIST_ACCESS_TOKEN env var")
-		return doctor.Render(os.Stdout, checks, false, jsonOutput, "Todoist Doctor", true)
+		return render(false)
 	}
 	masked := "****"
 	if len(token) > 8 {
@@ -55,11 +84,11 @@ func DoctorCmd(jsonOutput bool) error {
 	add("Access token", "ok", fmt.Sprintf("present (%s)", masked))
 
 	// 5. API connection
-	client, err := api.NewClient(token)
+	client, err := NewClient(token)
 	if err != nil {
 		add("API connection", "fail", fmt.Sprintf("failed to create client: %v", err))
 	} else {
-		projects, err := client.GetProjects()
+		projects, err := client.GetProjects(ctx)
 		if err != nil {
 			add("API connection", "fail", fmt.Sprintf("failed: %v", err))
 		} else {
@@ -67,5 +96,5 @@ func DoctorCmd(jsonOutput bool) error {
 		}
 	}
 
-	return doctor.Render(os.Stdout, checks, allOK, jsonOutput, "Todoist Doctor", true)
+	return render(allOK)
 }
@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/offline"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
+	"github.com/joeyhipolito/todoist-cli/internal/transform"
+)
+
+// EditCmd updates fields on an existing task.
+func EditCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
+	for _, arg := range args {
+		if arg == "--help" || arg == "-h" {
+			fmt.Fprint(w, `Usage: todoist edit <task-id> [options]
+
+Options:
+  --content <text>         New task name
+  --description <text>     New description
+  --date <date>            Due date (today, tomorrow, YYYY-MM-DD, "no date")
+  --due-date <YYYY-MM-DD>  Due date without time
+  --due-datetime <time>    Due date and time (RFC3339)
+  --priority <1-4>         Priority (1=urgent, 4=normal)
+  --labels <l1,l2>         Replace all labels
+  --add-label <l1,l2>      Add labels, keeping existing ones
+  --remove-label <l1,l2>   Remove labels, keeping the rest
+  --deadline <YYYY-MM-DD>  Deadline date
+  --duration <n>           Duration (e.g. 30m, 1h30m, 2d)
+  --assignee <user>        Assign to a collaborator (ID, name or email)
+  --json                   Output result as JSON
+  --help, -h               Show this help
+`)
+			return nil
+		}
+	}
+
+	if len(args) < 1 || strings.HasPrefix(args[0], "--") {
+		return fmt.Errorf("edit requires a task ID\n\nUsage: todoist edit <task-id> [--content <text>] [--date <date>] [--priority <1-4>] [--labels <l1,l2>] ...")
+	}
+
+	taskID := args[0]
+	args = args[1:]
+
+	req := &api.UpdateTaskRequest{}
+	var addLabels, removeLabels []string
+
+	// Parse flags
+	for i := 0; i < len(args); i++ {
+		if !strings.HasPrefix(args[i], "--") {
+			return fmt.Errorf("unexpected argument: %s", args[i])
+		}
+		if i+1 >= len(args) {
+			return fmt.Errorf("%s requires an argument", args[i])
+		}
+		value := args[i+1]
+
+		switch args[i] {
+		case "--content":
+			req.Content = &value
+		case "--description":
+			req.Description = &value
+		case "--date":
+			req.DueString = &value
+		case "--due-date":
+			req.DueDate = &value
+		case "--due-datetime":
+			req.DueDatetime = &value
+		case "--priority":
+			p, err := transform.ParsePriority(value)
+			if err != nil {
+				return err
+			}
+			req.Priority = &p
+		case "--labels":
+			labels := splitLabels(value)
+			req.Labels = &labels
+		case "--add-label":
+			addLabels = append(addLabels, splitLabels(value)...)
+		case "--remove-label":
+			removeLabels = append(removeLabels, splitLabels(value)...)
+		case "--deadline":
+			req.DeadlineDate = &value
+		case "--duration":
+			amount, unit, err := transform.ParseDuration(value)
+			if err != nil {
+				return err
+			}
+			req.Duration = &amount
+			req.DurationUnit = &unit
+		case "--assignee":
+			req.AssigneeID = &value
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+		i++
+	}
+
+	// Some flags need the task as it is now; fetch it at most once.
+	var current *api.Task
+	getCurrent := func() (*api.Task, error) {
+		if current != nil {
+			return current, nil
+		}
+		var err error
+		current, err = client.GetTask(ctx, taskID)
+		return current, err
+	}
+
+	// Adding or removing labels needs the current set unless --labels replaced it.
+	if len(addLabels) > 0 || len(removeLabels) > 0 {
+		var labels []string
+		if req.Labels != nil {
+			labels = *req.Labels
+		} else {
+			task, err := getCurrent()
+			if err != nil {
+				return err
+			}
+			labels = task.Labels
+		}
+		labels = mergeLabels(labels, addLabels, removeLabels)
+		req.Labels = &labels
+	}
+
+	// An assignee may be given by name or email among the project's collaborators.
+	if req.AssigneeID != nil && *req.AssigneeID != "" {
+		task, err := getCurrent()
+		if err != nil {
+			return err
+		}
+		id, err := resolveAssignee(ctx, client, task.ProjectID, *req.AssigneeID)
+		if err != nil {
+			return err
+		}
+		req.AssigneeID = &id
+	}
+
+	if req.IsEmpty() {
+		return fmt.Errorf("nothing to update\n\nRun 'todoist edit --help' for available options")
+	}
+
+	task, err := client.UpdateTask(ctx, taskID, req)
+	if _, ok := offline.AsQueued(err); ok {
+		return reportQueued(w, taskID, "Task update queued", format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if format != output.Text {
+		return output.Value(w, format, task)
+	}
+
+	fmt.Fprintf(w, "Updated task: %s (ID: %s)\n", task.Content, task.ID)
+	return nil
+}
+
+// splitLabels splits a comma-separated label list, dropping blanks and any
+// leading "@" so "@work, home" and "work,home" mean the same thing.
+func splitLabels(s string) []string {
+	labels := []string{}
+	for _, l := range strings.Split(s, ",") {
+		l = strings.TrimPrefix(strings.TrimSpace(l), "@")
+		if l != "" {
+			labels = append(labels, l)
+		}
+	}
+	return labels
+}
+
+// mergeLabels returns current plus add, minus remove, preserving order and
+// dropping duplicates. Label names are compared case-insensitively.
+func mergeLabels(current, add, remove []string) []string {
+	removed := make(map[string]bool, len(remove))
+	for _, l := range remove {
+		removed[strings.ToLower(l)] = true
+	}
+
+	seen := make(map[string]bool)
+	labels := []string{}
+	for _, l := range append(append([]string{}, current...), add...) {
+		key := strings.ToLower(l)
+		if removed[key] || seen[key] {
+			continue
+		}
+		seen[key] = true
+		labels = append(labels, l)
+	}
+	return labels
+}
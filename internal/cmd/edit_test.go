@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+func TestEditCmd(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		check   func(t *testing.T, task api.Task, ana *api.Collaborator)
+		wantErr string
+	}{
+		{
+			name: "content and priority",
+			args: []string{"--content", "Final draft", "--priority", "1"},
+			check: func(t *testing.T, task api.Task, _ *api.Collaborator) {
+				if task.Content != "Final draft" || task.Priority != 4 {
+					t.Errorf("task = %q p%d, want Final draft p4 (UI p1 is API 4)", task.Content, task.Priority)
+				}
+				if !slices.Equal(task.Labels, []string{"home", "Waiting"}) {
+					t.Errorf("Labels = %v, want them unchanged", task.Labels)
+				}
+			},
+		},
+		{
+			name: "date deadline and duration",
+			args: []string{"--date", "tomorrow", "--deadline", "2026-03-20", "--duration", "1h30m"},
+			check: func(t *testing.T, task api.Task, _ *api.Collaborator) {
+				if task.Due == nil || task.Due.Date != "2026-03-11" {
+					t.Errorf("Due = %+v, want 2026-03-11", task.Due)
+				}
+				if task.Deadline == nil || task.Deadline.Date != "2026-03-20" {
+					t.Errorf("Deadline = %+v, want 2026-03-20", task.Deadline)
+				}
+				if task.Duration == nil || *task.Duration != (api.Duration{Amount: 90, Unit: "minute"}) {
+					t.Errorf("Duration = %+v, want 90 minutes", task.Duration)
+				}
+			},
+		},
+		{
+			name: "replace labels",
+			args: []string{"--labels", "@deep, focus"},
+			check: func(t *testing.T, task api.Task, _ *api.Collaborator) {
+				if !slices.Equal(task.Labels, []string{"deep", "focus"}) {
+					t.Errorf("Labels = %v, want [deep focus]", task.Labels)
+				}
+			},
+		},
+		{
+			name: "clear labels",
+			args: []string{"--labels", ""},
+			check: func(t *testing.T, task api.Task, _ *api.Collaborator) {
+				if len(task.Labels) != 0 {
+					t.Errorf("Labels = %v, want none", task.Labels)
+				}
+			},
+		},
+		{
+			name: "add and remove labels",
+			args: []string{"--add-label", "today,HOME", "--remove-label", "waiting"},
+			check: func(t *testing.T, task api.Task, _ *api.Collaborator) {
+				if !slices.Equal(task.Labels, []string{"home", "today"}) {
+					t.Errorf("Labels = %v, want [home today]", task.Labels)
+				}
+			},
+		},
+		{
+			name: "add to replaced labels",
+			args: []string{"--labels", "deep", "--add-label", "today"},
+			check: func(t *testing.T, task api.Task, _ *api.Collaborator) {
+				if !slices.Equal(task.Labels, []string{"deep", "today"}) {
+					t.Errorf("Labels = %v, want [deep today]", task.Labels)
+				}
+			},
+		},
+		{
+			name: "assignee by name",
+			args: []string{"--assignee", "ana lima"},
+			check: func(t *testing.T, task api.Task, ana *api.Collaborator) {
+				if task.AssigneeID != ana.ID {
+					t.Errorf("AssigneeID = %q, want %q", task.AssigneeID, ana.ID)
+				}
+			},
+		},
+		{
+			name: "assignee by ID",
+			args: []string{"--assignee", "1234567"},
+			check: func(t *testing.T, task api.Task, _ *api.Collaborator) {
+				if task.AssigneeID != "1234567" {
+					t.Errorf("AssigneeID = %q, want the ID passed through", task.AssigneeID)
+				}
+			},
+		},
+		{name: "nothing to update", args: nil, wantErr: "nothing to update"},
+		{name: "bad priority", args: []string{"--priority", "9"}, wantErr: "priority"},
+		{name: "bad duration", args: []string{"--duration", "1.5h"}, wantErr: "duration must be"},
+		{name: "missing value", args: []string{"--content"}, wantErr: "requires an argument"},
+		{name: "unknown flag", args: []string{"--bogus", "x"}, wantErr: "unknown flag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFake()
+			work := f.AddProject(api.Project{Name: "Work"})
+			ana := f.AddCollaborator(work.ID, api.Collaborator{Name: "Ana Lima", Email: "ana@example.com"})
+			task := f.AddTask(api.Task{Content: "Draft", ProjectID: work.ID, Labels: []string{"home", "Waiting"}})
+
+			out, err := run(t, EditCmd, f, true, append([]string{task.ID}, tt.args...)...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("EditCmd() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("EditCmd() error = %v", err)
+			}
+
+			var updated api.Task
+			if err := json.Unmarshal([]byte(out), &updated); err != nil {
+				t.Fatalf("output is not a task: %v\n%s", err, out)
+			}
+			stored, _ := f.Task(task.ID)
+			tt.check(t, stored, ana)
+		})
+	}
+}
+
+func TestEditCmd_requiresTaskID(t *testing.T) {
+	_, err := run(t, EditCmd, newFake(), false, "--content", "x")
+	if err == nil || !strings.Contains(err.Error(), "requires a task ID") {
+		t.Errorf("EditCmd() error = %v, want a missing task ID error", err)
+	}
+}
+
+func TestSplitLabels(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"", []string{}},
+		{"work", []string{"work"}},
+		{"work,home", []string{"work", "home"}},
+		{"@work, home", []string{"work", "home"}},
+		{" , @ ,work,", []string{"work"}},
+	}
+	for _, tt := range tests {
+		if got := splitLabels(tt.input); !slices.Equal(got, tt.want) {
+			t.Errorf("splitLabels(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestMergeLabels(t *testing.T) {
+	tests := []struct {
+		name                 string
+		current, add, remove []string
+		want                 []string
+	}{
+		{"nothing", nil, nil, nil, []string{}},
+		{"add", []string{"work"}, []string{"today"}, nil, []string{"work", "today"}},
+		{"remove", []string{"work", "today"}, nil, []string{"work"}, []string{"today"}},
+		{"duplicates dropped", []string{"work"}, []string{"Work", "today", "today"}, nil, []string{"work", "today"}},
+		{"remove ignores case", []string{"Waiting", "home"}, nil, []string{"waiting"}, []string{"home"}},
+		{"remove wins over add", []string{"home"}, []string{"today"}, []string{"TODAY"}, []string{"home"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeLabels(tt.current, tt.add, tt.remove)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("mergeLabels(%v, %v, %v) = %v, want %v", tt.current, tt.add, tt.remove, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/joeyhipolito/todoist-cli/internal/config"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
+	"github.com/joeyhipolito/todoist-cli/internal/transform"
+)
+
+// OutputFormat resolves the global output flags: --format (or --json) as
+// name, and --template or --template-file. A --template value without
+// "{{" names a template from the config file ("template.<name>=...").
+func OutputFormat(name, tmpl, tmplFile string) (output.Format, error) {
+	if tmpl != "" && tmplFile != "" {
+		return output.Format{}, fmt.Errorf("--template and --template-file can't be used together")
+	}
+	if (tmpl != "" || tmplFile != "") && name != "" {
+		return output.Format{}, fmt.Errorf("--template can't be combined with --format or --json")
+	}
+
+	switch {
+	case tmplFile != "":
+		data, err := os.ReadFile(tmplFile)
+		if err != nil {
+			return output.Format{}, fmt.Errorf("failed to read template file: %w", err)
+		}
+		return parseTemplate(tmplFile, string(data))
+	case tmpl != "" && !strings.Contains(tmpl, "{{"):
+		text, ok, err := config.ResolveTemplate(tmpl)
+		if err != nil {
+			return output.Format{}, err
+		}
+		if !ok {
+			return output.Format{}, fmt.Errorf("template %q not found; define template.%s in %s, or pass a template such as '{{.ID}} {{.Content}}'", tmpl, tmpl, config.Path())
+		}
+		return parseTemplate(tmpl, text)
+	case tmpl != "":
+		return parseTemplate("--template", tmpl)
+	case name != "":
+		return output.ParseFormat(name)
+	}
+	return output.Text, nil
+}
+
+// parseTemplate parses text with the transform helpers (priority, due,
+// labels, overdue) available.
+func parseTemplate(name, text string) (output.Format, error) {
+	t, err := template.New(name).Funcs(transform.TemplateFuncs()).Parse(text)
+	if err != nil {
+		return output.Format{}, fmt.Errorf("invalid template: %w", err)
+	}
+	return output.TemplateFormat(t), nil
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/config"
+	"github.com/joeyhipolito/todoist-cli/internal/transform"
+)
+
+func TestOutputFormat_templates(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(config.EnvConfigDir, dir)
+	if err := config.Save(&config.Config{Templates: map[string]string{"prompt": "{{priority .}} {{.Content}} {{labels .}}"}}); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(dir, "task.tmpl")
+	if err := os.WriteFile(file, []byte("{{.ID}}\t{{.Content}}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	f := newFake()
+	task := f.AddTask(api.Task{Content: "Buy milk", Priority: 4, Labels: []string{"errands"}, Due: &api.Due{Date: "2026-03-10"}})
+
+	for _, tt := range []struct {
+		tmpl, file, want string
+	}{
+		{tmpl: "prompt", want: "P1 Buy milk @errands\n"},
+		{tmpl: "{{.Content}} ({{due .}})", want: "Buy milk (" + transform.FormatDueDate("2026-03-10", "") + ")\n"},
+		{file: file, want: task.ID + "\tBuy milk\n"},
+	} {
+		format, err := OutputFormat("", tt.tmpl, tt.file)
+		if err != nil {
+			t.Fatalf("OutputFormat(%q, %q) error = %v", tt.tmpl, tt.file, err)
+		}
+		var out bytes.Buffer
+		if err := ListCmd(context.Background(), f, &out, nil, format); err != nil {
+			t.Fatalf("ListCmd() error = %v", err)
+		}
+		if out.String() != tt.want {
+			t.Errorf("ListCmd() with template %q = %q, want %q", tt.tmpl+tt.file, out.String(), tt.want)
+		}
+	}
+
+	errs := []struct {
+		name, tmpl, file, want string
+	}{
+		{tmpl: "missing", want: `template "missing" not found`},
+		{tmpl: "{{.Content", want: "invalid template"},
+		{name: "json", tmpl: "prompt", want: "can't be combined"},
+		{tmpl: "prompt", file: file, want: "can't be used together"},
+		{name: "xml", want: "unknown format"},
+	}
+	for _, tt := range errs {
+		if _, err := OutputFormat(tt.name, tt.tmpl, tt.file); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("OutputFormat(%q, %q, %q) error = %v, want %q", tt.name, tt.tmpl, tt.file, err, tt.want)
+		}
+	}
+}
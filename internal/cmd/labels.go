@@ -1,42 +1,313 @@
 package cmd
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"os"
+	"io"
+	"strings"
 
 	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
 )
 
-// LabelsCmd lists all personal labels.
-func LabelsCmd(token string, args []string, jsonOutput bool) error {
-	client, err := api.NewClient(token)
+const labelsUsage = `Usage:
+  todoist labels                                   List personal labels
+  todoist labels add <name> [--color <c>] [--favorite]
+  todoist labels edit <label> [--name <new>] [--color <c>] [--favorite|--unfavorite]
+  todoist labels rename <old> <new>                Rename on every task
+  todoist labels delete <label>                    Delete and remove from every task
+  todoist labels shared                            List all label names in use, including shared`
+
+// LabelsCmd handles the "labels" command and its subcommands.
+func LabelsCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
+	// No subcommand → list labels (default)
+	if len(args) == 0 || strings.HasPrefix(args[0], "--") {
+		return labelsListCmd(ctx, client, w, args, format)
+	}
+
+	// Dispatch subcommand
+	switch args[0] {
+	case "add":
+		return labelsAddCmd(ctx, client, w, args[1:], format)
+	case "edit":
+		return labelsEditCmd(ctx, client, w, args[1:], format)
+	case "rename":
+		return labelsRenameCmd(ctx, client, w, args[1:], format)
+	case "delete":
+		return labelsDeleteCmd(ctx, client, w, args[1:], format)
+	case "shared":
+		return labelsSharedCmd(ctx, client, w, args[1:], format)
+	default:
+		return fmt.Errorf("unknown labels subcommand: %s\n\n%s", args[0], labelsUsage)
+	}
+}
+
+// labelsListCmd lists all personal labels.
+func labelsListCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
+	opts, args, err := parseListOptions(args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("unknown flag: %s", args[0])
+	}
+
+	it := client.IterLabels(ctx, opts)
+
+	if format != output.Text {
+		return output.Pages(w, format, it, labelColumns)
+	}
+
+	count := 0
+	for it.Next() {
+		for _, l := range it.Page() {
+			marker := " "
+			if l.IsFavorite {
+				marker = "*"
+			}
+			fmt.Fprintf(w, "  %s @%s (%s)\n", marker, l.Name, l.ID)
+			count++
+		}
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+
+	if count == 0 {
+		fmt.Fprintln(w, "No labels found.")
+	}
+
+	return nil
+}
+
+// labelsAddCmd creates a new personal label.
+func labelsAddCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
+	if len(args) < 1 || strings.HasPrefix(args[0], "--") {
+		return fmt.Errorf("labels add requires a label name\n\nUsage: todoist labels add <name> [--color <color>] [--favorite]")
+	}
+
+	req := &api.CreateLabelRequest{
+		Name: strings.TrimPrefix(args[0], "@"),
+	}
+
+	args = args[1:]
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--color":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--color requires a color name")
+			}
+			if err := validateColor(args[i+1]); err != nil {
+				return err
+			}
+			req.Color = args[i+1]
+			i++
+		case "--favorite":
+			req.IsFavorite = true
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+
+	label, err := client.CreateLabel(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if format != output.Text {
+		return output.Value(w, format, label)
+	}
+
+	fmt.Fprintf(w, "Created label: @%s (ID: %s)\n", label.Name, label.ID)
+	return nil
+}
+
+// labelsEditCmd updates the name, color, or favorite flag of a personal label.
+func labelsEditCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
+	if len(args) < 1 || strings.HasPrefix(args[0], "--") {
+		return fmt.Errorf("labels edit requires a label\n\nUsage: todoist labels edit <label> [--name <new>] [--color <color>] [--favorite|--unfavorite]")
+	}
+
+	name := args[0]
+	req := &api.UpdateLabelRequest{}
+
+	args = args[1:]
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--name":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--name requires a label name")
+			}
+			newName := strings.TrimPrefix(args[i+1], "@")
+			req.Name = &newName
+			i++
+		case "--color":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--color requires a color name")
+			}
+			if err := validateColor(args[i+1]); err != nil {
+				return err
+			}
+			color := args[i+1]
+			req.Color = &color
+			i++
+		case "--favorite", "--unfavorite":
+			fav := args[i] == "--favorite"
+			req.IsFavorite = &fav
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+
+	if *req == (api.UpdateLabelRequest{}) {
+		return fmt.Errorf("nothing to update\n\nUsage: todoist labels edit <label> [--name <new>] [--color <color>] [--favorite|--unfavorite]")
+	}
+
+	label, err := findLabel(ctx, client, name)
 	if err != nil {
 		return err
 	}
+	if label == nil {
+		return fmt.Errorf("label not found: %s", name)
+	}
 
-	labels, err := client.GetLabels()
+	updated, err := client.UpdateLabel(ctx, label.ID, req)
 	if err != nil {
 		return err
 	}
 
-	if jsonOutput {
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(labels)
+	if format != output.Text {
+		return output.Value(w, format, updated)
 	}
 
-	if len(labels) == 0 {
-		fmt.Println("No labels found.")
+	fmt.Fprintf(w, "Updated label: @%s (ID: %s)\n", updated.Name, updated.ID)
+	return nil
+}
+
+// labelsRenameCmd renames a label on every task that uses it. Personal labels
+// are renamed in place; names that only exist as shared labels go through
+// the shared rename endpoint. A name that is neither is an error.
+func labelsRenameCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
+	if len(args) < 2 {
+		return fmt.Errorf("labels rename requires the old and new names\n\nUsage: todoist labels rename <old> <new>")
+	}
+
+	oldName := strings.TrimPrefix(args[0], "@")
+	newName := strings.TrimPrefix(args[1], "@")
+
+	label, err := findLabel(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	if label != nil {
+		updated, err := client.UpdateLabel(ctx, label.ID, &api.UpdateLabelRequest{Name: &newName})
+		if err != nil {
+			return err
+		}
+
+		if format != output.Text {
+			return output.Value(w, format, updated)
+		}
+
+		fmt.Fprintf(w, "Renamed label @%s to @%s.\n", label.Name, updated.Name)
 		return nil
 	}
 
-	for _, l := range labels {
-		marker := " "
-		if l.IsFavorite {
-			marker = "*"
+	if oldName, err = findSharedLabel(ctx, client, oldName); err != nil {
+		return err
+	}
+	if oldName == "" {
+		return fmt.Errorf("label not found: %s", args[0])
+	}
+	if err := client.RenameSharedLabel(ctx, oldName, newName); err != nil {
+		return err
+	}
+
+	if format != output.Text {
+		return output.Value(w, format, map[string]string{
+			"status":   "ok",
+			"name":     oldName,
+			"new_name": newName,
+			"message":  "Shared label renamed",
+		})
+	}
+
+	fmt.Fprintf(w, "Renamed shared label @%s to @%s.\n", oldName, newName)
+	return nil
+}
+
+// labelsDeleteCmd deletes a personal label, or removes a shared label from
+// every task. A name that is neither is an error.
+func labelsDeleteCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
+	if len(args) < 1 {
+		return fmt.Errorf("labels delete requires a label\n\nUsage: todoist labels delete <label>")
+	}
+
+	name := strings.TrimPrefix(args[0], "@")
+
+	label, err := findLabel(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	result := map[string]string{"status": "ok", "name": name}
+	if label != nil {
+		if err := client.DeleteLabel(ctx, label.ID); err != nil {
+			return err
+		}
+		result["label_id"] = label.ID
+		result["message"] = "Label deleted"
+	} else {
+		if name, err = findSharedLabel(ctx, client, name); err != nil {
+			return err
 		}
-		fmt.Printf("  %s @%s (%s)\n", marker, l.Name, l.ID)
+		if name == "" {
+			return fmt.Errorf("label not found: %s", args[0])
+		}
+		if err := client.RemoveSharedLabel(ctx, name); err != nil {
+			return err
+		}
+		result["name"] = name
+		result["message"] = "Shared label removed"
+	}
+
+	if format != output.Text {
+		return output.Value(w, format, result)
+	}
+
+	fmt.Fprintf(w, "%s: @%s\n", result["message"], name)
+	return nil
+}
+
+// labelsSharedCmd lists every label name in use, including shared labels.
+func labelsSharedCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
+	omitPersonal := false
+	for _, arg := range args {
+		switch arg {
+		case "--omit-personal":
+			omitPersonal = true
+		default:
+			return fmt.Errorf("unknown flag: %s", arg)
+		}
+	}
+
+	names, err := client.GetSharedLabels(ctx, omitPersonal)
+	if err != nil {
+		return err
+	}
+
+	if format != output.Text {
+		return output.Value(w, format, names)
+	}
+
+	if len(names) == 0 {
+		fmt.Fprintln(w, "No shared labels found.")
+		return nil
+	}
+
+	for _, name := range names {
+		fmt.Fprintf(w, "  @%s\n", name)
 	}
 
 	return nil
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+func TestLabelsCmd(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		json      bool
+		wantOut   string   // HOME is replaced by the personal label's ID
+		wantCalls []string // calls that must be made, HOME replaced likewise
+		wantErr   string
+	}{
+		{
+			name:      "add",
+			args:      []string{"add", "@errands", "--color", "red", "--favorite"},
+			wantOut:   "Created label: @errands (ID: ",
+			wantCalls: []string{"CreateLabel errands"},
+		},
+		{
+			name:      "edit",
+			args:      []string{"edit", "@HOME", "--color", "green", "--unfavorite"},
+			json:      true,
+			wantOut:   "{\n  \"id\": \"HOME\",\n  \"name\": \"home\",\n  \"color\": \"green\",\n  \"order\": 0,\n  \"is_favorite\": false\n}\n",
+			wantCalls: []string{"UpdateLabel HOME"},
+		},
+		{
+			name:      "rename personal",
+			args:      []string{"rename", "@home", "house"},
+			wantOut:   "Renamed label @home to @house.\n",
+			wantCalls: []string{"UpdateLabel HOME"},
+		},
+		{
+			name:      "rename shared",
+			args:      []string{"rename", "@Team", "crew"},
+			json:      true,
+			wantOut:   "{\n  \"message\": \"Shared label renamed\",\n  \"name\": \"team\",\n  \"new_name\": \"crew\",\n  \"status\": \"ok\"\n}\n",
+			wantCalls: []string{"RenameSharedLabel team"},
+		},
+		{
+			name:      "delete personal",
+			args:      []string{"delete", "home"},
+			json:      true,
+			wantOut:   "{\n  \"label_id\": \"HOME\",\n  \"message\": \"Label deleted\",\n  \"name\": \"home\",\n  \"status\": \"ok\"\n}\n",
+			wantCalls: []string{"DeleteLabel HOME"},
+		},
+		{
+			name:      "delete shared",
+			args:      []string{"delete", "@team"},
+			wantOut:   "Shared label removed: @team\n",
+			wantCalls: []string{"RemoveSharedLabel team"},
+		},
+		{
+			name:    "shared",
+			args:    []string{"shared"},
+			json:    true,
+			wantOut: "[\n  \"home\",\n  \"team\"\n]\n",
+		},
+		{
+			name:    "shared omitting personal",
+			args:    []string{"shared", "--omit-personal"},
+			wantOut: "  @team\n",
+		},
+		{name: "add bad color", args: []string{"add", "x", "--color", "teal-ish"}, wantErr: "unknown color: teal-ish"},
+		{name: "edit bad color", args: []string{"edit", "home", "--color", "teal-ish"}, wantErr: "unknown color: teal-ish"},
+		{name: "edit nothing", args: []string{"edit", "home"}, wantErr: "nothing to update"},
+		{name: "edit unknown", args: []string{"edit", "nope", "--favorite"}, wantErr: "label not found: nope"},
+		{name: "rename unknown", args: []string{"rename", "@tema", "crew"}, wantErr: "label not found: @tema"},
+		{name: "delete unknown", args: []string{"delete", "@urgnet"}, wantErr: "label not found: @urgnet"},
+		{name: "unknown subcommand", args: []string{"merge"}, wantErr: "unknown labels subcommand"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFake()
+			home := f.AddLabel(api.Label{Name: "home", Color: "blue", IsFavorite: true})
+			f.AddSharedLabel("team")
+			ids := strings.NewReplacer("HOME", home.ID)
+
+			var args []string
+			for _, a := range tt.args {
+				if a == "@HOME" {
+					a = home.ID
+				}
+				args = append(args, a)
+			}
+
+			out, err := run(t, LabelsCmd, f, tt.json, args...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LabelsCmd() error = %v, want %q", err, tt.wantErr)
+				}
+				// Nothing is changed on a failed lookup or bad input.
+				for _, c := range f.Calls() {
+					if !strings.HasPrefix(c, "Get") {
+						t.Errorf("LabelsCmd() made call %q", c)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LabelsCmd() error = %v", err)
+			}
+			if want := ids.Replace(tt.wantOut); !strings.HasPrefix(out, want) {
+				t.Errorf("LabelsCmd() output = %q, want %q", out, want)
+			}
+			calls := f.Calls()
+			for _, c := range tt.wantCalls {
+				if want := ids.Replace(c); !slices.Contains(calls, want) {
+					t.Errorf("calls = %q, want %q", calls, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLabelsCmd_addJSON(t *testing.T) {
+	f := newFake()
+	out, err := run(t, LabelsCmd, f, true, "add", "errands", "--color", "red", "--favorite")
+	if err != nil {
+		t.Fatalf("LabelsCmd() error = %v", err)
+	}
+	var label api.Label
+	if err := json.Unmarshal([]byte(out), &label); err != nil {
+		t.Fatalf("output is not a label: %v\n%s", err, out)
+	}
+	if label.ID == "" || label.Name != "errands" || label.Color != "red" || !label.IsFavorite {
+		t.Errorf("LabelsCmd(add) = %+v, want a red favorite errands label", label)
+	}
+}
@@ -1,62 +1,143 @@
 package cmd
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"os"
+	"io"
 	"strings"
 
 	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/filter"
+	"github.com/joeyhipolito/todoist-cli/internal/offline"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
 	"github.com/joeyhipolito/todoist-cli/internal/transform"
 )
 
 // ListCmd lists active tasks with optional filtering.
-func ListCmd(token string, args []string, jsonOutput bool) error {
-	client, err := api.NewClient(token)
+func ListCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
+	opts, args, err := parseListOptions(args)
 	if err != nil {
 		return err
 	}
 
-	// Parse --filter flag
-	filter := ""
+	// Parse flags
+	var query, projectName, sectionName string
+	tree := false
 	for i := 0; i < len(args); i++ {
-		if args[i] == "--filter" {
+		switch args[i] {
+		case "--filter":
 			if i+1 >= len(args) {
 				return fmt.Errorf("--filter requires a query string")
 			}
-			filter = args[i+1]
+			query = args[i+1]
 			i++
+		case "--project":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--project requires a name")
+			}
+			projectName = args[i+1]
+			i++
+		case "--section":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--section requires a name")
+			}
+			sectionName = args[i+1]
+			i++
+		case "--tree":
+			tree = true
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
 		}
 	}
 
-	tasks, err := client.GetTasks(filter, "")
+	// Structured output is a flat list of tasks; parent_id already carries
+	// the nesting for anyone who wants to rebuild it.
+	if tree && format != output.Text {
+		return fmt.Errorf("--tree is only supported with text output")
+	}
+
+	// Catch syntax mistakes here, where the error can point at the column,
+	// rather than as a bare 400 from the API.
+	if query != "" {
+		if _, err := filter.Parse(query); err != nil {
+			return err
+		}
+	}
+
+	var projectID, sectionID string
+	if projectName != "" {
+		projectID, err = resolveProjectID(ctx, client, projectName)
+		if err != nil {
+			return err
+		}
+	}
+	if sectionName != "" {
+		section, err := resolveSection(ctx, client, projectID, sectionName)
+		if err != nil {
+			return err
+		}
+		sectionID = section.ID
+	}
+
+	it := client.IterTasks(ctx, query, projectID, sectionID, opts)
+
+	if format != output.Text {
+		return output.Pages(w, format, it, taskColumns)
+	}
+
+	projects, err := client.GetProjects(ctx)
 	if err != nil {
 		return err
 	}
-
-	if jsonOutput {
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(tasks)
+	line, err := newTaskLine(w, projects)
+	if err != nil {
+		return err
 	}
 
-	if len(tasks) == 0 {
-		fmt.Println("No tasks found.")
+	// A tree needs every task before it can place subtasks, so it can't stream.
+	if tree {
+		tasks, err := it.All()
+		if err != nil {
+			return err
+		}
+		if len(tasks) == 0 {
+			fmt.Fprintln(w, "No tasks found.")
+			return nil
+		}
+		transform.WalkTaskTree(transform.BuildTaskTree(tasks), func(n *transform.TaskNode, depth int) {
+			fmt.Fprintln(w, strings.Repeat("  ", depth)+line.Format(n.Task))
+		})
+		printSnapshotNote(w, client)
 		return nil
 	}
 
-	for _, t := range tasks {
-		priority := transform.FormatPriority(t.Priority)
-		due := ""
-		if t.Due != nil {
-			due = " (" + t.Due.Date + ")"
-		}
-		labels := ""
-		if len(t.Labels) > 0 {
-			labels = " @" + strings.Join(t.Labels, " @")
+	count := 0
+	for it.Next() {
+		for _, t := range it.Page() {
+			fmt.Fprintln(w, line.Format(t))
+			count++
 		}
-		fmt.Printf("  %s [%s] %s%s%s\n", t.ID, priority, t.Content, due, labels)
 	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+
+	if count == 0 {
+		fmt.Fprintln(w, "No tasks found.")
+	}
+	printSnapshotNote(w, client)
 
 	return nil
 }
+
+// printSnapshotNote tells the reader when the tasks shown came from the
+// offline snapshot rather than the API.
+func printSnapshotNote(w io.Writer, client api.TodoistAPI) {
+	oc, ok := findClient[*offline.Client](client)
+	if !ok {
+		return
+	}
+	if syncedAt, ok := oc.FromSnapshot(); ok {
+		fmt.Fprintf(w, "(offline: as of the last sync, %s, plus queued changes)\n", syncedAt.Local().Format("2006-01-02 15:04"))
+	}
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
+)
+
+func TestListCmd(t *testing.T) {
+	f := newFake()
+	work := f.AddProject(api.Project{Name: "Work"})
+	doing := f.AddSection(api.Section{Name: "Doing", ProjectID: work.ID})
+	milk := f.AddTask(api.Task{Content: "Buy milk", Labels: []string{"errand"}})
+	report := f.AddTask(api.Task{Content: "Write report", ProjectID: work.ID, SectionID: doing.ID, Priority: 4,
+		Due: &api.Due{Date: "2026-03-12"}, Deadline: &api.Deadline{Date: "2026-03-13"}})
+	outline := f.AddTask(api.Task{Content: "Outline", ProjectID: work.ID, SectionID: doing.ID, ParentID: report.ID})
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{
+			name: "all tasks",
+			want: []string{
+				"  " + milk.ID + " [P4] Buy milk @errand #Inbox",
+				"  " + report.ID + " [P1] Write report (2026-03-12) #Work (deadline 2026-03-13)",
+				"  " + outline.ID + " [P4] Outline #Work",
+			},
+		},
+		{
+			name: "by project",
+			args: []string{"--project", "work"},
+			want: []string{
+				"  " + report.ID + " [P1] Write report (2026-03-12) #Work (deadline 2026-03-13)",
+				"  " + outline.ID + " [P4] Outline #Work",
+			},
+		},
+		{
+			name: "tree",
+			args: []string{"--project", "Work", "--section", "Doing", "--tree"},
+			want: []string{
+				"  " + report.ID + " [P1] Write report (2026-03-12) #Work (deadline 2026-03-13)",
+				"    " + outline.ID + " [P4] Outline #Work",
+			},
+		},
+		{
+			name: "limit across pages",
+			args: []string{"--limit", "2", "--page-size", "1"},
+			want: []string{
+				"  " + milk.ID + " [P4] Buy milk @errand #Inbox",
+				"  " + report.ID + " [P1] Write report (2026-03-12) #Work (deadline 2026-03-13)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := run(t, ListCmd, f, false, tt.args...)
+			if err != nil {
+				t.Fatalf("ListCmd() error = %v", err)
+			}
+			if got := strings.Split(strings.TrimSuffix(out, "\n"), "\n"); strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("ListCmd() output:\n%s\nwant:\n%s", out, strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestListCmd_empty(t *testing.T) {
+	f := newFake()
+
+	out, err := run(t, ListCmd, f, false)
+	if err != nil || out != "No tasks found.\n" {
+		t.Errorf("ListCmd() = %q, %v, want No tasks found.", out, err)
+	}
+
+	out, err = run(t, ListCmd, f, true)
+	if err != nil || out != "[]\n" {
+		t.Errorf("ListCmd() --json = %q, %v, want []", out, err)
+	}
+}
+
+func TestListCmd_csv(t *testing.T) {
+	f := newFake()
+	f.AddTask(api.Task{Content: "Buy milk, eggs", Priority: 4, Labels: []string{"errands"}, Due: &api.Due{Date: "2026-03-10"}})
+
+	var out bytes.Buffer
+	if err := ListCmd(context.Background(), f, &out, nil, output.CSV); err != nil {
+		t.Fatalf("ListCmd() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || lines[0] != "ID,PRIORITY,CONTENT,DUE,LABELS,PROJECT_ID" || !strings.Contains(lines[1], `,P1,"Buy milk, eggs",2026-03-10,errands,`) {
+		t.Errorf("ListCmd() --format csv =\n%s", out.String())
+	}
+}
+
+func TestListCmd_json(t *testing.T) {
+	f := newFake()
+	for _, c := range []string{"a", "b", "c"} {
+		f.AddTask(api.Task{Content: c})
+	}
+
+	out, err := run(t, ListCmd, f, true, "--page-size", "2")
+	if err != nil {
+		t.Fatalf("ListCmd() error = %v", err)
+	}
+	var tasks []api.Task
+	if err := json.Unmarshal([]byte(out), &tasks); err != nil {
+		t.Fatalf("output is not a JSON array: %v\n%s", err, out)
+	}
+	if len(tasks) != 3 || tasks[2].Content != "c" {
+		t.Errorf("got %d tasks, want a, b, c", len(tasks))
+	}
+}
+
+func TestListCmd_treeNeedsText(t *testing.T) {
+	f := newFake()
+	f.AddTask(api.Task{Content: "Buy milk"})
+
+	for _, format := range []output.Format{output.JSON, output.CSV} {
+		var out bytes.Buffer
+		err := ListCmd(context.Background(), f, &out, []string{"--tree"}, format)
+		if err == nil || !strings.Contains(err.Error(), "--tree is only supported with text output") {
+			t.Errorf("ListCmd(--tree) format %v error = %v, want a text-only error", format, err)
+		}
+		if out.Len() != 0 {
+			t.Errorf("ListCmd(--tree) format %v wrote %q", format, out.String())
+		}
+	}
+}
+
+func TestListCmd_errors(t *testing.T) {
+	f := newFake()
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"--project", "Nope"}, "not found"},
+		{[]string{"--filter", "today"}, "filters are not supported"},
+		{[]string{"--filter", "today & (p1 |"}, "invalid filter at column 14"},
+		{[]string{"--limit", "0"}, "--limit"},
+		{[]string{"--projct", "Work"}, "unknown flag: --projct"},
+		{[]string{"Work"}, "unknown flag: Work"},
+	}
+	for _, tt := range tests {
+		if _, err := run(t, ListCmd, f, false, tt.args...); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("ListCmd(%v) error = %v, want %q", tt.args, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/offline"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
+)
+
+// MoveCmd moves one or more tasks to a project, section, or parent task.
+func MoveCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
+	usage := "Usage: todoist move <task-id...> (--to-project <name> [--section <name>] | --parent <task-id>)"
+
+	var taskIDs []string
+	var projectName, sectionName, parentID string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--to-project", "--project":
+			if i+1 >= len(args) {
+				return fmt.Errorf("%s requires a project name", args[i])
+			}
+			projectName = args[i+1]
+			i++
+		case "--section":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--section requires a section name")
+			}
+			sectionName = args[i+1]
+			i++
+		case "--parent":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--parent requires a task ID")
+			}
+			parentID = args[i+1]
+			i++
+		default:
+			if strings.HasPrefix(args[i], "--") {
+				return fmt.Errorf("unknown flag: %s", args[i])
+			}
+			taskIDs = append(taskIDs, args[i])
+		}
+	}
+
+	if len(taskIDs) == 0 {
+		return fmt.Errorf("move requires at least one task ID\n\n%s", usage)
+	}
+	if projectName == "" && sectionName == "" && parentID == "" {
+		return fmt.Errorf("move requires a destination\n\n%s", usage)
+	}
+	// A subtask lands in its parent's project and section, so a project or
+	// section given with --parent would be silently ignored.
+	if parentID != "" && (projectName != "" || sectionName != "") {
+		return fmt.Errorf("--parent can't be combined with --to-project or --section: subtasks move into their parent's project\n\n%s", usage)
+	}
+
+	// The API takes exactly one destination; the most specific one wins.
+	// A section already implies its project.
+	req := &api.MoveTaskRequest{}
+	dest := ""
+	var err error
+	switch {
+	case parentID != "":
+		req.ParentID = parentID
+		dest = "parent " + parentID
+	case sectionName != "":
+		var projectID string
+		if projectName != "" {
+			if projectID, err = resolveProjectID(ctx, client, projectName); err != nil {
+				return err
+			}
+		}
+		section, err := resolveSection(ctx, client, projectID, sectionName)
+		if err != nil {
+			return err
+		}
+		req.SectionID = section.ID
+		dest = "section " + section.Name
+	default:
+		if req.ProjectID, err = resolveProjectID(ctx, client, projectName); err != nil {
+			return err
+		}
+		dest = "project " + projectName
+	}
+
+	// Every move goes out in one sync request instead of a request per task.
+	cmds := make([]api.SyncCommand, len(taskIDs))
+	for i, id := range taskIDs {
+		cmds[i] = api.MoveTaskCommand(id, req)
+	}
+	resp, err := api.RunCommands(ctx, client, cmds)
+	if _, ok := offline.AsQueued(err); ok {
+		return reportQueuedMoves(w, taskIDs, dest, format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to move tasks: %w", err)
+	}
+
+	results := []map[string]string{}
+	var failed []string
+	for i, id := range taskIDs {
+		if err := resp.Err(cmds[i]); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", id, err))
+			continue
+		}
+		results = append(results, map[string]string{
+			"status":  "ok",
+			"task_id": id,
+			"message": "Task moved to " + dest,
+		})
+		if format == output.Text {
+			fmt.Fprintf(w, "Task %s moved to %s.\n", id, dest)
+		}
+	}
+
+	if format != output.Text {
+		if err := output.Value(w, format, results); err != nil {
+			return err
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to move %d task(s): %s", len(failed), strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// reportQueuedMoves writes the result of moves queued for the next sync.
+func reportQueuedMoves(w io.Writer, taskIDs []string, dest string, format output.Format) error {
+	if format != output.Text {
+		results := make([]map[string]string, len(taskIDs))
+		for i, id := range taskIDs {
+			results[i] = map[string]string{
+				"status":  "queued",
+				"task_id": id,
+				"message": "Task move to " + dest + " queued",
+			}
+		}
+		return output.Value(w, format, results)
+	}
+
+	for _, id := range taskIDs {
+		fmt.Fprintf(w, "Task %s move to %s queued.\n", id, dest)
+	}
+	fmt.Fprintln(w, "Run 'todoist sync' when back online.")
+	return nil
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+func TestMoveCmd(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		json        bool
+		wantOut     string
+		wantErr     string
+		wantProject string // project the first two tasks end up in
+	}{
+		{
+			name:        "several tasks in one sync",
+			args:        []string{"A", "B", "--to-project", "work"},
+			wantOut:     "Task A moved to project work.\nTask B moved to project work.\n",
+			wantProject: "Work",
+		},
+		{
+			name:        "section implies project",
+			args:        []string{"A", "B", "--section", "Backlog"},
+			wantOut:     "Task A moved to section Backlog.\nTask B moved to section Backlog.\n",
+			wantProject: "Work",
+		},
+		{
+			name:        "json",
+			args:        []string{"A", "--to-project", "Work"},
+			json:        true,
+			wantOut:     "[\n  {\n    \"message\": \"Task moved to project Work\",\n    \"status\": \"ok\",\n    \"task_id\": \"A\"\n  }\n]\n",
+			wantProject: "",
+		},
+		{
+			name:        "partial failure",
+			args:        []string{"A", "999", "B", "--to-project", "Work"},
+			wantOut:     "Task A moved to project Work.\nTask B moved to project Work.\n",
+			wantErr:     "failed to move 1 task(s): 999:",
+			wantProject: "Work",
+		},
+		{name: "no tasks", args: []string{"--to-project", "Work"}, wantErr: "at least one task ID"},
+		{name: "no destination", args: []string{"A"}, wantErr: "requires a destination"},
+		{name: "unknown project", args: []string{"A", "--to-project", "Nope"}, wantErr: "Nope"},
+		{name: "parent and project", args: []string{"A", "--parent", "B", "--to-project", "Work"}, wantErr: "can't be combined"},
+		{name: "parent and section", args: []string{"A", "--section", "Backlog", "--parent", "B"}, wantErr: "can't be combined"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFake()
+			work := f.AddProject(api.Project{Name: "Work"})
+			f.AddSection(api.Section{Name: "Backlog", ProjectID: work.ID})
+			a := f.AddTask(api.Task{Content: "A"})
+			b := f.AddTask(api.Task{Content: "B"})
+			ids := strings.NewReplacer("A", a.ID, "B", b.ID)
+
+			var args []string
+			for _, arg := range tt.args {
+				if arg == "A" || arg == "B" {
+					arg = ids.Replace(arg)
+				}
+				args = append(args, arg)
+			}
+
+			out, err := run(t, MoveCmd, f, tt.json, args...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("MoveCmd() error = %v, want %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("MoveCmd() error = %v", err)
+			}
+			if want := strings.NewReplacer("Task A", "Task "+a.ID, "Task B", "Task "+b.ID, `"A"`, `"`+a.ID+`"`).Replace(tt.wantOut); out != want {
+				t.Errorf("MoveCmd() output = %q, want %q", out, want)
+			}
+			if tt.wantProject == "" {
+				return
+			}
+
+			var syncs int
+			for _, c := range f.Calls() {
+				if strings.HasPrefix(c, "Sync ") {
+					syncs++
+				}
+			}
+			if syncs != 1 {
+				t.Errorf("made %d sync requests, want 1", syncs)
+			}
+			for _, id := range []string{a.ID, b.ID} {
+				if task, _ := f.Task(id); task.ProjectID != work.ID {
+					t.Errorf("task %s in project %s, want %s", id, task.ProjectID, work.ID)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+// parseListOptions extracts --limit and --page-size from args and returns
+// the remaining arguments untouched, in order.
+func parseListOptions(args []string) (api.ListOptions, []string, error) {
+	var opts api.ListOptions
+	var rest []string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--limit":
+			if i+1 >= len(args) {
+				return opts, nil, fmt.Errorf("--limit requires a number")
+			}
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil || n <= 0 {
+				return opts, nil, fmt.Errorf("--limit must be a positive integer")
+			}
+			opts.Limit = n
+			i++
+		case "--page-size":
+			if i+1 >= len(args) {
+				return opts, nil, fmt.Errorf("--page-size requires a number")
+			}
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil || n <= 0 || n > api.MaxPageSize {
+				return opts, nil, fmt.Errorf("--page-size must be between 1 and %d", api.MaxPageSize)
+			}
+			opts.PageSize = n
+			i++
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+
+	return opts, rest, nil
+}
@@ -1,121 +1,436 @@
 package cmd
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"os"
+	"io"
+	"sort"
+	"strings"
 
 	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
 )
 
+const projectsUsage = `Usage:
+  todoist projects                      List all projects as a tree
+  todoist projects add <name>           Create a project
+      [--parent <name>] [--color <c>] [--favorite] [--view list|board|calendar]
+  todoist projects edit <project>       Update a project
+      [--name <new>] [--color <c>] [--favorite|--unfavorite] [--view <style>]
+  todoist projects archive <project>    Archive a project
+  todoist projects unarchive <project>  Restore an archived project
+  todoist projects archived             List archived projects
+  todoist projects delete <project>     Delete a project and all its tasks [--yes]
+
+<project> is a project ID or name.`
+
 // ProjectsCmd handles the "projects" command and its subcommands.
-func ProjectsCmd(token string, args []string, jsonOutput bool) error {
+func ProjectsCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
 	// No subcommand → list projects (default)
-	if len(args) == 0 {
-		return projectsListCmd(token, jsonOutput)
+	if len(args) == 0 || strings.HasPrefix(args[0], "--") {
+		return projectsListCmd(ctx, client, w, args, format)
 	}
 
 	// Dispatch subcommand
 	switch args[0] {
 	case "add":
-		return projectsAddCmd(token, args[1:], jsonOutput)
+		return projectsAddCmd(ctx, client, w, args[1:], format)
+	case "edit":
+		return projectsEditCmd(ctx, client, w, args[1:], format)
+	case "archive":
+		return projectsArchiveCmd(ctx, client, w, args[1:], format)
+	case "unarchive":
+		return projectsUnarchiveCmd(ctx, client, w, args[1:], format)
+	case "archived":
+		return projectsArchivedCmd(ctx, client, w, args[1:], format)
 	case "delete":
-		return projectsDeleteCmd(token, args[1:], jsonOutput)
+		return projectsDeleteCmd(ctx, client, w, args[1:], format)
 	default:
-		return fmt.Errorf("unknown projects subcommand: %s\n\nUsage:\n  todoist projects              List all projects\n  todoist projects add <name>   Create a project\n  todoist projects delete <id>  Delete a project", args[0])
+		return fmt.Errorf("unknown projects subcommand: %s\n\n%s", args[0], projectsUsage)
 	}
 }
 
-// projectsListCmd lists all projects.
-func projectsListCmd(token string, jsonOutput bool) error {
-	client, err := api.NewClient(token)
+// projectsListCmd lists all projects, indented under their parents.
+func projectsListCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
+	opts, args, err := parseListOptions(args)
 	if err != nil {
 		return err
 	}
+	if len(args) > 0 {
+		return fmt.Errorf("unknown flag: %s", args[0])
+	}
 
-	projects, err := client.GetProjects()
-	if err != nil {
-		return err
+	it := client.IterProjects(ctx, opts)
+
+	if format != output.Text {
+		return output.Pages(w, format, it, projectColumns)
 	}
 
-	if jsonOutput {
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(projects)
+	// Indenting needs every parent, so text output can't stream.
+	projects, err := it.All()
+	if err != nil {
+		return err
 	}
 
 	if len(projects) == 0 {
-		fmt.Println("No projects found.")
+		fmt.Fprintln(w, "No projects found.")
 		return nil
 	}
 
+	printProjectTree(w, projects)
+	return nil
+}
+
+// printProjectTree prints projects indented under their parents, ordered
+// by child_order. Projects whose parent is missing are printed as roots.
+func printProjectTree(w io.Writer, projects []*api.Project) {
+	byID := make(map[string]bool, len(projects))
+	for _, p := range projects {
+		byID[p.ID] = true
+	}
+
+	children := make(map[string][]*api.Project)
 	for _, p := range projects {
-		marker := " "
-		if p.IsFavorite {
-			marker = "*"
+		parent := p.ParentID
+		if !byID[parent] {
+			parent = ""
 		}
-		fmt.Printf("  %s %s (%s)\n", marker, p.Name, p.ID)
+		children[parent] = append(children[parent], p)
 	}
 
-	return nil
+	var walk func(parentID string, depth int)
+	walk = func(parentID string, depth int) {
+		list := children[parentID]
+		sort.SliceStable(list, func(i, j int) bool { return list[i].Order < list[j].Order })
+		for _, p := range list {
+			marker := " "
+			if p.IsFavorite {
+				marker = "*"
+			}
+			fmt.Fprintf(w, "  %s %s%s (%s)\n", marker, strings.Repeat("  ", depth), p.Name, p.ID)
+			walk(p.ID, depth+1)
+		}
+	}
+	walk("", 0)
 }
 
 // projectsAddCmd creates a new project.
-func projectsAddCmd(token string, args []string, jsonOutput bool) error {
-	if len(args) < 1 {
-		return fmt.Errorf("projects add requires a project name\n\nUsage: todoist projects add <name>")
+func projectsAddCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
+	if len(args) < 1 || strings.HasPrefix(args[0], "--") {
+		return fmt.Errorf("projects add requires a project name\n\nUsage: todoist projects add <name> [--parent <name>] [--color <color>] [--favorite] [--view <style>]")
+	}
+
+	req := &api.CreateProjectRequest{
+		Name: args[0],
 	}
 
-	client, err := api.NewClient(token)
+	var err error
+	args = args[1:]
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--parent":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--parent requires a project name")
+			}
+			if req.ParentID, err = resolveProjectID(ctx, client, args[i+1]); err != nil {
+				return err
+			}
+			i++
+		case "--color":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--color requires a color name")
+			}
+			if err := validateColor(args[i+1]); err != nil {
+				return err
+			}
+			req.Color = args[i+1]
+			i++
+		case "--favorite":
+			req.IsFavorite = true
+		case "--view":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--view requires a style (list, board, calendar)")
+			}
+			if err := validateViewStyle(args[i+1]); err != nil {
+				return err
+			}
+			req.ViewStyle = args[i+1]
+			i++
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+
+	project, err := client.CreateProject(ctx, req)
 	if err != nil {
 		return err
 	}
 
-	req := &api.CreateProjectRequest{
-		Name: args[0],
+	if format != output.Text {
+		return output.Value(w, format, project)
 	}
 
-	project, err := client.CreateProject(req)
+	fmt.Fprintf(w, "Created project: %s (ID: %s)\n", project.Name, project.ID)
+	return nil
+}
+
+// projectsEditCmd updates a project's name, color, favorite flag, or view style.
+func projectsEditCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
+	if len(args) < 1 || strings.HasPrefix(args[0], "--") {
+		return fmt.Errorf("projects edit requires a project\n\nUsage: todoist projects edit <project> [--name <new>] [--color <color>] [--favorite|--unfavorite] [--view <style>]")
+	}
+
+	nameOrID := args[0]
+	req := &api.UpdateProjectRequest{}
+
+	args = args[1:]
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--name":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--name requires a project name")
+			}
+			name := args[i+1]
+			req.Name = &name
+			i++
+		case "--color":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--color requires a color name")
+			}
+			if err := validateColor(args[i+1]); err != nil {
+				return err
+			}
+			color := args[i+1]
+			req.Color = &color
+			i++
+		case "--favorite", "--unfavorite":
+			fav := args[i] == "--favorite"
+			req.IsFavorite = &fav
+		case "--view":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--view requires a style (list, board, calendar)")
+			}
+			if err := validateViewStyle(args[i+1]); err != nil {
+				return err
+			}
+			view := args[i+1]
+			req.ViewStyle = &view
+			i++
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+
+	if *req == (api.UpdateProjectRequest{}) {
+		return fmt.Errorf("nothing to update\n\nUsage: todoist projects edit <project> [--name <new>] [--color <color>] [--favorite|--unfavorite] [--view <style>]")
+	}
+
+	projectID, err := resolveProjectID(ctx, client, nameOrID)
+	if err != nil {
+		return err
+	}
+
+	project, err := client.UpdateProject(ctx, projectID, req)
+	if err != nil {
+		return err
+	}
+
+	if format != output.Text {
+		return output.Value(w, format, project)
+	}
+
+	fmt.Fprintf(w, "Updated project: %s (ID: %s)\n", project.Name, project.ID)
+	return nil
+}
+
+// projectsArchiveCmd archives a project.
+func projectsArchiveCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
+	if len(args) < 1 {
+		return fmt.Errorf("projects archive requires a project\n\nUsage: todoist projects archive <project>")
+	}
+
+	projectID, err := resolveProjectID(ctx, client, args[0])
 	if err != nil {
 		return err
 	}
 
-	if jsonOutput {
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(project)
+	project, err := client.ArchiveProject(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	if format != output.Text {
+		return output.Value(w, format, project)
 	}
 
-	fmt.Printf("Created project: %s (ID: %s)\n", project.Name, project.ID)
+	fmt.Fprintf(w, "Archived project: %s (ID: %s)\n", project.Name, project.ID)
 	return nil
 }
 
-// projectsDeleteCmd permanently deletes a project.
-func projectsDeleteCmd(token string, args []string, jsonOutput bool) error {
+// projectsUnarchiveCmd restores an archived project.
+func projectsUnarchiveCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
 	if len(args) < 1 {
-		return fmt.Errorf("projects delete requires a project ID\n\nUsage: todoist projects delete <project-id>")
+		return fmt.Errorf("projects unarchive requires a project\n\nUsage: todoist projects unarchive <project>")
+	}
+
+	archived, err := client.GetArchivedProjects(ctx)
+	if err != nil {
+		return err
+	}
+	target, err := findProject(archived, args[0])
+	if err != nil {
+		return fmt.Errorf("archived %w", err)
 	}
 
-	client, err := api.NewClient(token)
+	project, err := client.UnarchiveProject(ctx, target.ID)
 	if err != nil {
 		return err
 	}
 
-	projectID := args[0]
-	if err := client.DeleteProject(projectID); err != nil {
+	if format != output.Text {
+		return output.Value(w, format, project)
+	}
+
+	fmt.Fprintf(w, "Unarchived project: %s (ID: %s)\n", project.Name, project.ID)
+	return nil
+}
+
+// projectsArchivedCmd lists archived projects.
+func projectsArchivedCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
+	opts, args, err := parseListOptions(args)
+	if err != nil {
 		return err
 	}
+	if len(args) > 0 {
+		return fmt.Errorf("unknown flag: %s", args[0])
+	}
+
+	it := client.IterArchivedProjects(ctx, opts)
+
+	if format != output.Text {
+		return output.Pages(w, format, it, projectColumns)
+	}
 
-	if jsonOutput {
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(map[string]string{
+	projects, err := it.All()
+	if err != nil {
+		return err
+	}
+
+	if len(projects) == 0 {
+		fmt.Fprintln(w, "No archived projects found.")
+		return nil
+	}
+
+	printProjectTree(w, projects)
+	return nil
+}
+
+// projectsDeleteCmd permanently deletes a project after confirming how many
+// tasks (including those in sub-projects) will be destroyed.
+func projectsDeleteCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
+	skipConfirm := false
+	var rest []string
+	for _, arg := range args {
+		if arg == "--yes" || arg == "-y" {
+			skipConfirm = true
+		} else {
+			rest = append(rest, arg)
+		}
+	}
+
+	if len(rest) < 1 {
+		return fmt.Errorf("projects delete requires a project\n\nUsage: todoist projects delete <project> [--yes]")
+	}
+
+	projectID, err := resolveProjectID(ctx, client, rest[0])
+	if err != nil {
+		return err
+	}
+	projects, err := client.GetProjects(ctx)
+	if err != nil {
+		return err
+	}
+	project, err := findProject(projects, projectID)
+	if err != nil {
+		return err
+	}
+
+	if !skipConfirm {
+		// Deleting a project also deletes its sub-projects and their tasks.
+		doomed := descendantProjectIDs(projects, project.ID)
+		taskCount := 0
+		for _, id := range append([]string{project.ID}, doomed...) {
+			tasks, err := client.GetTasks(ctx, "", id, "")
+			if err != nil {
+				return err
+			}
+			taskCount += len(tasks)
+		}
+
+		question := fmt.Sprintf("Delete project %q", project.Name)
+		if len(doomed) > 0 {
+			question += fmt.Sprintf(" and %d sub-project(s)", len(doomed))
+		}
+		ok, err := confirm(fmt.Sprintf("%s? This permanently destroys %d task(s).", question, taskCount))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("deletion cancelled")
+		}
+	}
+
+	if err := client.DeleteProject(ctx, project.ID); err != nil {
+		return err
+	}
+
+	if format != output.Text {
+		return output.Value(w, format, map[string]string{
 			"status":     "ok",
-			"project_id": projectID,
+			"project_id": project.ID,
 			"message":    "Project deleted",
 		})
 	}
 
-	fmt.Printf("Project %s deleted.\n", projectID)
+	fmt.Fprintf(w, "Project %s deleted.\n", project.Name)
 	return nil
 }
+
+// descendantProjectIDs returns the IDs of every sub-project below projectID.
+// Each project is visited once, so a parent cycle in bad data can't recurse
+// forever.
+func descendantProjectIDs(projects []*api.Project, projectID string) []string {
+	var ids []string
+	seen := map[string]bool{projectID: true}
+	var visit func(id string)
+	visit = func(id string) {
+		for _, p := range projects {
+			if p.ParentID != id || seen[p.ID] {
+				continue
+			}
+			seen[p.ID] = true
+			ids = append(ids, p.ID)
+			visit(p.ID)
+		}
+	}
+	visit(projectID)
+	return ids
+}
+
+// validateColor returns an error listing the valid colors if name isn't one.
+func validateColor(name string) error {
+	if !api.IsValidColor(name) {
+		return fmt.Errorf("unknown color: %s\n\nValid colors: %s", name, strings.Join(api.Colors, ", "))
+	}
+	return nil
+}
+
+// validateViewStyle returns an error if style isn't a project view style.
+func validateViewStyle(style string) error {
+	for _, v := range api.ViewStyles {
+		if v == style {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown view style: %s (use %s)", style, strings.Join(api.ViewStyles, ", "))
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/api/apitest"
+)
+
+func TestProjectsCmd(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    string // output, with project names in {braces} replaced by IDs
+		check   func(t *testing.T, f *apitest.Fake)
+		wantErr string
+	}{
+		{
+			name: "list as tree",
+			want: "    Inbox ({Inbox})\n  * Work ({Work})\n      Clients ({Clients})\n    Home ({Home})\n",
+		},
+		{
+			name: "add under parent",
+			args: []string{"add", "Garden", "--parent", "Home", "--color", "green", "--favorite"},
+			check: func(t *testing.T, f *apitest.Fake) {
+				projects, _ := f.GetProjects(t.Context())
+				p, err := findProject(projects, "Garden")
+				if err != nil {
+					t.Fatal(err)
+				}
+				home, _ := findProject(projects, "Home")
+				if p.ParentID != home.ID || p.Color != "green" || !p.IsFavorite {
+					t.Errorf("project = %+v, want green favorite under Home", p)
+				}
+			},
+		},
+		{
+			name: "archive moves sub-projects too",
+			args: []string{"archive", "Work"},
+			want: "Archived project: Work (ID: {Work})\n",
+			check: func(t *testing.T, f *apitest.Fake) {
+				archived, _ := f.GetArchivedProjects(t.Context())
+				if len(archived) != 2 {
+					t.Errorf("archived %d projects, want Work and Clients", len(archived))
+				}
+			},
+		},
+		{
+			name: "delete with --yes",
+			args: []string{"delete", "Work", "--yes"},
+			want: "Project Work deleted.\n",
+			check: func(t *testing.T, f *apitest.Fake) {
+				tasks, _ := f.GetTasks(t.Context(), "", "", "")
+				if len(tasks) != 0 {
+					t.Errorf("%d tasks left, want the project's tasks deleted", len(tasks))
+				}
+			},
+		},
+		{name: "add without name", args: []string{"add"}, wantErr: "requires a project name"},
+		{name: "bad color", args: []string{"add", "X", "--color", "plaid"}, wantErr: "color"},
+		{name: "unknown project", args: []string{"archive", "Nope"}, wantErr: "not found"},
+		{name: "unknown subcommand", args: []string{"frobnicate"}, wantErr: "unknown projects subcommand"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFake()
+			work := f.AddProject(api.Project{Name: "Work", IsFavorite: true, Order: 1})
+			clients := f.AddProject(api.Project{Name: "Clients", ParentID: work.ID})
+			home := f.AddProject(api.Project{Name: "Home", Order: 2})
+			f.AddTask(api.Task{Content: "Invoice", ProjectID: clients.ID})
+			ids := strings.NewReplacer("{Inbox}", f.InboxID(), "{Work}", work.ID, "{Clients}", clients.ID, "{Home}", home.ID)
+
+			out, err := run(t, ProjectsCmd, f, false, tt.args...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ProjectsCmd() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ProjectsCmd() error = %v", err)
+			}
+			if want := ids.Replace(tt.want); tt.want != "" && out != want {
+				t.Errorf("ProjectsCmd() output:\n%s\nwant:\n%s", out, want)
+			}
+			if tt.check != nil {
+				tt.check(t, f)
+			}
+		})
+	}
+}
+
+func TestProjectsCmd_deletePrompt(t *testing.T) {
+	tests := []struct {
+		name        string
+		interactive bool
+		answer      string
+		wantErr     string
+	}{
+		{name: "yes", interactive: true, answer: "y\n"},
+		{name: "no", interactive: true, answer: "n\n", wantErr: "deletion cancelled"},
+		{name: "end of input", interactive: true, answer: "", wantErr: "deletion cancelled"},
+		{name: "not a terminal", answer: "y\n", wantErr: "pass --yes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var prompt strings.Builder
+			oldIn, oldErr, oldInteractive := stdin, stderr, interactive
+			stdin, stderr, interactive = strings.NewReader(tt.answer), &prompt, func() bool { return tt.interactive }
+			t.Cleanup(func() { stdin, stderr, interactive = oldIn, oldErr, oldInteractive })
+
+			f := newFake()
+			work := f.AddProject(api.Project{Name: "Work"})
+			clients := f.AddProject(api.Project{Name: "Clients", ParentID: work.ID})
+			f.AddTask(api.Task{Content: "Invoice", ProjectID: clients.ID})
+
+			out, err := run(t, ProjectsCmd, f, true, "delete", "work")
+			projects, _ := f.GetProjects(t.Context())
+			_, findErr := findProject(projects, "Work")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ProjectsCmd() error = %v, want %q", err, tt.wantErr)
+				}
+				if out != "" || findErr != nil {
+					t.Errorf("output = %q, project found = %v; want no output and the project kept", out, findErr == nil)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ProjectsCmd() error = %v", err)
+			}
+			if !strings.Contains(out, `"status": "ok"`) || findErr == nil {
+				t.Errorf("output = %q, project found = %v; want it deleted", out, findErr == nil)
+			}
+			if want := `Delete project "Work" and 1 sub-project(s)? This permanently destroys 1 task(s). [y/N] `; prompt.String() != want {
+				t.Errorf("prompt = %q, want %q", prompt.String(), want)
+			}
+		})
+	}
+}
+
+func TestDescendantProjectIDs(t *testing.T) {
+	projects := []*api.Project{
+		{ID: "work"},
+		{ID: "clients", ParentID: "work"},
+		{ID: "acme", ParentID: "clients"},
+		{ID: "internal", ParentID: "work"},
+		{ID: "home"},
+		// A parent cycle, as in corrupted data, must not recurse forever.
+		{ID: "a", ParentID: "b"},
+		{ID: "b", ParentID: "a"},
+		{ID: "self", ParentID: "self"},
+	}
+
+	tests := []struct {
+		projectID string
+		want      []string
+	}{
+		{"work", []string{"clients", "acme", "internal"}},
+		{"home", nil},
+		{"a", []string{"b"}},
+		{"self", nil},
+	}
+	for _, tt := range tests {
+		if got := descendantProjectIDs(projects, tt.projectID); !slices.Equal(got, tt.want) {
+			t.Errorf("descendantProjectIDs(%s) = %v, want %v", tt.projectID, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/joeyhipolito/todoist-cli/internal/output"
+)
+
+// Confirmation prompts are written to stderr, so --json output on stdout
+// stays parseable, and answered on stdin. Tests replace all three.
+var (
+	stdin       io.Reader = os.Stdin
+	stderr      io.Writer = os.Stderr
+	interactive           = func() bool { return output.IsTerminal(os.Stdin) }
+)
+
+// confirm asks question and reports whether the answer was yes. It
+// refuses to ask when stdin isn't a terminal, where nobody can answer;
+// scripts pass --yes instead.
+func confirm(question string) (bool, error) {
+	if !interactive() {
+		return false, fmt.Errorf("cannot ask for confirmation: stdin is not a terminal; pass --yes to confirm")
+	}
+	fmt.Fprintf(stderr, "%s [y/N] ", question)
+	reply, err := bufio.NewReader(stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("failed to read answer: %w", err)
+	}
+	return strings.EqualFold(strings.TrimSpace(reply), "y"), nil
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/offline"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
+)
+
+// ReopenCmd reopens a completed task, or the N most recently completed tasks with --last.
+func ReopenCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
+	if len(args) < 1 {
+		return fmt.Errorf("reopen requires a task ID\n\nUsage: todoist reopen <task-id>\n       todoist reopen --last <n>")
+	}
+
+	if args[0] != "--last" {
+		taskID := args[0]
+		if err := client.ReopenTask(ctx, taskID); err != nil {
+			if _, ok := offline.AsQueued(err); ok {
+				return reportQueued(w, taskID, "Task reopen queued", format)
+			}
+			return err
+		}
+
+		if format != output.Text {
+			return output.Value(w, format, reopenResult(taskID))
+		}
+
+		fmt.Fprintf(w, "Task %s reopened.\n", taskID)
+		return nil
+	}
+
+	if len(args) < 2 {
+		return fmt.Errorf("--last requires a number")
+	}
+	n, err := strconv.Atoi(args[1])
+	if err != nil || n <= 0 {
+		return fmt.Errorf("--last must be a positive integer")
+	}
+
+	resp, err := client.GetCompletedTasks(ctx, "", "", n)
+	if err != nil {
+		return err
+	}
+
+	// Most recent first; RFC3339 timestamps sort lexically.
+	items := resp.Items
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].CompletedAt > items[j].CompletedAt
+	})
+	if len(items) > n {
+		items = items[:n]
+	}
+
+	// Reopen them all in one sync request.
+	cmds := make([]api.SyncCommand, len(items))
+	for i, t := range items {
+		cmds[i] = api.ReopenTaskCommand(t.TaskID)
+	}
+	sync, err := api.RunCommands(ctx, client, cmds)
+	if _, ok := offline.AsQueued(err); ok {
+		return reportQueuedReopens(w, items, format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to reopen tasks: %w", err)
+	}
+
+	results := []map[string]string{}
+	for i, t := range items {
+		if err := sync.Err(cmds[i]); err != nil {
+			return fmt.Errorf("failed to reopen task %s: %w", t.TaskID, err)
+		}
+		results = append(results, reopenResult(t.TaskID))
+		if format == output.Text {
+			fmt.Fprintf(w, "Task %s reopened: %s\n", t.TaskID, t.Content)
+		}
+	}
+
+	if format != output.Text {
+		return output.Value(w, format, results)
+	}
+
+	if len(items) == 0 {
+		fmt.Fprintln(w, "No completed tasks found.")
+	}
+	return nil
+}
+
+// reopenResult is the JSON status object for one reopened task, shaped like CloseCmd's.
+func reopenResult(taskID string) map[string]string {
+	return map[string]string{
+		"status":  "ok",
+		"task_id": taskID,
+		"message": "Task reopened",
+	}
+}
+
+// reportQueuedReopens writes the result of reopens queued for the next sync.
+func reportQueuedReopens(w io.Writer, items []api.CompletedTask, format output.Format) error {
+	if format != output.Text {
+		results := make([]map[string]string, len(items))
+		for i, t := range items {
+			results[i] = map[string]string{
+				"status":  "queued",
+				"task_id": t.TaskID,
+				"message": "Task reopen queued",
+			}
+		}
+		return output.Value(w, format, results)
+	}
+
+	for _, t := range items {
+		fmt.Fprintf(w, "Task %s reopen queued: %s\n", t.TaskID, t.Content)
+	}
+	fmt.Fprintln(w, "Run 'todoist sync' when back online.")
+	return nil
+}
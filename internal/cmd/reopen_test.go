@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+func TestReopenCmd(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		json         bool
+		wantOut      string
+		wantReopened []string // task keys, in reopen order
+		wantSyncs    int
+		wantErr      string
+	}{
+		{
+			name:         "single task",
+			args:         []string{"OLD"},
+			wantOut:      "Task OLD reopened.\n",
+			wantReopened: []string{"OLD"},
+		},
+		{
+			name:         "json matches close",
+			args:         []string{"OLD"},
+			json:         true,
+			wantOut:      "{\n  \"message\": \"Task reopened\",\n  \"status\": \"ok\",\n  \"task_id\": \"OLD\"\n}\n",
+			wantReopened: []string{"OLD"},
+		},
+		{
+			name:         "last picks the most recent first in one sync",
+			args:         []string{"--last", "2"},
+			wantOut:      "Task NEW reopened: Newest\nTask MID reopened: Middle\n",
+			wantReopened: []string{"NEW", "MID"},
+			wantSyncs:    1,
+		},
+		{
+			name:         "last json",
+			args:         []string{"--last", "1"},
+			json:         true,
+			wantOut:      "[\n  {\n    \"message\": \"Task reopened\",\n    \"status\": \"ok\",\n    \"task_id\": \"NEW\"\n  }\n]\n",
+			wantReopened: []string{"NEW"},
+			wantSyncs:    1,
+		},
+		{name: "missing id", wantErr: "requires a task ID"},
+		{name: "last without number", args: []string{"--last"}, wantErr: "requires a number"},
+		{name: "bad last", args: []string{"--last", "0"}, wantErr: "positive integer"},
+		{name: "unknown task", args: []string{"999"}, wantErr: "not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFake()
+			old := f.AddCompletedTask(api.Task{Content: "Oldest", CompletedAt: "2026-03-01T09:00:00Z"})
+			newest := f.AddCompletedTask(api.Task{Content: "Newest", CompletedAt: "2026-03-09T09:00:00Z"})
+			mid := f.AddCompletedTask(api.Task{Content: "Middle", CompletedAt: "2026-03-05T09:00:00Z"})
+			ids := strings.NewReplacer("OLD", old.ID, "NEW", newest.ID, "MID", mid.ID)
+
+			var args []string
+			for _, a := range tt.args {
+				args = append(args, ids.Replace(a))
+			}
+
+			out, err := run(t, ReopenCmd, f, tt.json, args...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ReopenCmd() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReopenCmd() error = %v", err)
+			}
+			if want := ids.Replace(tt.wantOut); out != want {
+				t.Errorf("ReopenCmd() output = %q, want %q", out, want)
+			}
+
+			var reopened []string
+			syncs := 0
+			for _, c := range f.Calls() {
+				if id, ok := strings.CutPrefix(c, "ReopenTask "); ok {
+					reopened = append(reopened, id)
+				}
+				if strings.HasPrefix(c, "Sync ") {
+					syncs++
+				}
+			}
+			var want []string
+			for _, k := range tt.wantReopened {
+				want = append(want, ids.Replace(k))
+			}
+			if !slices.Equal(reopened, want) {
+				t.Errorf("reopened %v, want %v", reopened, want)
+			}
+			if syncs != tt.wantSyncs {
+				t.Errorf("made %d sync requests, want %d", syncs, tt.wantSyncs)
+			}
+		})
+	}
+}
+
+func TestReopenCmd_lastWithNothingCompleted(t *testing.T) {
+	out, err := run(t, ReopenCmd, newFake(), false, "--last", "3")
+	if err != nil {
+		t.Fatalf("ReopenCmd() error = %v", err)
+	}
+	if out != "No completed tasks found.\n" {
+		t.Errorf("ReopenCmd() output = %q", out)
+	}
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/cache"
+)
+
+// resolveProjectID returns the ID of the project whose name matches name
+// case-insensitively.
+func resolveProjectID(ctx context.Context, client api.TodoistAPI, name string) (string, error) {
+	projects, err := client.GetProjects(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve project: %w", err)
+	}
+	p, err := findProject(projects, name)
+	if err != nil && staleCache(client, cache.Projects) {
+		return resolveProjectID(ctx, client, name)
+	}
+	if err != nil {
+		return "", err
+	}
+	return p.ID, nil
+}
+
+// staleCache reports whether client answered a lookup of name from its
+// cache, and if so drops the entry so a retry asks the API. Callers retry
+// a failed lookup once when it returns true, since the name may simply be
+// newer than the cache.
+func staleCache(client api.TodoistAPI, name string) bool {
+	c, ok := findClient[*cache.Client](client)
+	if !ok || !c.Hit(name) {
+		return false
+	}
+	return c.Invalidate(name) == nil
+}
+
+// findProject returns the project matching nameOrID, by exact ID or by
+// case-insensitive name.
+func findProject(projects []*api.Project, nameOrID string) (*api.Project, error) {
+	for _, p := range projects {
+		if p.ID == nameOrID {
+			return p, nil
+		}
+	}
+	for _, p := range projects {
+		if strings.EqualFold(p.Name, nameOrID) {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("project not found: %s", nameOrID)
+}
+
+// resolveSection returns the section matching nameOrID, by exact ID or by
+// case-insensitive name. When projectID is empty every project is searched,
+// and a name shared by sections in several projects is an error.
+func resolveSection(ctx context.Context, client api.TodoistAPI, projectID, nameOrID string) (*api.Section, error) {
+	sections, err := client.GetSections(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve section: %w", err)
+	}
+
+	for _, s := range sections {
+		if s.ID == nameOrID {
+			return s, nil
+		}
+	}
+
+	var matches []*api.Section
+	for _, s := range sections {
+		if strings.EqualFold(s.Name, nameOrID) {
+			matches = append(matches, s)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		if staleCache(client, cache.Sections) {
+			return resolveSection(ctx, client, projectID, nameOrID)
+		}
+		return nil, fmt.Errorf("section not found: %s", nameOrID)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("section name %q exists in %d projects; add --project to choose one", nameOrID, len(matches))
+	}
+}
+
+// findLabel returns the personal label matching nameOrID, by exact ID or by
+// case-insensitive name with any leading "@" ignored. It returns nil, nil
+// when no personal label matches, since the name may still be a shared label.
+func findLabel(ctx context.Context, client api.TodoistAPI, nameOrID string) (*api.Label, error) {
+	labels, err := client.GetLabels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve label: %w", err)
+	}
+
+	name := strings.TrimPrefix(nameOrID, "@")
+	for _, l := range labels {
+		if l.ID == nameOrID || strings.EqualFold(l.Name, name) {
+			return l, nil
+		}
+	}
+	if staleCache(client, cache.Labels) {
+		return findLabel(ctx, client, nameOrID)
+	}
+	return nil, nil
+}
+
+// findSharedLabel returns the name of the shared label matching name, by
+// exact or case-insensitive name with any leading "@" ignored. It returns
+// "" when no task uses such a label, since the shared label endpoints
+// accept any name and would report success without changing anything.
+func findSharedLabel(ctx context.Context, client api.TodoistAPI, name string) (string, error) {
+	names, err := client.GetSharedLabels(ctx, true)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve label: %w", err)
+	}
+	name = strings.TrimPrefix(name, "@")
+	if slices.Contains(names, name) {
+		return name, nil
+	}
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return n, nil
+		}
+	}
+	return "", nil
+}
+
+// resolveAssignee returns the user ID of the collaborator on projectID
+// matching nameOrID by exact ID, or by case-insensitive name or email.
+// A value that matches no collaborator is passed through as a user ID and
+// left for the API to validate.
+func resolveAssignee(ctx context.Context, client api.TodoistAPI, projectID, nameOrID string) (string, error) {
+	collaborators, err := client.GetCollaborators(ctx, projectID)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve assignee: %w", err)
+	}
+	for _, c := range collaborators {
+		if c.ID == nameOrID || strings.EqualFold(c.Name, nameOrID) || strings.EqualFold(c.Email, nameOrID) {
+			return c.ID, nil
+		}
+	}
+	if staleCache(client, cache.Collaborators+"-"+projectID) {
+		return resolveAssignee(ctx, client, projectID, nameOrID)
+	}
+	return nameOrID, nil
+}
+
+// findAssignee returns the collaborator on projectID with the given user
+// ID, or nil if there is none, such as someone who has left the project.
+// Lookup errors are treated as no match, since callers only want a name
+// to show in place of the ID.
+func findAssignee(ctx context.Context, client api.TodoistAPI, projectID, userID string) *api.Collaborator {
+	if userID == "" {
+		return nil
+	}
+	collaborators, err := client.GetCollaborators(ctx, projectID)
+	if err != nil {
+		return nil
+	}
+	for _, c := range collaborators {
+		if c.ID == userID {
+			return c
+		}
+	}
+	if staleCache(client, cache.Collaborators+"-"+projectID) {
+		return findAssignee(ctx, client, projectID, userID)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
+)
+
+const sectionsUsage = `Usage:
+  todoist sections [--project <name>]                      List sections
+  todoist sections add <name> --project <name>             Create a section
+  todoist sections rename <section> <new-name> [--project <name>]
+  todoist sections delete <section> [--project <name>]     Delete a section and its tasks
+
+<section> is a section ID or name.`
+
+// SectionsCmd handles the "sections" command and its subcommands.
+func SectionsCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
+	// Every subcommand accepts --project; pull it out before dispatching.
+	var projectName string
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--project":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--project requires a name")
+			}
+			projectName = args[i+1]
+			i++
+		case "--help", "-h":
+			fmt.Fprintln(w, sectionsUsage)
+			return nil
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+
+	var projectID string
+	if projectName != "" {
+		id, err := resolveProjectID(ctx, client, projectName)
+		if err != nil {
+			return err
+		}
+		projectID = id
+	}
+
+	// No subcommand → list sections (default)
+	if len(rest) == 0 {
+		return sectionsListCmd(ctx, client, w, projectID, format)
+	}
+
+	switch rest[0] {
+	case "add":
+		return sectionsAddCmd(ctx, client, w, projectID, rest[1:], format)
+	case "rename":
+		return sectionsRenameCmd(ctx, client, w, projectID, rest[1:], format)
+	case "delete":
+		return sectionsDeleteCmd(ctx, client, w, projectID, rest[1:], format)
+	default:
+		return fmt.Errorf("unknown sections subcommand: %s\n\n%s", rest[0], sectionsUsage)
+	}
+}
+
+// sectionsListCmd lists sections, prefixed by project name when listing every project.
+func sectionsListCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, projectID string, format output.Format) error {
+	// Listing always asks the API; cached sections are only for name lookups.
+	sections, err := client.IterSections(ctx, projectID, api.ListOptions{}).All()
+	if err != nil {
+		return err
+	}
+
+	if format != output.Text {
+		return output.Value(w, format, sections)
+	}
+
+	if len(sections) == 0 {
+		fmt.Fprintln(w, "No sections found.")
+		return nil
+	}
+
+	projectNames := map[string]string{}
+	if projectID == "" {
+		projects, err := client.GetProjects(ctx)
+		if err != nil {
+			return err
+		}
+		for _, p := range projects {
+			projectNames[p.ID] = p.Name
+		}
+	}
+
+	for _, s := range sections {
+		if projectID == "" {
+			fmt.Fprintf(w, "  %s / %s (%s)\n", projectNames[s.ProjectID], s.Name, s.ID)
+		} else {
+			fmt.Fprintf(w, "  %s (%s)\n", s.Name, s.ID)
+		}
+	}
+
+	return nil
+}
+
+// sectionsAddCmd creates a new section in the chosen project.
+func sectionsAddCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, projectID string, args []string, format output.Format) error {
+	if len(args) < 1 {
+		return fmt.Errorf("sections add requires a section name\n\nUsage: todoist sections add <name> --project <name>")
+	}
+	if projectID == "" {
+		return fmt.Errorf("sections add requires --project\n\nUsage: todoist sections add <name> --project <name>")
+	}
+
+	section, err := client.CreateSection(ctx, &api.CreateSectionRequest{
+		Name:      args[0],
+		ProjectID: projectID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if format != output.Text {
+		return output.Value(w, format, section)
+	}
+
+	fmt.Fprintf(w, "Created section: %s (ID: %s)\n", section.Name, section.ID)
+	return nil
+}
+
+// sectionsRenameCmd renames a section.
+func sectionsRenameCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, projectID string, args []string, format output.Format) error {
+	if len(args) < 2 {
+		return fmt.Errorf("sections rename requires a section and a new name\n\nUsage: todoist sections rename <section> <new-name> [--project <name>]")
+	}
+
+	section, err := resolveSection(ctx, client, projectID, args[0])
+	if err != nil {
+		return err
+	}
+
+	updated, err := client.UpdateSection(ctx, section.ID, &api.UpdateSectionRequest{Name: args[1]})
+	if err != nil {
+		return err
+	}
+
+	if format != output.Text {
+		return output.Value(w, format, updated)
+	}
+
+	fmt.Fprintf(w, "Renamed section %s to %s.\n", section.Name, updated.Name)
+	return nil
+}
+
+// sectionsDeleteCmd permanently deletes a section.
+func sectionsDeleteCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, projectID string, args []string, format output.Format) error {
+	if len(args) < 1 {
+		return fmt.Errorf("sections delete requires a section\n\nUsage: todoist sections delete <section> [--project <name>]")
+	}
+
+	section, err := resolveSection(ctx, client, projectID, args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteSection(ctx, section.ID); err != nil {
+		return err
+	}
+
+	if format != output.Text {
+		return output.Value(w, format, map[string]string{
+			"status":     "ok",
+			"section_id": section.ID,
+			"message":    "Section deleted",
+		})
+	}
+
+	fmt.Fprintf(w, "Section %s deleted.\n", section.Name)
+	return nil
+}
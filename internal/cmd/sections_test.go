@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+func TestSectionsCmd(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		json      bool
+		wantOut   string   // section keys are replaced by their IDs
+		wantCalls []string // calls that must be made, keys replaced likewise
+		wantErr   string
+	}{
+		{
+			name:    "list every project",
+			args:    nil,
+			wantOut: "  Work / Doing (DOING)\n  Work / Backlog (WORKBL)\n  Home / Backlog (HOMEBL)\n",
+		},
+		{
+			name:    "list one project",
+			args:    []string{"--project", "work"},
+			wantOut: "  Doing (DOING)\n  Backlog (WORKBL)\n",
+		},
+		{
+			name:    "list json",
+			args:    []string{"--project", "Home"},
+			json:    true,
+			wantOut: "[\n  {\n    \"id\": \"HOMEBL\",",
+		},
+		{
+			name:      "add",
+			args:      []string{"add", "Review", "--project", "Work"},
+			wantOut:   "Created section: Review (ID: ",
+			wantCalls: []string{"CreateSection Review"},
+		},
+		{
+			name:      "rename by name",
+			args:      []string{"rename", "doing", "In progress"},
+			wantOut:   "Renamed section Doing to In progress.\n",
+			wantCalls: []string{"UpdateSection DOING"},
+		},
+		{
+			name:      "rename by ID of an ambiguous name",
+			args:      []string{"rename", "HOMEBL", "Someday"},
+			wantOut:   "Renamed section Backlog to Someday.\n",
+			wantCalls: []string{"UpdateSection HOMEBL"},
+		},
+		{
+			name:      "rename ambiguous name within a project",
+			args:      []string{"rename", "Backlog", "Someday", "--project", "Home"},
+			wantOut:   "Renamed section Backlog to Someday.\n",
+			wantCalls: []string{"UpdateSection HOMEBL"},
+		},
+		{
+			name:      "delete json",
+			args:      []string{"delete", "Doing"},
+			json:      true,
+			wantOut:   "{\n  \"message\": \"Section deleted\",\n  \"section_id\": \"DOING\",\n  \"status\": \"ok\"\n}\n",
+			wantCalls: []string{"DeleteSection DOING"},
+		},
+		{name: "add without project", args: []string{"add", "Review"}, wantErr: "requires --project"},
+		{name: "ambiguous name", args: []string{"delete", "backlog"}, wantErr: `section name "backlog" exists in 2 projects`},
+		{name: "unknown section", args: []string{"rename", "Nope", "x"}, wantErr: "section not found: Nope"},
+		{name: "unknown project", args: []string{"--project", "Nope"}, wantErr: "project not found: Nope"},
+		{name: "missing new name", args: []string{"rename", "Doing"}, wantErr: "requires a section and a new name"},
+		{name: "unknown subcommand", args: []string{"move"}, wantErr: "unknown sections subcommand"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFake()
+			work := f.AddProject(api.Project{Name: "Work"})
+			home := f.AddProject(api.Project{Name: "Home"})
+			doing := f.AddSection(api.Section{Name: "Doing", ProjectID: work.ID})
+			workBacklog := f.AddSection(api.Section{Name: "Backlog", ProjectID: work.ID})
+			homeBacklog := f.AddSection(api.Section{Name: "Backlog", ProjectID: home.ID})
+			ids := strings.NewReplacer("DOING", doing.ID, "WORKBL", workBacklog.ID, "HOMEBL", homeBacklog.ID)
+
+			var args []string
+			for _, a := range tt.args {
+				args = append(args, ids.Replace(a))
+			}
+
+			out, err := run(t, SectionsCmd, f, tt.json, args...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("SectionsCmd() error = %v, want %q", err, tt.wantErr)
+				}
+				for _, c := range f.Calls() {
+					if !strings.HasPrefix(c, "Get") {
+						t.Errorf("SectionsCmd() made call %q", c)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SectionsCmd() error = %v", err)
+			}
+			if want := ids.Replace(tt.wantOut); !strings.HasPrefix(out, want) {
+				t.Errorf("SectionsCmd() output = %q, want %q", out, want)
+			}
+			calls := f.Calls()
+			for _, c := range tt.wantCalls {
+				if want := ids.Replace(c); !slices.Contains(calls, want) {
+					t.Errorf("calls = %q, want %q", calls, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
+	"github.com/joeyhipolito/todoist-cli/internal/transform"
+)
+
+// ShowCmd prints every field of a single task in readable form.
+func ShowCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
+	if len(args) < 1 {
+		return fmt.Errorf("show requires a task ID\n\nUsage: todoist show <task-id>")
+	}
+
+	task, err := client.GetTask(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	if format != output.Text {
+		return output.Value(w, format, task)
+	}
+
+	// Resolve IDs to names. The project list is small; sections and
+	// siblings are scoped to the task's project.
+	projectName := task.ProjectID
+	projects, err := client.GetProjects(ctx)
+	if err != nil {
+		return err
+	}
+	for _, p := range projects {
+		if p.ID == task.ProjectID {
+			projectName = p.Name
+			break
+		}
+	}
+
+	sectionName := ""
+	if task.SectionID != "" {
+		sectionName = task.SectionID
+		sections, err := client.GetSections(ctx, task.ProjectID)
+		if err != nil {
+			return err
+		}
+		for _, s := range sections {
+			if s.ID == task.SectionID {
+				sectionName = s.Name
+				break
+			}
+		}
+	}
+
+	parent := ""
+	if task.ParentID != "" {
+		parent = task.ParentID
+		if p, err := client.GetTask(ctx, task.ParentID); err == nil {
+			parent = fmt.Sprintf("%s (%s)", p.Content, p.ID)
+		}
+	}
+
+	assignee := task.AssigneeID
+	if c := findAssignee(ctx, client, task.ProjectID, task.AssigneeID); c != nil {
+		assignee = fmt.Sprintf("%s (%s)", c.Name, c.ID)
+	}
+
+	siblings, err := client.GetTasks(ctx, "", task.ProjectID, "")
+	if err != nil {
+		return err
+	}
+	var subtasks []*api.Task
+	for _, t := range siblings {
+		if t.ParentID == task.ID {
+			subtasks = append(subtasks, t)
+		}
+	}
+	sort.SliceStable(subtasks, func(i, j int) bool {
+		return subtasks[i].Order < subtasks[j].Order
+	})
+
+	fields := [][2]string{
+		{"ID", task.ID},
+		{"Content", task.Content},
+		{"Description", task.Description},
+		{"Project", projectName},
+		{"Section", sectionName},
+		{"Parent", parent},
+		{"Priority", transform.FormatPriority(task.Priority)},
+		{"Due", formatDue(task.Due)},
+		{"Deadline", formatDeadline(task.Deadline)},
+		{"Duration", formatDuration(task.Duration)},
+		{"Labels", strings.TrimSpace(transform.FormatLabels(task.Labels))},
+		{"Assignee", assignee},
+		{"Comments", fmt.Sprintf("%d", task.NoteCount)},
+		{"Created", task.CreatedAt},
+	}
+
+	for _, f := range fields {
+		if f[1] == "" {
+			continue
+		}
+		fmt.Fprintf(w, "%-12s %s\n", f[0]+":", f[1])
+	}
+
+	if len(subtasks) > 0 {
+		fmt.Fprintf(w, "Subtasks (%d):\n", len(subtasks))
+		for _, t := range subtasks {
+			fmt.Fprintln(w, "  "+transform.FormatTaskLine(t))
+		}
+	}
+
+	return nil
+}
+
+// formatDue renders a due date with its original string when recurring.
+func formatDue(d *api.Due) string {
+	if d == nil {
+		return ""
+	}
+	s := transform.FormatDueDate(d.Date, d.Datetime)
+	if d.IsRecurring && d.String != "" {
+		s += " (" + d.String + ")"
+	}
+	return s
+}
+
+// formatDeadline renders a deadline the same way as a due date.
+func formatDeadline(d *api.Deadline) string {
+	if d == nil {
+		return ""
+	}
+	return transform.FormatDueDate(d.Date, "")
+}
+
+// formatDuration renders a task duration, or "" if unset.
+func formatDuration(d *api.Duration) string {
+	if d == nil {
+		return ""
+	}
+	return transform.FormatDuration(d.Amount, d.Unit)
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+func TestShowCmd_assignee(t *testing.T) {
+	f := newFake()
+	work := f.AddProject(api.Project{Name: "Work"})
+	ana := f.AddCollaborator(work.ID, api.Collaborator{Name: "Ana Lima", Email: "ana@example.com"})
+	mine := f.AddTask(api.Task{Content: "Review", ProjectID: work.ID, AssigneeID: ana.ID})
+	gone := f.AddTask(api.Task{Content: "Old", ProjectID: work.ID, AssigneeID: "7654321"})
+
+	out, err := run(t, ShowCmd, f, false, mine.ID)
+	if err != nil {
+		t.Fatalf("ShowCmd() error = %v", err)
+	}
+	if want := "Assignee:    Ana Lima (" + ana.ID + ")\n"; !strings.Contains(out, want) {
+		t.Errorf("ShowCmd() output:\n%s\nwant line %q", out, want)
+	}
+
+	// Someone no longer sharing the project is shown by ID.
+	out, err = run(t, ShowCmd, f, false, gone.ID)
+	if err != nil {
+		t.Fatalf("ShowCmd() error = %v", err)
+	}
+	if want := "Assignee:    7654321\n"; !strings.Contains(out, want) {
+		t.Errorf("ShowCmd() output:\n%s\nwant line %q", out, want)
+	}
+}
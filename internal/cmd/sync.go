@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/config"
+	"github.com/joeyhipolito/todoist-cli/internal/offline"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
+)
+
+// SyncStateFile is the file in the config directory that holds the local
+// copy of the account and the sync token for the next incremental sync.
+const SyncStateFile = "sync.json"
+
+// syncStatePath returns the path of the sync state file.
+func syncStatePath() string {
+	return filepath.Join(config.Dir(), SyncStateFile)
+}
+
+// SyncCmd brings the local copy of the account up to date. Changes queued
+// while offline are sent first, in the order they were made. The first run
+// (or --full) then downloads everything; later runs fetch only what
+// changed. --pending lists the queued changes without contacting the API.
+func SyncCmd(ctx context.Context, client api.TodoistAPI, w io.Writer, args []string, format output.Format) error {
+	full, pending := false, false
+	for _, arg := range args {
+		switch arg {
+		case "--full":
+			full = true
+		case "--pending":
+			pending = true
+		default:
+			return fmt.Errorf("unknown flag: %s\n\nUsage: todoist sync [--full | --pending]", arg)
+		}
+	}
+
+	journal := openJournal()
+	if pending {
+		return listPending(w, journal, format)
+	}
+
+	// Sync talks to the API itself, never to the offline layer.
+	if oc, ok := client.(*offline.Client); ok {
+		if oc.Forced() {
+			return fmt.Errorf("cannot sync in offline mode; drop --offline or unset %s", EnvOffline)
+		}
+		journal, client = oc.Journal(), oc.Unwrap()
+	}
+
+	outcomes, err := journal.Replay(ctx, client)
+	if format == output.Text {
+		printOutcomes(w, outcomes)
+	}
+	var state *api.SyncState
+	var fullSync bool
+	if err == nil {
+		state, fullSync, err = syncState(ctx, client, full)
+	}
+
+	// Scripts need to know which queued changes went through even when
+	// the run failed part way.
+	if format != output.Text {
+		replayed := []map[string]string{}
+		for _, o := range outcomes {
+			replayed = append(replayed, outcomeResult(o))
+		}
+		result := map[string]any{"status": "ok", "replayed": replayed}
+		if err != nil {
+			result["status"], result["error"] = "error", err.Error()
+		} else {
+			result["full_sync"] = fullSync
+			result["tasks"] = len(state.Items)
+			result["projects"] = len(state.Projects)
+			result["sections"] = len(state.Sections)
+			result["labels"] = len(state.Labels)
+		}
+		if werr := output.Value(w, format, result); werr != nil {
+			return werr
+		}
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	kind := "incremental"
+	if fullSync {
+		kind = "full"
+	}
+	fmt.Fprintf(w, "Synced (%s): %d tasks, %d projects, %d sections, %d labels.\n",
+		kind, len(state.Items), len(state.Projects), len(state.Sections), len(state.Labels))
+	return nil
+}
+
+// syncState updates the sync state file, from scratch if full is set, and
+// reports whether the server sent a full sync.
+func syncState(ctx context.Context, client api.TodoistAPI, full bool) (*api.SyncState, bool, error) {
+	path := syncStatePath()
+	state, err := api.LoadSyncState(path)
+	if err != nil {
+		return nil, false, err
+	}
+	if full {
+		state = &api.SyncState{}
+	}
+
+	fullSync, err := state.Sync(ctx, client)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := state.Save(path); err != nil {
+		return nil, false, err
+	}
+	return state, fullSync, nil
+}
+
+// listPending prints the changes waiting in the journal.
+func listPending(w io.Writer, journal *offline.Journal, format output.Format) error {
+	entries, err := journal.Entries()
+	if err != nil {
+		return err
+	}
+
+	if format != output.Text {
+		results := []map[string]string{}
+		for _, e := range entries {
+			results = append(results, map[string]string{
+				"change":    e.String(),
+				"task_id":   e.TaskID(),
+				"queued_at": e.QueuedAt.Format(time.RFC3339),
+			})
+		}
+		return output.Value(w, format, results)
+	}
+
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "No queued changes.")
+		return nil
+	}
+	for _, e := range entries {
+		fmt.Fprintf(w, "  %s  %s\n", e.QueuedAt.Local().Format("2006-01-02 15:04"), e)
+	}
+	fmt.Fprintf(w, "%d change(s) queued. Run 'todoist sync' when back online.\n", len(entries))
+	return nil
+}
+
+// printOutcomes reports what happened to each replayed change.
+func printOutcomes(w io.Writer, outcomes []offline.Outcome) {
+	if len(outcomes) == 0 {
+		return
+	}
+	counts := map[string]int{}
+	for _, o := range outcomes {
+		counts[o.Status]++
+		switch o.Status {
+		case offline.StatusSynced:
+			if o.Entry.Command.Type == api.CommandItemAdd {
+				fmt.Fprintf(w, "  synced    %s (ID: %s)\n", o.Entry, o.TaskID)
+			} else {
+				fmt.Fprintf(w, "  synced    %s\n", o.Entry)
+			}
+		default:
+			fmt.Fprintf(w, "  %-9s %s: %v\n", o.Status, o.Entry, o.Err)
+		}
+	}
+	fmt.Fprintf(w, "Replayed %d queued change(s): %d synced, %d conflict(s), %d failed.\n",
+		len(outcomes), counts[offline.StatusSynced], counts[offline.StatusConflict], counts[offline.StatusFailed])
+}
+
+// outcomeResult is the JSON form of a replayed change.
+func outcomeResult(o offline.Outcome) map[string]string {
+	result := map[string]string{
+		"status":  o.Status,
+		"task_id": o.TaskID,
+		"change":  o.Entry.String(),
+	}
+	if o.Err != nil {
+		result["error"] = o.Err.Error()
+	}
+	return result
+}
+
+// reportQueued writes the result of a change that was queued for the next
+// sync instead of sent, with the same shape as the command's "ok" result.
+func reportQueued(w io.Writer, taskID, message string, format output.Format) error {
+	if format != output.Text {
+		return output.Value(w, format, map[string]string{
+			"status":  "queued",
+			"task_id": taskID,
+			"message": message,
+		})
+	}
+
+	fmt.Fprintf(w, "%s (ID: %s). Run 'todoist sync' when back online.\n", message, taskID)
+	return nil
+}
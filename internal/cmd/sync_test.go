@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/api/apitest"
+	"github.com/joeyhipolito/todoist-cli/internal/config"
+	"github.com/joeyhipolito/todoist-cli/internal/output"
+)
+
+func TestSyncCmd(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(config.EnvConfigDir, dir)
+
+	f := newFake()
+	work := f.AddProject(api.Project{Name: "Work"})
+	f.AddTask(api.Task{Content: "Write report", ProjectID: work.ID})
+	f.AddLabel(api.Label{Name: "home"})
+
+	out, err := run(t, SyncCmd, f, false)
+	if err != nil {
+		t.Fatalf("SyncCmd() error = %v", err)
+	}
+	if want := "Synced (full): 1 tasks, 2 projects, 0 sections, 1 labels.\n"; out != want {
+		t.Errorf("SyncCmd() output = %q, want %q", out, want)
+	}
+
+	state, err := api.LoadSyncState(filepath.Join(dir, SyncStateFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.SyncToken == "" || len(state.Items) != 1 {
+		t.Errorf("saved state = %+v, want a token and one task", state)
+	}
+	if info, err := os.Stat(filepath.Join(dir, SyncStateFile)); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("state file mode = %v, %v, want 0600", info.Mode().Perm(), err)
+	}
+
+	// A second run picks up the new task.
+	f.AddTask(api.Task{Content: "Call mom"})
+	out, err = run(t, SyncCmd, f, true)
+	if err != nil {
+		t.Fatalf("SyncCmd() error = %v", err)
+	}
+	if !strings.Contains(out, `"tasks": 2`) {
+		t.Errorf("SyncCmd() output = %s, want 2 tasks", out)
+	}
+
+	if _, err := run(t, SyncCmd, f, false, "--bogus"); err == nil || !strings.Contains(err.Error(), "unknown flag") {
+		t.Errorf("SyncCmd(--bogus) error = %v", err)
+	}
+}
+
+func TestSyncCmd_replaysQueuedChanges(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv(config.EnvConfigDir, t.TempDir())
+	f := newFake()
+	report := f.AddTask(api.Task{Content: "Write report"})
+
+	exec := func(fn cmdFunc, client api.TodoistAPI, jsonOutput bool, args ...string) (string, error) {
+		var out bytes.Buffer
+		format := output.Text
+		if jsonOutput {
+			format = output.JSON
+		}
+		err := fn(ctx, client, &out, args, format)
+		return out.String(), err
+	}
+
+	offline := NewOffline(f, true)
+	out, err := exec(AddCmd, offline, true, "Buy milk")
+	if err != nil {
+		t.Fatalf("AddCmd() error = %v", err)
+	}
+	if !strings.Contains(out, `"status": "queued"`) {
+		t.Errorf("AddCmd() output = %s, want queued status", out)
+	}
+	out, err = exec(CloseCmd, offline, false, report.ID)
+	if err != nil {
+		t.Fatalf("CloseCmd() error = %v", err)
+	}
+	if want := "Task completion queued (ID: " + report.ID + "). Run 'todoist sync' when back online.\n"; out != want {
+		t.Errorf("CloseCmd() output = %q, want %q", out, want)
+	}
+	if calls := f.Calls(); len(calls) != 0 {
+		t.Errorf("API calls while offline = %q, want none", calls)
+	}
+
+	if _, err := exec(SyncCmd, offline, false); err == nil || !strings.Contains(err.Error(), "offline mode") {
+		t.Errorf("SyncCmd() in offline mode error = %v", err)
+	}
+	out, err = exec(SyncCmd, offline, false, "--pending")
+	if err != nil {
+		t.Fatalf("SyncCmd(--pending) error = %v", err)
+	}
+	if !strings.Contains(out, `add "Buy milk"`) || !strings.Contains(out, "close "+report.ID) || !strings.Contains(out, "2 change(s) queued") {
+		t.Errorf("SyncCmd(--pending) output = %q", out)
+	}
+
+	// The task was deleted elsewhere in the meantime.
+	f.DeleteTask(ctx, report.ID)
+	out, err = exec(SyncCmd, NewOffline(f, false), false)
+	if err != nil {
+		t.Fatalf("SyncCmd() error = %v", err)
+	}
+	for _, want := range []string{
+		`synced    add "Buy milk" (ID: `,
+		"conflict  close " + report.ID + ": task " + report.ID + " was deleted upstream",
+		"Replayed 2 queued change(s): 1 synced, 1 conflict(s), 0 failed.",
+		"Synced (full): 1 tasks",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("SyncCmd() output = %q, want %q", out, want)
+		}
+	}
+
+	// Offline listing answers from the snapshot.
+	out, err = exec(ListCmd, NewOffline(f, true), false)
+	if err != nil {
+		t.Fatalf("ListCmd() error = %v", err)
+	}
+	if !strings.Contains(out, "Buy milk") || !strings.Contains(out, "(offline: as of the last sync") {
+		t.Errorf("ListCmd() output = %q, want the synced task and an offline note", out)
+	}
+}
+
+func TestReopenCmd_queuesOffline(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv(config.EnvConfigDir, t.TempDir())
+	f := newFake()
+	report := f.AddCompletedTask(api.Task{Content: "Write report", CompletedAt: "2026-03-09T10:00:00Z"})
+	taxes := f.AddCompletedTask(api.Task{Content: "Taxes", CompletedAt: "2026-03-08T10:00:00Z"})
+	offline := NewOffline(f, true)
+
+	var out bytes.Buffer
+	if err := ReopenCmd(ctx, offline, &out, []string{report.ID}, output.Text); err != nil {
+		t.Fatalf("ReopenCmd() error = %v", err)
+	}
+	if want := "Task reopen queued (ID: " + report.ID + "). Run 'todoist sync' when back online.\n"; out.String() != want {
+		t.Errorf("ReopenCmd() output = %q, want %q", out.String(), want)
+	}
+
+	out.Reset()
+	if err := ReopenCmd(ctx, offline, &out, []string{"--last", "2"}, output.JSON); err != nil {
+		t.Fatalf("ReopenCmd(--last) error = %v", err)
+	}
+	var got []map[string]string
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	if len(got) != 2 || got[0]["status"] != "queued" || got[0]["task_id"] != report.ID || got[1]["task_id"] != taxes.ID {
+		t.Errorf("ReopenCmd(--last) output = %s, want both reopens queued", out.String())
+	}
+
+	entries, err := openJournal().Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Errorf("journal has %d entries, want a reopen per task", len(entries))
+	}
+	for _, c := range f.Calls() {
+		if strings.HasPrefix(c, "ReopenTask") || strings.HasPrefix(c, "Sync") {
+			t.Errorf("API call while offline: %s", c)
+		}
+	}
+}
+
+// failingReads passes sync commands through but fails the resource reads
+// that follow them.
+type failingReads struct {
+	*apitest.Fake
+}
+
+func (f failingReads) Sync(ctx context.Context, req *api.SyncRequest) (*api.SyncResponse, error) {
+	if len(req.ResourceTypes) > 0 {
+		return nil, errors.New("connection reset")
+	}
+	return f.Fake.Sync(ctx, req)
+}
+
+func TestSyncCmd_jsonReportsReplayOnError(t *testing.T) {
+	t.Setenv(config.EnvConfigDir, t.TempDir())
+	f := newFake()
+	var out bytes.Buffer
+	if err := AddCmd(context.Background(), NewOffline(f, true), &out, []string{"Buy milk"}, output.Text); err != nil {
+		t.Fatalf("AddCmd() error = %v", err)
+	}
+
+	out.Reset()
+	err := SyncCmd(context.Background(), failingReads{f}, &out, nil, output.JSON)
+	if err == nil || !strings.Contains(err.Error(), "connection reset") {
+		t.Fatalf("SyncCmd() error = %v, want the read failure", err)
+	}
+	var got struct {
+		Status   string              `json:"status"`
+		Error    string              `json:"error"`
+		Replayed []map[string]string `json:"replayed"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	if got.Status != "error" || !strings.Contains(got.Error, "connection reset") ||
+		len(got.Replayed) != 1 || got.Replayed[0]["status"] != "synced" || got.Replayed[0]["task_id"] == "" {
+		t.Errorf("SyncCmd() output = %s, want the synced add and the error", out.String())
+	}
+}
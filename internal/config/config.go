@@ -7,7 +7,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -17,11 +20,52 @@ const (
 	ConfigFile = "config"
 	// EnvConfigDir is the environment variable that overrides the config directory.
 	EnvConfigDir = "TODOIST_CONFIG_DIR"
+	// EnvRateLimitBudget is the environment variable that overrides rate_limit_budget.
+	EnvRateLimitBudget = "TODOIST_RATE_LIMIT_BUDGET"
+	// EnvRateLimit is the environment variable that overrides rate_limit.
+	EnvRateLimit = "TODOIST_RATE_LIMIT"
+	// EnvAPIURL is the environment variable that overrides api_url.
+	EnvAPIURL = "TODOIST_API_URL"
+	// EnvMaxRetries is the environment variable that overrides max_retries.
+	EnvMaxRetries = "TODOIST_MAX_RETRIES"
+	// EnvCacheTTL is the environment variable that overrides cache_ttl.
+	EnvCacheTTL = "TODOIST_CACHE_TTL"
+	// EnvTheme is the environment variable that overrides theme.
+	EnvTheme = "TODOIST_THEME"
 )
 
 // Config represents the Todoist CLI configuration.
 type Config struct {
 	AccessToken string
+
+	// RateLimitBudget caps the total time one API request may spend waiting
+	// out 429 responses. Nil means use the client default.
+	RateLimitBudget *time.Duration
+
+	// RateLimit paces API requests to this many per minute. Zero disables
+	// pacing; nil means use the client default.
+	RateLimit *int
+
+	// APIURL overrides the Todoist API base URL, e.g. to point at a mock server.
+	APIURL string
+
+	// MaxRetries overrides how many times a failed request is retried.
+	MaxRetries *int
+
+	// CacheTTL is how long cached projects, sections, labels and
+	// collaborators stay fresh. Zero disables the cache; nil means use the
+	// default.
+	CacheTTL *time.Duration
+
+	// Templates holds named --template texts, from "template.<name>" keys.
+	Templates map[string]string
+
+	// Theme names the color theme for task lines; "none" turns color off.
+	Theme string
+
+	// Colors overrides theme colors by role, from "color.<role>" keys
+	// holding ANSI SGR parameters such as "1;31".
+	Colors map[string]string
 }
 
 // Store holds the resolved configuration directory path.
@@ -103,6 +147,47 @@ func (s *Store) Load() (*Config, error) {
 		switch key {
 		case "access_token":
 			cfg.AccessToken = value
+		case "rate_limit_budget":
+			d, err := parseDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid rate_limit_budget: %w", err)
+			}
+			cfg.RateLimitBudget = &d
+		case "rate_limit":
+			n, err := parseNonNegative(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid rate_limit: %w", err)
+			}
+			cfg.RateLimit = &n
+		case "api_url":
+			cfg.APIURL = value
+		case "max_retries":
+			n, err := parseNonNegative(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid max_retries: %w", err)
+			}
+			cfg.MaxRetries = &n
+		case "cache_ttl":
+			d, err := parseDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid cache_ttl: %w", err)
+			}
+			cfg.CacheTTL = &d
+		case "theme":
+			cfg.Theme = value
+		default:
+			if name, ok := strings.CutPrefix(key, "template."); ok && name != "" {
+				if cfg.Templates == nil {
+					cfg.Templates = map[string]string{}
+				}
+				cfg.Templates[name] = value
+			}
+			if role, ok := strings.CutPrefix(key, "color."); ok && role != "" {
+				if cfg.Colors == nil {
+					cfg.Colors = map[string]string{}
+				}
+				cfg.Colors[role] = value
+			}
 		}
 	}
 
@@ -127,6 +212,55 @@ func (s *Store) Save(cfg *Config) error {
 	b.WriteString("# Get from: https://todoist.com/app/settings/integrations/developer\n")
 	fmt.Fprintf(&b, "access_token=%s\n", cfg.AccessToken)
 
+	if cfg.RateLimit != nil {
+		b.WriteString("\n")
+		b.WriteString("# Max API requests per minute (0 to disable pacing)\n")
+		fmt.Fprintf(&b, "rate_limit=%d\n", *cfg.RateLimit)
+	}
+
+	if cfg.RateLimitBudget != nil {
+		b.WriteString("\n")
+		b.WriteString("# Max time to wait out rate limits per request (e.g. 30s, 2m, 0 to fail fast)\n")
+		fmt.Fprintf(&b, "rate_limit_budget=%s\n", *cfg.RateLimitBudget)
+	}
+
+	if cfg.APIURL != "" {
+		b.WriteString("\n")
+		b.WriteString("# API base URL (default https://api.todoist.com/api/v1)\n")
+		fmt.Fprintf(&b, "api_url=%s\n", cfg.APIURL)
+	}
+
+	if cfg.MaxRetries != nil {
+		b.WriteString("\n")
+		b.WriteString("# Retries for network errors, 5xx and 429 responses\n")
+		fmt.Fprintf(&b, "max_retries=%d\n", *cfg.MaxRetries)
+	}
+
+	if cfg.CacheTTL != nil {
+		b.WriteString("\n")
+		b.WriteString("# How long to cache projects, sections and labels (e.g. 1h, 10m, 0 to disable)\n")
+		fmt.Fprintf(&b, "cache_ttl=%s\n", *cfg.CacheTTL)
+	}
+
+	if cfg.Theme != "" || len(cfg.Colors) > 0 {
+		b.WriteString("\n")
+		b.WriteString("# Color theme (default, vivid, mono, none) and per-role overrides\n")
+		if cfg.Theme != "" {
+			fmt.Fprintf(&b, "theme=%s\n", cfg.Theme)
+		}
+		for _, role := range sortedKeys(cfg.Colors) {
+			fmt.Fprintf(&b, "color.%s=%s\n", role, cfg.Colors[role])
+		}
+	}
+
+	if len(cfg.Templates) > 0 {
+		b.WriteString("\n")
+		b.WriteString("# Named output templates, used with --template <name>\n")
+		for _, name := range sortedKeys(cfg.Templates) {
+			fmt.Fprintf(&b, "template.%s=%s\n", name, cfg.Templates[name])
+		}
+	}
+
 	if err := os.WriteFile(s.Path(), []byte(b.String()), 0600); err != nil {
 		return fmt.Errorf("writing config file: %w", err)
 	}
@@ -144,6 +278,170 @@ func (s *Store) ResolveToken() string {
 	return os.Getenv("TODOIST_ACCESS_TOKEN")
 }
 
+// ResolveRateLimitBudget returns the rate limit budget using config priority:
+// environment variable TODOIST_RATE_LIMIT_BUDGET > config file. ok is false
+// when neither is set.
+func (s *Store) ResolveRateLimitBudget() (budget time.Duration, ok bool, err error) {
+	if v := os.Getenv(EnvRateLimitBudget); v != "" {
+		d, err := parseDuration(v)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid %s: %w", EnvRateLimitBudget, err)
+		}
+		return d, true, nil
+	}
+
+	cfg, err := s.Load()
+	if err != nil {
+		return 0, false, err
+	}
+	if cfg.RateLimitBudget == nil {
+		return 0, false, nil
+	}
+	return *cfg.RateLimitBudget, true, nil
+}
+
+// ResolveRateLimit returns the requests-per-minute limit using config
+// priority: environment variable TODOIST_RATE_LIMIT > config file. ok is
+// false when neither is set.
+func (s *Store) ResolveRateLimit() (perMinute int, ok bool, err error) {
+	if v := os.Getenv(EnvRateLimit); v != "" {
+		n, err := parseNonNegative(v)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid %s: %w", EnvRateLimit, err)
+		}
+		return n, true, nil
+	}
+
+	cfg, err := s.Load()
+	if err != nil {
+		return 0, false, err
+	}
+	if cfg.RateLimit == nil {
+		return 0, false, nil
+	}
+	return *cfg.RateLimit, true, nil
+}
+
+// ResolveAPIURL returns the API base URL using config priority:
+// environment variable TODOIST_API_URL > config file. It returns "" when
+// neither is set.
+func (s *Store) ResolveAPIURL() (string, error) {
+	if v := os.Getenv(EnvAPIURL); v != "" {
+		return v, nil
+	}
+
+	cfg, err := s.Load()
+	if err != nil {
+		return "", err
+	}
+	return cfg.APIURL, nil
+}
+
+// ResolveMaxRetries returns the retry count using config priority:
+// environment variable TODOIST_MAX_RETRIES > config file. ok is false when
+// neither is set.
+func (s *Store) ResolveMaxRetries() (retries int, ok bool, err error) {
+	if v := os.Getenv(EnvMaxRetries); v != "" {
+		n, err := parseNonNegative(v)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid %s: %w", EnvMaxRetries, err)
+		}
+		return n, true, nil
+	}
+
+	cfg, err := s.Load()
+	if err != nil {
+		return 0, false, err
+	}
+	if cfg.MaxRetries == nil {
+		return 0, false, nil
+	}
+	return *cfg.MaxRetries, true, nil
+}
+
+// ResolveCacheTTL returns the cache TTL using config priority: environment
+// variable TODOIST_CACHE_TTL > config file. ok is false when neither is set.
+func (s *Store) ResolveCacheTTL() (ttl time.Duration, ok bool, err error) {
+	if v := os.Getenv(EnvCacheTTL); v != "" {
+		d, err := parseDuration(v)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid %s: %w", EnvCacheTTL, err)
+		}
+		return d, true, nil
+	}
+
+	cfg, err := s.Load()
+	if err != nil {
+		return 0, false, err
+	}
+	if cfg.CacheTTL == nil {
+		return 0, false, nil
+	}
+	return *cfg.CacheTTL, true, nil
+}
+
+// ResolveTemplate returns the named template from the config file. ok is
+// false when there is no template by that name.
+func (s *Store) ResolveTemplate(name string) (text string, ok bool, err error) {
+	cfg, err := s.Load()
+	if err != nil {
+		return "", false, err
+	}
+	text, ok = cfg.Templates[name]
+	return text, ok, nil
+}
+
+// ResolveTheme returns the color theme name using config priority:
+// environment variable TODOIST_THEME > config file, along with the color
+// overrides from the config file. The name is "" when neither sets it.
+func (s *Store) ResolveTheme() (name string, colors map[string]string, err error) {
+	cfg, err := s.Load()
+	if err != nil {
+		return "", nil, err
+	}
+	if v := os.Getenv(EnvTheme); v != "" {
+		return v, cfg.Colors, nil
+	}
+	return cfg.Theme, cfg.Colors, nil
+}
+
+// parseNonNegative parses a non-negative integer such as a rate or retry count.
+func parseNonNegative(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("must not be negative")
+	}
+	return n, nil
+}
+
+// parseDuration parses a non-negative Go duration string. A bare number is
+// taken as seconds.
+func parseDuration(s string) (time.Duration, error) {
+	var d time.Duration
+	if n, err := strconv.Atoi(s); err == nil {
+		d = time.Duration(n) * time.Second
+	} else if d, err = time.ParseDuration(s); err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("must not be negative: %s", s)
+	}
+	return d, nil
+}
+
+// sortedKeys returns the keys of m in order, so saved files are stable.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // defaultStore returns a Store using the default env-based resolution.
 // Callers that need explicit error handling should use NewStoreWithEnv directly.
 func defaultStore() *Store {
@@ -177,3 +475,26 @@ func Permissions() (os.FileMode, error) { return defaultStore().Permissions() }
 
 // ResolveToken returns the access token from config file or TODOIST_ACCESS_TOKEN env var.
 func ResolveToken() string { return defaultStore().ResolveToken() }
+
+// ResolveRateLimit returns the requests-per-minute limit from TODOIST_RATE_LIMIT or the config file.
+func ResolveRateLimit() (int, bool, error) { return defaultStore().ResolveRateLimit() }
+
+// ResolveRateLimitBudget returns the rate limit budget from TODOIST_RATE_LIMIT_BUDGET or the config file.
+func ResolveRateLimitBudget() (time.Duration, bool, error) {
+	return defaultStore().ResolveRateLimitBudget()
+}
+
+// ResolveAPIURL returns the API base URL from TODOIST_API_URL or the config file.
+func ResolveAPIURL() (string, error) { return defaultStore().ResolveAPIURL() }
+
+// ResolveMaxRetries returns the retry count from TODOIST_MAX_RETRIES or the config file.
+func ResolveMaxRetries() (int, bool, error) { return defaultStore().ResolveMaxRetries() }
+
+// ResolveCacheTTL returns the cache TTL from TODOIST_CACHE_TTL or the config file.
+func ResolveCacheTTL() (time.Duration, bool, error) { return defaultStore().ResolveCacheTTL() }
+
+// ResolveTemplate returns the named template from the config file.
+func ResolveTemplate(name string) (string, bool, error) { return defaultStore().ResolveTemplate(name) }
+
+// ResolveTheme returns the color theme from TODOIST_THEME or the config file, and the color overrides.
+func ResolveTheme() (string, map[string]string, error) { return defaultStore().ResolveTheme() }
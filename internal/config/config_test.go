@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestNewStoreWithEnv_default(t *testing.T) {
@@ -129,3 +130,188 @@ func TestStore_ResolveToken(t *testing.T) {
 		t.Errorf("ResolveToken() = %q, want %q", tok, "file-token")
 	}
 }
+
+func TestStore_ResolveRateLimitBudget(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv(EnvConfigDir, tmp)
+	t.Setenv(EnvRateLimitBudget, "")
+
+	s, err := NewStoreWithEnv()
+	if err != nil {
+		t.Fatalf("NewStoreWithEnv() error = %v", err)
+	}
+
+	// Nothing set.
+	if _, ok, err := s.ResolveRateLimitBudget(); ok || err != nil {
+		t.Errorf("ResolveRateLimitBudget() ok = %v, err = %v, want false, nil", ok, err)
+	}
+
+	// Config file value survives a save/load round trip.
+	budget := 90 * time.Second
+	if err := s.Save(&Config{AccessToken: "tok", RateLimitBudget: &budget}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if got, ok, err := s.ResolveRateLimitBudget(); !ok || err != nil || got != budget {
+		t.Errorf("ResolveRateLimitBudget() = %v, %v, %v, want %v, true, nil", got, ok, err, budget)
+	}
+
+	// Env var takes priority; a bare number means seconds.
+	t.Setenv(EnvRateLimitBudget, "5")
+	if got, _, _ := s.ResolveRateLimitBudget(); got != 5*time.Second {
+		t.Errorf("ResolveRateLimitBudget() = %v, want 5s", got)
+	}
+
+	t.Setenv(EnvRateLimitBudget, "soon")
+	if _, _, err := s.ResolveRateLimitBudget(); err == nil {
+		t.Error("ResolveRateLimitBudget() with invalid env var: want error")
+	}
+}
+
+func TestStore_ResolveRateLimit(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv(EnvConfigDir, tmp)
+	t.Setenv(EnvRateLimit, "")
+
+	s, err := NewStoreWithEnv()
+	if err != nil {
+		t.Fatalf("NewStoreWithEnv() error = %v", err)
+	}
+
+	if _, ok, err := s.ResolveRateLimit(); ok || err != nil {
+		t.Errorf("ResolveRateLimit() ok = %v, err = %v, want false, nil", ok, err)
+	}
+
+	zero := 0
+	if err := s.Save(&Config{AccessToken: "tok", RateLimit: &zero}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if got, ok, err := s.ResolveRateLimit(); !ok || err != nil || got != 0 {
+		t.Errorf("ResolveRateLimit() = %v, %v, %v, want 0, true, nil", got, ok, err)
+	}
+
+	t.Setenv(EnvRateLimit, "30")
+	if got, _, _ := s.ResolveRateLimit(); got != 30 {
+		t.Errorf("ResolveRateLimit() = %v, want 30", got)
+	}
+
+	t.Setenv(EnvRateLimit, "-1")
+	if _, _, err := s.ResolveRateLimit(); err == nil {
+		t.Error("ResolveRateLimit() with negative env var: want error")
+	}
+}
+
+func TestStore_ResolveAPIURLAndMaxRetries(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv(EnvConfigDir, tmp)
+	t.Setenv(EnvAPIURL, "")
+	t.Setenv(EnvMaxRetries, "")
+
+	s, err := NewStoreWithEnv()
+	if err != nil {
+		t.Fatalf("NewStoreWithEnv() error = %v", err)
+	}
+
+	retries := 5
+	if err := s.Save(&Config{AccessToken: "tok", APIURL: "http://localhost:8080/api/v1", MaxRetries: &retries}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if got, err := s.ResolveAPIURL(); err != nil || got != "http://localhost:8080/api/v1" {
+		t.Errorf("ResolveAPIURL() = %q, %v, want config value", got, err)
+	}
+	if got, ok, err := s.ResolveMaxRetries(); !ok || err != nil || got != 5 {
+		t.Errorf("ResolveMaxRetries() = %v, %v, %v, want 5, true, nil", got, ok, err)
+	}
+
+	t.Setenv(EnvAPIURL, "http://127.0.0.1:9999")
+	t.Setenv(EnvMaxRetries, "0")
+	if got, _ := s.ResolveAPIURL(); got != "http://127.0.0.1:9999" {
+		t.Errorf("ResolveAPIURL() = %q, want env value", got)
+	}
+	if got, ok, _ := s.ResolveMaxRetries(); !ok || got != 0 {
+		t.Errorf("ResolveMaxRetries() = %v, %v, want 0, true", got, ok)
+	}
+}
+
+func TestStore_ResolveCacheTTL(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv(EnvConfigDir, tmp)
+	t.Setenv(EnvCacheTTL, "")
+
+	s, err := NewStoreWithEnv()
+	if err != nil {
+		t.Fatalf("NewStoreWithEnv() error = %v", err)
+	}
+
+	if _, ok, err := s.ResolveCacheTTL(); ok || err != nil {
+		t.Errorf("ResolveCacheTTL() ok = %v, err = %v, want false, nil", ok, err)
+	}
+
+	// Zero (cache disabled) is distinct from unset.
+	var zero time.Duration
+	if err := s.Save(&Config{AccessToken: "tok", CacheTTL: &zero}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if got, ok, err := s.ResolveCacheTTL(); !ok || err != nil || got != 0 {
+		t.Errorf("ResolveCacheTTL() = %v, %v, %v, want 0, true, nil", got, ok, err)
+	}
+
+	t.Setenv(EnvCacheTTL, "10m")
+	if got, _, _ := s.ResolveCacheTTL(); got != 10*time.Minute {
+		t.Errorf("ResolveCacheTTL() = %v, want 10m", got)
+	}
+
+	t.Setenv(EnvCacheTTL, "-1h")
+	if _, _, err := s.ResolveCacheTTL(); err == nil {
+		t.Error("ResolveCacheTTL() with negative env var: want error")
+	}
+}
+
+func TestStore_ResolveTemplate(t *testing.T) {
+	t.Setenv(EnvConfigDir, t.TempDir())
+
+	s, err := NewStoreWithEnv()
+	if err != nil {
+		t.Fatalf("NewStoreWithEnv() error = %v", err)
+	}
+
+	if _, ok, err := s.ResolveTemplate("prompt"); ok || err != nil {
+		t.Errorf("ResolveTemplate() ok = %v, err = %v, want false, nil", ok, err)
+	}
+
+	want := "{{.ID}} {{.Content}} {{due .}}"
+	if err := s.Save(&Config{AccessToken: "tok", Templates: map[string]string{"prompt": want, "bar": "{{.Content}}"}}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if got, ok, err := s.ResolveTemplate("prompt"); !ok || err != nil || got != want {
+		t.Errorf("ResolveTemplate() = %q, %v, %v, want %q", got, ok, err, want)
+	}
+	if cfg, _ := s.Load(); len(cfg.Templates) != 2 {
+		t.Errorf("Load() templates = %v, want both saved", cfg.Templates)
+	}
+}
+
+func TestStore_ResolveTheme(t *testing.T) {
+	t.Setenv(EnvConfigDir, t.TempDir())
+	t.Setenv(EnvTheme, "")
+
+	s, err := NewStoreWithEnv()
+	if err != nil {
+		t.Fatalf("NewStoreWithEnv() error = %v", err)
+	}
+
+	if name, colors, err := s.ResolveTheme(); name != "" || colors != nil || err != nil {
+		t.Errorf("ResolveTheme() = %q, %v, %v, want empty", name, colors, err)
+	}
+
+	if err := s.Save(&Config{AccessToken: "tok", Theme: "mono", Colors: map[string]string{"p1": "1;35"}}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if name, colors, err := s.ResolveTheme(); name != "mono" || colors["p1"] != "1;35" || err != nil {
+		t.Errorf("ResolveTheme() = %q, %v, %v, want mono with the p1 override", name, colors, err)
+	}
+
+	t.Setenv(EnvTheme, "none")
+	if name, _, _ := s.ResolveTheme(); name != "none" {
+		t.Errorf("ResolveTheme() = %q, want the env override", name)
+	}
+}
@@ -0,0 +1,352 @@
+// Package filter parses Todoist filter queries and evaluates them against
+// tasks locally, so a query can be checked before it is sent and answered
+// from a snapshot when the API is out of reach.
+//
+// The grammar follows the Todoist filter syntax:
+//
+//	query   = or { "," or }          comma joins queries, like "|"
+//	or      = and { "|" and }
+//	and     = unary { "&" unary }
+//	unary   = "!" unary | "(" or ")" | term
+//
+// Terms are the text between operators, such as "today", "p1", "@home",
+// "#Work", "due before: 2026-03-12" or "assigned to: me". A backslash
+// makes the next character part of the term, so "@a\&b" is one label.
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+// ParseError reports a problem with a query at a column, counted in
+// characters from 1.
+type ParseError struct {
+	Query  string
+	Column int
+	Msg    string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("invalid filter at column %d: %s\n\n  %s\n  %s^",
+		e.Column, e.Msg, e.Query, strings.Repeat(" ", e.Column-1))
+}
+
+// Filter is a parsed query.
+type Filter struct {
+	query string
+	root  node
+
+	// unsupported lists terms the API understands but this package
+	// can't evaluate, such as relative dates like "next monday".
+	unsupported []*term
+}
+
+// Parse parses query. Structural mistakes, such as an unbalanced
+// parenthesis or an operator without an operand, are reported as a
+// *ParseError pointing at the offending column. Terms this package can't
+// evaluate are not errors here; see Local.
+func Parse(query string) (*Filter, error) {
+	p := &parser{query: query, tokens: lex(query)}
+	f := &Filter{query: query}
+
+	root, err := p.parseQuery()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, p.errorAt(tok.pos, fmt.Sprintf("unexpected %q", tok.text))
+	}
+	f.root = root
+	f.unsupported = p.unsupported
+	return f, nil
+}
+
+// String returns the query as written.
+func (f *Filter) String() string {
+	return f.query
+}
+
+// Local returns a *ParseError for the first term that can only be
+// evaluated by the API, or nil if Match understands the whole query.
+func (f *Filter) Local() error {
+	if len(f.unsupported) == 0 {
+		return nil
+	}
+	t := f.unsupported[0]
+	return &ParseError{Query: f.query, Column: t.col, Msg: fmt.Sprintf("%q can only be evaluated by the Todoist API", t.text)}
+}
+
+// Match reports whether t matches the query. Terms Local reports never
+// match.
+func (f *Filter) Match(t *api.Task, env *Env) bool {
+	return f.root.match(t, env)
+}
+
+// Apply returns the tasks that match the query, in their original order.
+func (f *Filter) Apply(tasks []*api.Task, env *Env) []*api.Task {
+	matched := make([]*api.Task, 0, len(tasks))
+	for _, t := range tasks {
+		if f.Match(t, env) {
+			matched = append(matched, t)
+		}
+	}
+	return matched
+}
+
+// Env is what terms are evaluated against besides the task itself. The
+// zero value works: dates are relative to the current time and names
+// match nothing.
+type Env struct {
+	// Now is the moment "today" and "overdue" are relative to. Zero means
+	// time.Now().
+	Now time.Time
+
+	// Projects and Sections resolve #project and /section names.
+	Projects []*api.Project
+	Sections []*api.Section
+
+	// Collaborators resolve names and emails in "assigned to:".
+	Collaborators []*api.Collaborator
+
+	// UserID is who "assigned to: me" means, and who "assigned to:
+	// others" excludes. When empty, neither matches.
+	UserID string
+
+	once     sync.Once
+	projects map[string]*api.Project
+	sections map[string]*api.Section
+}
+
+func (e *Env) now() time.Time {
+	if e.Now.IsZero() {
+		return time.Now()
+	}
+	return e.Now
+}
+
+// index builds the ID lookups on first use.
+func (e *Env) index() {
+	e.once.Do(func() {
+		e.projects = make(map[string]*api.Project, len(e.Projects))
+		for _, p := range e.Projects {
+			e.projects[p.ID] = p
+		}
+		e.sections = make(map[string]*api.Section, len(e.Sections))
+		for _, s := range e.Sections {
+			e.sections[s.ID] = s
+		}
+	})
+}
+
+// --- syntax tree ---
+
+type node interface {
+	match(t *api.Task, env *Env) bool
+}
+
+type andNode struct{ left, right node }
+
+func (n *andNode) match(t *api.Task, env *Env) bool {
+	return n.left.match(t, env) && n.right.match(t, env)
+}
+
+type orNode struct{ left, right node }
+
+func (n *orNode) match(t *api.Task, env *Env) bool {
+	return n.left.match(t, env) || n.right.match(t, env)
+}
+
+type notNode struct{ operand node }
+
+func (n *notNode) match(t *api.Task, env *Env) bool {
+	return !n.operand.match(t, env)
+}
+
+// --- lexer ---
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokTerm
+	tokAnd
+	tokOr
+	tokNot
+	tokLParen
+	tokRParen
+	tokComma
+)
+
+var operators = map[rune]tokenKind{
+	'&': tokAnd,
+	'|': tokOr,
+	'!': tokNot,
+	'(': tokLParen,
+	')': tokRParen,
+	',': tokComma,
+}
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int // byte offset in the query
+}
+
+// lex splits query into operators and the trimmed terms between them.
+func lex(query string) []token {
+	var tokens []token
+	var term strings.Builder
+	start := -1 // byte offset of the term's first non-space character
+
+	flush := func() {
+		if text := strings.TrimSpace(term.String()); text != "" {
+			tokens = append(tokens, token{kind: tokTerm, text: text, pos: start})
+		}
+		term.Reset()
+		start = -1
+	}
+
+	for i := 0; i < len(query); {
+		r, size := utf8.DecodeRuneInString(query[i:])
+		if kind, ok := operators[r]; ok {
+			flush()
+			tokens = append(tokens, token{kind: kind, text: string(r), pos: i})
+			i += size
+			continue
+		}
+		if r == '\\' && i+size < len(query) {
+			if start < 0 {
+				start = i
+			}
+			i += size
+			r, size = utf8.DecodeRuneInString(query[i:])
+		} else if start < 0 && r != ' ' && r != '\t' {
+			start = i
+		}
+		term.WriteRune(r)
+		i += size
+	}
+	flush()
+	return append(tokens, token{kind: tokEOF, pos: len(query)})
+}
+
+// --- parser ---
+
+type parser struct {
+	query       string
+	tokens      []token
+	next        int
+	unsupported []*term
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) take() token {
+	tok := p.tokens[p.next]
+	if tok.kind != tokEOF {
+		p.next++
+	}
+	return tok
+}
+
+func (p *parser) errorAt(pos int, msg string) *ParseError {
+	return &ParseError{Query: p.query, Column: utf8.RuneCountInString(p.query[:pos]) + 1, Msg: msg}
+}
+
+func (p *parser) parseQuery() (node, error) {
+	left, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokComma {
+		p.take()
+		right, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOr {
+		p.take()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokAnd {
+		p.take()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	tok := p.take()
+	switch tok.kind {
+	case tokNot:
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{operand}, nil
+	case tokLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.peek(); closing.kind != tokRParen {
+			if closing.kind == tokEOF {
+				return nil, p.errorAt(tok.pos, `unclosed "("`)
+			}
+			return nil, p.errorAt(closing.pos, fmt.Sprintf(`expected ")" but found %q`, closing.text))
+		}
+		p.take()
+		return inner, nil
+	case tokTerm:
+		t, err := parseTerm(tok.text)
+		if err != nil {
+			return nil, p.errorAt(tok.pos, err.Error())
+		}
+		t.col = utf8.RuneCountInString(p.query[:tok.pos]) + 1
+		if t.fn == nil {
+			p.unsupported = append(p.unsupported, t)
+		}
+		return t, nil
+	case tokEOF:
+		if tok.pos == 0 {
+			return nil, p.errorAt(tok.pos, "empty filter")
+		}
+		return nil, p.errorAt(tok.pos, "expected a filter term at the end of the query")
+	default:
+		return nil, p.errorAt(tok.pos, fmt.Sprintf("expected a filter term but found %q", tok.text))
+	}
+}
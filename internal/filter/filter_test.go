@@ -0,0 +1,148 @@
+package filter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+func TestParse_errors(t *testing.T) {
+	tests := []struct {
+		query   string
+		column  int
+		wantMsg string
+	}{
+		{"", 1, "empty filter"},
+		{"today &", 8, "at the end"},
+		{"today & | p1", 9, `found "|"`},
+		{"(today | p1", 1, `unclosed "("`},
+		{"today)", 6, `unexpected ")"`},
+		{"p1 & @", 6, `"@" needs a label name`},
+		{"#Work & ##", 9, `"##" needs a project name`},
+		{"due before:  ", 1, `missing a value after "due before:"`},
+		{"()", 2, `found ")"`},
+		{"café & !", 9, "at the end"},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.query)
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("Parse(%q) error = %v, want a *ParseError", tt.query, err)
+			continue
+		}
+		if pe.Column != tt.column || !strings.Contains(pe.Msg, tt.wantMsg) {
+			t.Errorf("Parse(%q) = column %d %q, want column %d %q", tt.query, pe.Column, pe.Msg, tt.column, tt.wantMsg)
+		}
+	}
+}
+
+func TestParseError_pointsAtColumn(t *testing.T) {
+	_, err := Parse("today & | p1")
+	want := "invalid filter at column 9: expected a filter term but found \"|\"\n\n  today & | p1\n          ^"
+	if err == nil || err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
+	}
+}
+
+func TestFilter_Local(t *testing.T) {
+	f, err := Parse("today | due before: next monday")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	var pe *ParseError
+	if err := f.Local(); !errors.As(err, &pe) || pe.Column != 9 {
+		t.Errorf("Local() = %v, want an error at column 9", err)
+	}
+
+	f, _ = Parse("(today | overdue) & p1")
+	if err := f.Local(); err != nil {
+		t.Errorf("Local() = %v, want nil", err)
+	}
+}
+
+func TestFilter_Match(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	env := &Env{
+		Now: now,
+		Projects: []*api.Project{
+			{ID: "inbox", Name: "Inbox"},
+			{ID: "work", Name: "Work"},
+			{ID: "reports", Name: "Reports", ParentID: "work"},
+		},
+		Sections:      []*api.Section{{ID: "s1", ProjectID: "work", Name: "Next Up"}},
+		Collaborators: []*api.Collaborator{{ID: "u2", Name: "Ana Lima", Email: "ana@example.com"}},
+		UserID:        "u1",
+	}
+	tasks := []*api.Task{
+		{ID: "today", ProjectID: "inbox", Content: "Buy milk", Priority: 4, Labels: []string{"errands"}, Due: &api.Due{Date: "2026-03-10"}},
+		{ID: "late", ProjectID: "work", SectionID: "s1", Priority: 1, Due: &api.Due{Date: "2026-03-08", IsRecurring: true}, AssigneeID: "u1"},
+		{ID: "morning", ProjectID: "reports", Priority: 3, Due: &api.Due{Date: "2026-03-10T09:00:00Z", Datetime: "2026-03-10T09:00:00Z"}, AssigneeID: "u2"},
+		{ID: "soon", ProjectID: "reports", ParentID: "morning", Priority: 2, Labels: []string{"work-deep"}, Due: &api.Due{Date: "2026-03-13"}},
+		{ID: "nodate", ProjectID: "inbox", Priority: 1, Content: "Read a book"},
+	}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"today", "today morning"},
+		{"overdue", "late morning"},
+		{"od | today", "today late morning"},
+		{"no date", "nodate"},
+		{"!no date & !overdue", "today soon"},
+		{"p1", "today"},
+		{"p4", "late nodate"},
+		{"@errands", "today"},
+		{"@work*", "soon"},
+		{"no labels", "late morning nodate"},
+		{"#work", "late"},
+		{"##Work", "late morning soon"},
+		{"#Inbox & p1, #Reports & subtask", "today soon"},
+		{"/next up", "late"},
+		{"due before: today", "late"},
+		{"due before: 2026-03-11", "today late morning"},
+		{"due after: today", "soon"},
+		{"date: tomorrow | due: 2026-03-13", "soon"},
+		{"3 days", "today morning"},
+		{"next 4 days", "today morning soon"},
+		{"recurring", "late"},
+		{"assigned to: me", "late"},
+		{"assigned to: others", "morning"},
+		{"assigned to: ANA LIMA | assigned to: ana@example.com", "morning"},
+		{"search: milk", "today"},
+		{"(today | overdue) & !#Inbox", "late morning"},
+		{"!(p1 | p4)", "morning soon"},
+		{"all", "today late morning soon nodate"},
+		{"next monday", ""},
+	}
+	for _, tt := range tests {
+		f, err := Parse(tt.query)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", tt.query, err)
+			continue
+		}
+		var got []string
+		for _, task := range f.Apply(tasks, env) {
+			got = append(got, task.ID)
+		}
+		if strings.Join(got, " ") != tt.want {
+			t.Errorf("%q matched %q, want %q", tt.query, strings.Join(got, " "), tt.want)
+		}
+	}
+}
+
+func TestLex_escapes(t *testing.T) {
+	f, err := Parse(`@a\&b | @c\|d`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	for labels, want := range map[string]bool{"a&b": true, "c|d": true, "a": false} {
+		task := &api.Task{Labels: []string{labels}}
+		if got := f.Match(task, &Env{}); got != want {
+			t.Errorf("Match(@%s) = %v, want %v", labels, got, want)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+// term is one filter term. fn is nil for terms only the API can evaluate.
+type term struct {
+	text string
+	col  int
+	fn   func(t *api.Task, env *Env) bool
+}
+
+func (t *term) match(task *api.Task, env *Env) bool {
+	return t.fn != nil && t.fn(task, env)
+}
+
+var daysTerm = regexp.MustCompile(`^(?:next )?(\d+) days?$`)
+
+// parseTerm classifies the text of one term. It returns an error only for
+// terms that can't be valid, such as "@" without a name.
+func parseTerm(text string) (*term, error) {
+	t := &term{text: text}
+	key := strings.ToLower(strings.Join(strings.Fields(text), " "))
+
+	switch {
+	case strings.HasPrefix(text, "##"):
+		name, err := nameAfter(text, "##", "project")
+		if err != nil {
+			return nil, err
+		}
+		t.fn = func(task *api.Task, env *Env) bool { return inProjectTree(task, env, name) }
+		return t, nil
+	case strings.HasPrefix(text, "#"):
+		name, err := nameAfter(text, "#", "project")
+		if err != nil {
+			return nil, err
+		}
+		t.fn = func(task *api.Task, env *Env) bool {
+			env.index()
+			p, ok := env.projects[task.ProjectID]
+			return ok && glob(name, p.Name)
+		}
+		return t, nil
+	case strings.HasPrefix(text, "/"):
+		name, err := nameAfter(text, "/", "section")
+		if err != nil {
+			return nil, err
+		}
+		t.fn = func(task *api.Task, env *Env) bool {
+			env.index()
+			s, ok := env.sections[task.SectionID]
+			return ok && glob(name, s.Name)
+		}
+		return t, nil
+	case strings.HasPrefix(text, "@"):
+		name, err := nameAfter(text, "@", "label")
+		if err != nil {
+			return nil, err
+		}
+		t.fn = func(task *api.Task, env *Env) bool {
+			for _, l := range task.Labels {
+				if glob(name, l) {
+					return true
+				}
+			}
+			return false
+		}
+		return t, nil
+	}
+
+	if head, value, ok := strings.Cut(key, ":"); ok {
+		return parseKeyValue(t, head, strings.TrimSpace(value), strings.TrimSpace(text[strings.Index(text, ":")+1:]))
+	}
+
+	switch key {
+	case "today":
+		t.fn = dueOn(func(today time.Time) time.Time { return today })
+	case "tomorrow":
+		t.fn = dueOn(func(today time.Time) time.Time { return today.AddDate(0, 0, 1) })
+	case "yesterday":
+		t.fn = dueOn(func(today time.Time) time.Time { return today.AddDate(0, 0, -1) })
+	case "overdue", "od":
+		t.fn = overdue
+	case "no date", "no due date":
+		t.fn = func(task *api.Task, env *Env) bool { return task.Due == nil }
+	case "recurring":
+		t.fn = func(task *api.Task, env *Env) bool { return task.Due != nil && task.Due.IsRecurring }
+	case "no deadline":
+		t.fn = func(task *api.Task, env *Env) bool { return task.Deadline == nil }
+	case "no labels":
+		t.fn = func(task *api.Task, env *Env) bool { return len(task.Labels) == 0 }
+	case "subtask":
+		t.fn = func(task *api.Task, env *Env) bool { return task.ParentID != "" }
+	case "assigned":
+		t.fn = func(task *api.Task, env *Env) bool { return task.AssigneeID != "" }
+	case "all", "view all":
+		t.fn = func(task *api.Task, env *Env) bool { return true }
+	case "p1", "p2", "p3", "p4":
+		priority := 5 - int(key[1]-'0') // p1 is API priority 4
+		t.fn = func(task *api.Task, env *Env) bool { return task.Priority == priority }
+	default:
+		if m := daysTerm.FindStringSubmatch(key); m != nil {
+			n, _ := strconv.Atoi(m[1])
+			t.fn = dueWithin(n)
+		} else if day, ok := parseDate(key); ok {
+			t.fn = dueOn(day)
+		}
+	}
+	return t, nil
+}
+
+// parseKeyValue handles "key: value" terms. value is lowercased; raw keeps
+// the value as written, for names.
+func parseKeyValue(t *term, head, value, raw string) (*term, error) {
+	switch head {
+	case "due", "date", "due before", "date before", "due after", "date after", "assigned to", "search":
+	default:
+		return t, nil
+	}
+	if value == "" {
+		return nil, fmt.Errorf("missing a value after %q", head+":")
+	}
+
+	switch head {
+	case "due", "date":
+		if day, ok := parseDate(value); ok {
+			t.fn = dueOn(day)
+		}
+	case "due before", "date before":
+		if day, ok := parseDate(value); ok {
+			t.fn = dueCompare(day, func(due, limit time.Time) bool { return due.Before(limit) })
+		}
+	case "due after", "date after":
+		if day, ok := parseDate(value); ok {
+			t.fn = dueCompare(day, func(due, limit time.Time) bool { return due.After(limit) })
+		}
+	case "assigned to":
+		t.fn = assignedTo(raw)
+	case "search":
+		t.fn = func(task *api.Task, env *Env) bool {
+			return strings.Contains(strings.ToLower(task.Content), value)
+		}
+	}
+	return t, nil
+}
+
+// nameAfter returns the name following prefix, or an error if it is empty.
+func nameAfter(text, prefix, kind string) (string, error) {
+	name := strings.TrimSpace(strings.TrimPrefix(text, prefix))
+	if name == "" {
+		return "", fmt.Errorf("%q needs a %s name", prefix, kind)
+	}
+	return name, nil
+}
+
+// glob reports whether name matches pattern case-insensitively, where "*"
+// in pattern matches any run of characters.
+func glob(pattern, name string) bool {
+	pattern, name = strings.ToLower(pattern), strings.ToLower(name)
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == name
+	}
+	if !strings.HasPrefix(name, parts[0]) {
+		return false
+	}
+	name = name[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(name, part)
+		if i < 0 {
+			return false
+		}
+		name = name[i+len(part):]
+	}
+	return strings.HasSuffix(name, parts[len(parts)-1])
+}
+
+// inProjectTree reports whether the task is in the named project or one
+// of its descendants.
+func inProjectTree(task *api.Task, env *Env, name string) bool {
+	env.index()
+	seen := map[string]bool{}
+	for id := task.ProjectID; id != "" && !seen[id]; {
+		seen[id] = true
+		p, ok := env.projects[id]
+		if !ok {
+			return false
+		}
+		if glob(name, p.Name) {
+			return true
+		}
+		id = p.ParentID
+	}
+	return false
+}
+
+// assignedTo matches "me", "others", or a collaborator's ID, name or email.
+// Without env.UserID, neither "me" nor "others" matches anything.
+func assignedTo(who string) func(*api.Task, *Env) bool {
+	return func(task *api.Task, env *Env) bool {
+		switch strings.ToLower(who) {
+		case "me":
+			return env.UserID != "" && task.AssigneeID == env.UserID
+		case "others":
+			return env.UserID != "" && task.AssigneeID != "" && task.AssigneeID != env.UserID
+		}
+		if task.AssigneeID == "" {
+			return false
+		}
+		if task.AssigneeID == who {
+			return true
+		}
+		for _, c := range env.Collaborators {
+			if c.ID == task.AssigneeID && (strings.EqualFold(c.Name, who) || strings.EqualFold(c.Email, who)) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// --- dates ---
+
+// parseDate parses the date values this package understands into a
+// function of today.
+func parseDate(value string) (func(today time.Time) time.Time, bool) {
+	switch value {
+	case "today":
+		return func(today time.Time) time.Time { return today }, true
+	case "tomorrow":
+		return func(today time.Time) time.Time { return today.AddDate(0, 0, 1) }, true
+	case "yesterday":
+		return func(today time.Time) time.Time { return today.AddDate(0, 0, -1) }, true
+	}
+	d, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, false
+	}
+	return func(today time.Time) time.Time {
+		return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, today.Location())
+	}, true
+}
+
+// today returns midnight at the start of the env's current day.
+func today(env *Env) time.Time {
+	now := env.now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+}
+
+// dueDay returns the day the task is due, in the env's time zone.
+func dueDay(task *api.Task, env *Env) (time.Time, bool) {
+	if task.Due == nil {
+		return time.Time{}, false
+	}
+	loc := env.now().Location()
+	if task.Due.Datetime != "" {
+		if t, err := time.Parse(time.RFC3339, task.Due.Datetime); err == nil {
+			t = t.In(loc)
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc), true
+		}
+	}
+	date := task.Due.Date
+	if len(date) > len("2006-01-02") {
+		date = date[:len("2006-01-02")] // floating time, "2006-01-02T15:04:05"
+	}
+	d, err := time.ParseInLocation("2006-01-02", date, loc)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return d, true
+}
+
+func dueOn(day func(today time.Time) time.Time) func(*api.Task, *Env) bool {
+	return dueCompare(day, func(due, limit time.Time) bool { return due.Equal(limit) })
+}
+
+func dueCompare(day func(today time.Time) time.Time, cmp func(due, limit time.Time) bool) func(*api.Task, *Env) bool {
+	return func(task *api.Task, env *Env) bool {
+		due, ok := dueDay(task, env)
+		return ok && cmp(due, day(today(env)))
+	}
+}
+
+// dueWithin matches tasks due in the n days starting today.
+func dueWithin(n int) func(*api.Task, *Env) bool {
+	return func(task *api.Task, env *Env) bool {
+		due, ok := dueDay(task, env)
+		start := today(env)
+		return ok && !due.Before(start) && due.Before(start.AddDate(0, 0, n))
+	}
+}
+
+// overdue matches tasks due before today, or earlier today at a time that
+// has passed.
+func overdue(task *api.Task, env *Env) bool {
+	due, ok := dueDay(task, env)
+	if !ok {
+		return false
+	}
+	if due.Before(today(env)) {
+		return true
+	}
+	if task.Due.Datetime != "" {
+		if t, err := time.Parse(time.RFC3339, task.Due.Datetime); err == nil {
+			return t.Before(env.now())
+		}
+	}
+	return false
+}
@@ -0,0 +1,252 @@
+package offline
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+// Entry is one queued change. The change is stored as the sync command that
+// will carry it, so replaying the journal is a single batched sync, and
+// commands that refer to a task added offline use its temp ID.
+type Entry struct {
+	QueuedAt time.Time       `json:"queued_at"`
+	Command  api.SyncCommand `json:"command"`
+}
+
+// String describes the change for people, e.g. `add "Buy milk"` or
+// `close 6X7rM8997g3RQmvh`.
+func (e Entry) String() string {
+	verb := strings.TrimPrefix(e.Command.Type, "item_")
+	switch e.Command.Type {
+	case api.CommandItemAdd:
+		content, _ := e.Command.Args["content"].(string)
+		return fmt.Sprintf("add %q", content)
+	case api.CommandItemUpdate:
+		verb = "edit"
+	case api.CommandItemUncomplete:
+		verb = "reopen"
+	}
+	return verb + " " + e.TaskID()
+}
+
+// TaskID returns the task the entry changes: the temp ID of a queued add,
+// or the ID the command targets.
+func (e Entry) TaskID() string {
+	if e.Command.TempID != "" {
+		return e.Command.TempID
+	}
+	id, _ := e.Command.Args["id"].(string)
+	return id
+}
+
+// Journal is an append-only file of queued changes, one JSON entry per line.
+type Journal struct {
+	path string
+}
+
+// OpenJournal returns the journal stored at path. The file is created on
+// the first Append.
+func OpenJournal(path string) *Journal {
+	return &Journal{path: path}
+}
+
+// Path returns the journal file path.
+func (j *Journal) Path() string {
+	return j.path
+}
+
+// Append adds entries to the end of the journal and flushes them to disk
+// before returning, so a queued change survives a crash.
+func (j *Journal) Append(entries ...Entry) error {
+	var buf bytes.Buffer
+	for _, e := range entries {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("failed to encode journal entry: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	if err := os.MkdirAll(filepath.Dir(j.path), 0700); err != nil {
+		return fmt.Errorf("failed to create journal directory: %w", err)
+	}
+	f, err := os.OpenFile(j.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open journal: %w", err)
+	}
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	return f.Close()
+}
+
+// Entries returns the queued changes in the order they were made. A
+// missing journal has no entries.
+func (j *Journal) Entries() ([]Entry, error) {
+	f, err := os.Open(j.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("failed to parse journal line %d: %w", line, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+	return entries, nil
+}
+
+// rewrite replaces the journal with entries, atomically. An empty list
+// removes the file.
+func (j *Journal) rewrite(entries []Entry) error {
+	if len(entries) == 0 {
+		if err := os.Remove(j.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove journal: %w", err)
+		}
+		return nil
+	}
+
+	tmp := j.path + ".tmp"
+	if err := os.Remove(tmp); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to rewrite journal: %w", err)
+	}
+	if err := (&Journal{path: tmp}).Append(entries...); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, j.path); err != nil {
+		return fmt.Errorf("failed to rewrite journal: %w", err)
+	}
+	return nil
+}
+
+// Outcome statuses reported by Replay.
+const (
+	StatusSynced   = "synced"
+	StatusConflict = "conflict"
+	StatusFailed   = "failed"
+)
+
+// Outcome is what happened to one entry during Replay.
+type Outcome struct {
+	Entry  Entry
+	Status string
+	// TaskID is the real ID of a task added offline, once synced.
+	TaskID string
+	Err    error
+}
+
+// Replay sends the queued changes to client in order, in batched syncs,
+// and removes every entry the API answered for. Entries it never answered
+// for, because the connection failed part way, stay queued for the next
+// run with any temp IDs already mapped to real ones.
+//
+// A change to a task that was deleted upstream is a conflict: it is
+// dropped and reported, except for a queued delete, whose work is already
+// done. Other rejected changes are dropped as failed, since sending them
+// again would fail the same way.
+func (j *Journal) Replay(ctx context.Context, client api.TodoistAPI) ([]Outcome, error) {
+	entries, err := j.Entries()
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+
+	var outcomes []Outcome
+	tempIDs := map[string]string{}
+	sent := 0
+	var syncErr error
+	for batch := range slices.Chunk(entries, api.MaxSyncCommands) {
+		cmds := make([]api.SyncCommand, len(batch))
+		for i, e := range batch {
+			cmds[i] = e.Command
+			cmds[i].Args = mapTempIDs(e.Command.Args, tempIDs)
+		}
+		resp, err := client.Sync(ctx, &api.SyncRequest{Commands: cmds})
+		if err != nil {
+			syncErr = err
+			break
+		}
+		for k, v := range resp.TempIDMapping {
+			tempIDs[k] = v
+		}
+		for i, e := range batch {
+			outcomes = append(outcomes, outcome(e, resp.Err(cmds[i]), tempIDs))
+		}
+		sent += len(batch)
+	}
+
+	remaining := entries[sent:]
+	for i := range remaining {
+		remaining[i].Command.Args = mapTempIDs(remaining[i].Command.Args, tempIDs)
+	}
+	if err := j.rewrite(remaining); err != nil {
+		return outcomes, err
+	}
+	if syncErr != nil {
+		return outcomes, fmt.Errorf("failed to replay journal, %d change(s) still queued: %w", len(remaining), syncErr)
+	}
+	return outcomes, nil
+}
+
+// outcome classifies the API's answer err to entry e.
+func outcome(e Entry, err error, tempIDs map[string]string) Outcome {
+	o := Outcome{Entry: e, Status: StatusSynced, TaskID: e.TaskID()}
+	if real, ok := tempIDs[o.TaskID]; ok {
+		o.TaskID = real
+	}
+	switch {
+	case err == nil:
+	case api.IsNotFoundError(err) && e.Command.Type == api.CommandItemDelete:
+		// Already gone; nothing left to do.
+	case api.IsNotFoundError(err):
+		o.Status, o.Err = StatusConflict, fmt.Errorf("task %s was deleted upstream", o.TaskID)
+	default:
+		o.Status, o.Err = StatusFailed, err
+	}
+	return o
+}
+
+// mapTempIDs returns args with any string value that is a known temp ID
+// replaced by the real ID.
+func mapTempIDs(args map[string]any, mapping map[string]string) map[string]any {
+	out := make(map[string]any, len(args))
+	for k, v := range args {
+		if s, ok := v.(string); ok {
+			if real, ok := mapping[s]; ok {
+				v = real
+			}
+		}
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,419 @@
+// Package offline keeps the CLI usable without a connection to Todoist.
+//
+// Client wraps an api.TodoistAPI. Task changes it cannot deliver, because
+// the API is unreachable or offline mode was asked for, are written to a
+// Journal as sync commands and replayed in order by `todoist sync`. Reads
+// it cannot deliver are answered from the snapshot `todoist sync` keeps in
+// the config directory, with the queued changes applied on top.
+package offline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+// QueuedError reports that a change was written to the journal instead of
+// being sent. Commands treat it as success with a "queued" status.
+type QueuedError struct {
+	Entries []Entry
+	// Cause is the error that showed the API was unreachable, or nil when
+	// the client was already offline.
+	Cause error
+}
+
+func (e *QueuedError) Error() string {
+	msg := fmt.Sprintf("%d change(s) queued for the next sync", len(e.Entries))
+	if e.Cause != nil {
+		msg += ": " + e.Cause.Error()
+	}
+	return msg
+}
+
+func (e *QueuedError) Unwrap() error {
+	return e.Cause
+}
+
+// TaskID returns the task the first queued change applies to. For a
+// queued add it is the temp ID the task goes by until it is synced.
+func (e *QueuedError) TaskID() string {
+	if len(e.Entries) == 0 {
+		return ""
+	}
+	return e.Entries[0].TaskID()
+}
+
+// AsQueued returns the QueuedError in err's chain, if any.
+func AsQueued(err error) (*QueuedError, bool) {
+	var q *QueuedError
+	if errors.As(err, &q) {
+		return q, true
+	}
+	return nil, false
+}
+
+// unreachable reports whether err means the API could not be reached: the
+// request never got an HTTP response, or the server kept failing with 5xx
+// errors. A cancelled or expired context is the caller giving up, not the
+// network.
+func unreachable(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) || api.IsServerError(err)
+}
+
+// Client is an api.TodoistAPI that queues task changes and answers reads
+// from the last synced snapshot while the API is out of reach.
+type Client struct {
+	api.TodoistAPI
+
+	// Now is the clock used to stamp queued changes. It defaults to time.Now.
+	Now func() time.Time
+
+	journal      *Journal
+	snapshotPath string
+	forced       bool
+
+	mu       sync.Mutex
+	offline  bool  // the API was found unreachable during this run
+	cause    error // the error that showed it
+	syncedAt *time.Time
+}
+
+var _ api.TodoistAPI = (*Client)(nil)
+
+// New returns a Client that sends calls to next while it can, queues
+// changes in journal, and falls back to the sync state at snapshotPath.
+// When forced is true it never contacts the API for task changes or
+// reads.
+func New(next api.TodoistAPI, journal *Journal, snapshotPath string, forced bool) *Client {
+	return &Client{TodoistAPI: next, Now: time.Now, journal: journal, snapshotPath: snapshotPath, forced: forced}
+}
+
+// Unwrap returns the client that talks to the API.
+func (c *Client) Unwrap() api.TodoistAPI {
+	return c.TodoistAPI
+}
+
+// Journal returns the journal queued changes are written to.
+func (c *Client) Journal() *Journal {
+	return c.journal
+}
+
+// Forced reports whether offline mode was asked for rather than detected.
+func (c *Client) Forced() bool {
+	return c.forced
+}
+
+// Offline reports whether the client is not contacting the API, either
+// because it was told not to or because the API was found unreachable.
+func (c *Client) Offline() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.forced || c.offline
+}
+
+// FromSnapshot reports whether any read was answered from the snapshot,
+// and when that snapshot was synced.
+func (c *Client) FromSnapshot() (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.syncedAt == nil {
+		return time.Time{}, false
+	}
+	return *c.syncedAt, true
+}
+
+// fallBack reports whether err means the API is unreachable, and if so
+// remembers it so later calls in the same run don't wait on the network.
+func (c *Client) fallBack(ctx context.Context, err error) bool {
+	if !unreachable(ctx, err) {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.offline = true
+	if c.cause == nil {
+		c.cause = err
+	}
+	return true
+}
+
+// --- task changes ---
+
+// CreateTask queues the new task under a temp ID when offline.
+func (c *Client) CreateTask(ctx context.Context, req *api.CreateTaskRequest) (*api.Task, error) {
+	var task *api.Task
+	err := c.change(ctx, []api.SyncCommand{api.AddTaskCommand(req)}, func() (err error) {
+		task, err = c.TodoistAPI.CreateTask(ctx, req)
+		return err
+	})
+	return task, err
+}
+
+// UpdateTask queues the update when offline.
+func (c *Client) UpdateTask(ctx context.Context, taskID string, req *api.UpdateTaskRequest) (*api.Task, error) {
+	var task *api.Task
+	err := c.change(ctx, []api.SyncCommand{api.UpdateTaskCommand(taskID, req)}, func() (err error) {
+		task, err = c.TodoistAPI.UpdateTask(ctx, taskID, req)
+		return err
+	})
+	return task, err
+}
+
+// MoveTask queues the move when offline.
+func (c *Client) MoveTask(ctx context.Context, taskID string, req *api.MoveTaskRequest) (*api.Task, error) {
+	var task *api.Task
+	err := c.change(ctx, []api.SyncCommand{api.MoveTaskCommand(taskID, req)}, func() (err error) {
+		task, err = c.TodoistAPI.MoveTask(ctx, taskID, req)
+		return err
+	})
+	return task, err
+}
+
+// CloseTask queues the close when offline.
+func (c *Client) CloseTask(ctx context.Context, taskID string) error {
+	return c.change(ctx, []api.SyncCommand{api.CloseTaskCommand(taskID)}, func() error {
+		return c.TodoistAPI.CloseTask(ctx, taskID)
+	})
+}
+
+// ReopenTask queues the reopen when offline.
+func (c *Client) ReopenTask(ctx context.Context, taskID string) error {
+	return c.change(ctx, []api.SyncCommand{api.ReopenTaskCommand(taskID)}, func() error {
+		return c.TodoistAPI.ReopenTask(ctx, taskID)
+	})
+}
+
+// DeleteTask queues the delete when offline.
+func (c *Client) DeleteTask(ctx context.Context, taskID string) error {
+	return c.change(ctx, []api.SyncCommand{api.DeleteTaskCommand(taskID)}, func() error {
+		return c.TodoistAPI.DeleteTask(ctx, taskID)
+	})
+}
+
+// Sync queues a batch of commands when offline. Requests without commands
+// only read, and pass straight through.
+func (c *Client) Sync(ctx context.Context, req *api.SyncRequest) (*api.SyncResponse, error) {
+	if len(req.Commands) == 0 {
+		return c.TodoistAPI.Sync(ctx, req)
+	}
+	var resp *api.SyncResponse
+	err := c.change(ctx, req.Commands, func() (err error) {
+		resp, err = c.TodoistAPI.Sync(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+// change sends a task change with send, or queues cmds when the client is
+// offline, when they refer to a task that so far only exists in the
+// journal, or when send finds the API unreachable.
+func (c *Client) change(ctx context.Context, cmds []api.SyncCommand, send func() error) error {
+	pending, err := c.pending(cmds)
+	if err != nil {
+		return err
+	}
+
+	var cause error
+	if !c.Offline() && !pending {
+		err := send()
+		if !c.fallBack(ctx, err) {
+			return err
+		}
+		cause = err
+	}
+
+	now := c.Now().UTC()
+	entries := make([]Entry, len(cmds))
+	for i, cmd := range cmds {
+		entries[i] = Entry{QueuedAt: now, Command: cmd}
+	}
+	if err := c.journal.Append(entries...); err != nil {
+		return err
+	}
+	return &QueuedError{Entries: entries, Cause: cause}
+}
+
+// pending reports whether any of cmds refers to a task added offline and
+// not yet synced. Such a change has to wait in the journal behind the add.
+func (c *Client) pending(cmds []api.SyncCommand) (bool, error) {
+	ids, err := c.tempIDs()
+	if err != nil || len(ids) == 0 {
+		return false, err
+	}
+	for _, cmd := range cmds {
+		for _, v := range cmd.Args {
+			if s, ok := v.(string); ok && ids[s] {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// tempIDs returns the temp IDs of tasks added in the journal.
+func (c *Client) tempIDs() (map[string]bool, error) {
+	entries, err := c.journal.Entries()
+	if err != nil {
+		return nil, err
+	}
+	ids := map[string]bool{}
+	for _, e := range entries {
+		if e.Command.TempID != "" {
+			ids[e.Command.TempID] = true
+		}
+	}
+	return ids, nil
+}
+
+// --- reads ---
+
+// GetTasks answers from the snapshot when offline, evaluating the filter
+// locally.
+func (c *Client) GetTasks(ctx context.Context, filter, projectID, sectionID string) ([]*api.Task, error) {
+	return read(ctx, c, func() ([]*api.Task, error) {
+		return c.TodoistAPI.GetTasks(ctx, filter, projectID, sectionID)
+	}, func(s *api.SyncState) ([]*api.Task, error) {
+		return snapshotTasks(s, filter, projectID, sectionID, c.Now())
+	})
+}
+
+// IterTasks answers from the snapshot when offline, evaluating the filter
+// locally.
+func (c *Client) IterTasks(ctx context.Context, filter, projectID, sectionID string, opts api.ListOptions) *api.Iterator[*api.Task] {
+	return iter(ctx, c, c.TodoistAPI.IterTasks(ctx, filter, projectID, sectionID, opts), opts, func(s *api.SyncState) ([]*api.Task, error) {
+		return snapshotTasks(s, filter, projectID, sectionID, c.Now())
+	})
+}
+
+// GetTask answers from the snapshot when offline, or when taskID is the
+// temp ID of a task added offline.
+func (c *Client) GetTask(ctx context.Context, taskID string) (*api.Task, error) {
+	local := func(s *api.SyncState) (*api.Task, error) {
+		t, ok := s.Items[taskID]
+		if !ok {
+			return nil, notFound(taskID)
+		}
+		return t, nil
+	}
+	ids, err := c.tempIDs()
+	if err != nil {
+		return nil, err
+	}
+	if ids[taskID] {
+		s, err := c.snapshot()
+		if err != nil {
+			return nil, err
+		}
+		return local(s)
+	}
+	return read(ctx, c, func() (*api.Task, error) {
+		return c.TodoistAPI.GetTask(ctx, taskID)
+	}, local)
+}
+
+// GetProjects answers from the snapshot when offline.
+func (c *Client) GetProjects(ctx context.Context) ([]*api.Project, error) {
+	return read(ctx, c, func() ([]*api.Project, error) {
+		return c.TodoistAPI.GetProjects(ctx)
+	}, func(s *api.SyncState) ([]*api.Project, error) {
+		return s.ProjectList(), nil
+	})
+}
+
+// IterProjects answers from the snapshot when offline.
+func (c *Client) IterProjects(ctx context.Context, opts api.ListOptions) *api.Iterator[*api.Project] {
+	return iter(ctx, c, c.TodoistAPI.IterProjects(ctx, opts), opts, func(s *api.SyncState) ([]*api.Project, error) {
+		return s.ProjectList(), nil
+	})
+}
+
+// GetSections answers from the snapshot when offline.
+func (c *Client) GetSections(ctx context.Context, projectID string) ([]*api.Section, error) {
+	return read(ctx, c, func() ([]*api.Section, error) {
+		return c.TodoistAPI.GetSections(ctx, projectID)
+	}, func(s *api.SyncState) ([]*api.Section, error) {
+		return snapshotSections(s, projectID), nil
+	})
+}
+
+// IterSections answers from the snapshot when offline.
+func (c *Client) IterSections(ctx context.Context, projectID string, opts api.ListOptions) *api.Iterator[*api.Section] {
+	return iter(ctx, c, c.TodoistAPI.IterSections(ctx, projectID, opts), opts, func(s *api.SyncState) ([]*api.Section, error) {
+		return snapshotSections(s, projectID), nil
+	})
+}
+
+// GetLabels answers from the snapshot when offline.
+func (c *Client) GetLabels(ctx context.Context) ([]*api.Label, error) {
+	return read(ctx, c, func() ([]*api.Label, error) {
+		return c.TodoistAPI.GetLabels(ctx)
+	}, func(s *api.SyncState) ([]*api.Label, error) {
+		return s.LabelList(), nil
+	})
+}
+
+// IterLabels answers from the snapshot when offline.
+func (c *Client) IterLabels(ctx context.Context, opts api.ListOptions) *api.Iterator[*api.Label] {
+	return iter(ctx, c, c.TodoistAPI.IterLabels(ctx, opts), opts, func(s *api.SyncState) ([]*api.Label, error) {
+		return s.LabelList(), nil
+	})
+}
+
+// read calls fetch unless the client is offline, and answers with local
+// from the snapshot when it is or when fetch finds the API unreachable.
+func read[T any](ctx context.Context, c *Client, fetch func() (T, error), local func(*api.SyncState) (T, error)) (T, error) {
+	if !c.Offline() {
+		v, err := fetch()
+		if !c.fallBack(ctx, err) {
+			return v, err
+		}
+	}
+	s, err := c.snapshot()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return local(s)
+}
+
+// iter is read for iterators. It pages through inner as usual, and serves
+// local from the snapshot instead if the API turns out to be unreachable
+// before the first page. Once pages have been handed out a failure is
+// reported as is, since mixing the two sources would repeat results.
+func iter[T any](ctx context.Context, c *Client, inner *api.Iterator[T], opts api.ListOptions, local func(*api.SyncState) ([]T, error)) *api.Iterator[T] {
+	const more = "more" // any non-empty cursor keeps the outer iterator going
+
+	fetch := func(ctx context.Context, cursor string, limit int) ([]T, string, error) {
+		if cursor == "" && c.Offline() {
+			return snapshotPage(c, local)
+		}
+		if inner.Next() {
+			return inner.Page(), more, nil
+		}
+		err := inner.Err()
+		if cursor == "" && c.fallBack(ctx, err) {
+			return snapshotPage(c, local)
+		}
+		return nil, "", err
+	}
+	return api.NewIterator(ctx, fetch, opts)
+}
+
+// snapshotPage answers a whole listing from the snapshot as a single page.
+func snapshotPage[T any](c *Client, local func(*api.SyncState) ([]T, error)) ([]T, string, error) {
+	s, err := c.snapshot()
+	if err != nil {
+		return nil, "", err
+	}
+	items, err := local(s)
+	return items, "", err
+}
@@ -0,0 +1,351 @@
+package offline
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/api/apitest"
+	"github.com/joeyhipolito/todoist-cli/internal/api/fakeserver"
+)
+
+var testNow = time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+// unreachableAPI returns a client whose requests fail to connect.
+func unreachableAPI(t *testing.T) *api.Client {
+	t.Helper()
+	srv := httptest.NewServer(nil)
+	srv.Close()
+	c, err := api.NewClient("token", api.WithBaseURL(srv.URL), api.WithRetryPolicy(api.RetryPolicy{MaxRetries: 0}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// newTestClient returns a Client over next with a snapshot holding an
+// inbox and the given tasks.
+func newTestClient(t *testing.T, next api.TodoistAPI, forced bool, tasks ...*api.Task) *Client {
+	t.Helper()
+	dir := t.TempDir()
+	state := &api.SyncState{
+		SyncToken: "token-1",
+		SyncedAt:  testNow.Add(-time.Hour),
+		Items:     map[string]*api.Task{},
+		Projects:  map[string]*api.Project{"inbox": {ID: "inbox", Name: "Inbox", IsInboxProject: true}},
+		Sections:  map[string]*api.Section{},
+		Labels:    map[string]*api.Label{},
+	}
+	for _, task := range tasks {
+		state.Items[task.ID] = task
+	}
+	path := filepath.Join(dir, "sync.json")
+	if err := state.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	c := New(next, OpenJournal(filepath.Join(dir, "journal.jsonl")), path, forced)
+	c.Now = func() time.Time { return testNow }
+	return c
+}
+
+func TestClient_queuesWhenUnreachable(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t, unreachableAPI(t), false,
+		&api.Task{ID: "t1", ProjectID: "inbox", Content: "Write report", Order: 1},
+		&api.Task{ID: "t2", ProjectID: "inbox", Content: "Call mom", Order: 2},
+	)
+
+	_, err := c.CreateTask(ctx, &api.CreateTaskRequest{Content: "Buy milk", DueString: "tomorrow"})
+	q, ok := AsQueued(err)
+	if !ok {
+		t.Fatalf("CreateTask() error = %v, want queued", err)
+	}
+	if q.Cause == nil {
+		t.Error("QueuedError.Cause = nil, want the connection error")
+	}
+	if !c.Offline() {
+		t.Error("Offline() = false after a connection failure")
+	}
+	tempID := q.TaskID()
+
+	// Later changes are queued without trying the network again.
+	if err := c.CloseTask(ctx, "t2"); !isQueued(err) {
+		t.Fatalf("CloseTask() error = %v, want queued", err)
+	}
+	content := "Buy oat milk"
+	if _, err := c.UpdateTask(ctx, tempID, &api.UpdateTaskRequest{Content: &content}); !isQueued(err) {
+		t.Fatalf("UpdateTask() error = %v, want queued", err)
+	}
+
+	entries, err := c.Journal().Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.String())
+	}
+	want := []string{`add "Buy milk"`, "close t2", "edit " + tempID}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("journal = %q, want %q", got, want)
+	}
+
+	// Reads come from the snapshot with the queued changes applied.
+	tasks, err := c.GetTasks(ctx, "", "", "")
+	if err != nil {
+		t.Fatalf("GetTasks() error = %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].ID != "t1" || tasks[1].ID != tempID {
+		t.Fatalf("GetTasks() = %+v, want t1 and the queued task", tasks)
+	}
+	if added := tasks[1]; added.Content != "Buy oat milk" || added.ProjectID != "inbox" || added.Due == nil || added.Due.Date != "2026-03-11" {
+		t.Errorf("queued task = %+v, want the edit, inbox and tomorrow's date", added)
+	}
+	if syncedAt, ok := c.FromSnapshot(); !ok || !syncedAt.Equal(testNow.Add(-time.Hour)) {
+		t.Errorf("FromSnapshot() = %v, %v", syncedAt, ok)
+	}
+
+	if _, err := c.GetTask(ctx, "t2"); !api.IsNotFoundError(err) {
+		t.Errorf("GetTask(closed) error = %v, want not found", err)
+	}
+
+	// Filters are evaluated locally, as far as they can be.
+	tasks, err = c.GetTasks(ctx, "!no date & #inbox", "", "")
+	if err != nil || len(tasks) != 1 || tasks[0].ID != tempID {
+		t.Errorf("GetTasks(filter) = %+v, %v, want the queued task", tasks, err)
+	}
+	if _, err := c.GetTasks(ctx, "due before: next monday", "", ""); err == nil || !strings.Contains(err.Error(), "cannot filter offline") {
+		t.Errorf("GetTasks(unsupported filter) error = %v", err)
+	}
+}
+
+func TestClient_iterFallsBack(t *testing.T) {
+	c := newTestClient(t, unreachableAPI(t), false, &api.Task{ID: "t1", ProjectID: "inbox", Content: "Write report"})
+
+	tasks, err := c.IterTasks(context.Background(), "", "inbox", "", api.ListOptions{}).All()
+	if err != nil {
+		t.Fatalf("IterTasks() error = %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != "t1" {
+		t.Errorf("IterTasks() = %+v, want the snapshot task", tasks)
+	}
+	if _, ok := c.FromSnapshot(); !ok {
+		t.Error("FromSnapshot() = false")
+	}
+}
+
+func TestClient_assigneeFilters(t *testing.T) {
+	ctx := context.Background()
+	f := apitest.New()
+	p := f.AddProject(api.Project{Name: "Team"})
+	ana := f.AddCollaborator(p.ID, api.Collaborator{Name: "Ana Lima"})
+	f.AddTask(api.Task{ID: "mine", ProjectID: p.ID, Content: "Mine", AssigneeID: f.User.ID})
+	f.AddTask(api.Task{ID: "ana", ProjectID: p.ID, Content: "Ana's", AssigneeID: ana.ID})
+	f.AddTask(api.Task{ID: "open", ProjectID: p.ID, Content: "Nobody's"})
+
+	var state api.SyncState
+	if _, err := state.Sync(ctx, f); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sync.json")
+	if err := state.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	c := New(unreachableAPI(t), OpenJournal(filepath.Join(dir, "journal.jsonl")), path, false)
+	c.Now = func() time.Time { return testNow }
+
+	for query, want := range map[string]string{
+		"assigned to: me":       "mine",
+		"assigned to: others":   "ana",
+		"assigned to: Ana Lima": "ana",
+	} {
+		tasks, err := c.GetTasks(ctx, query, "", "")
+		if err != nil {
+			t.Fatalf("GetTasks(%q) error = %v", query, err)
+		}
+		if len(tasks) != 1 || tasks[0].ID != want {
+			t.Errorf("GetTasks(%q) = %+v, want [%s]", query, tasks, want)
+		}
+	}
+}
+
+func TestClient_online(t *testing.T) {
+	ctx := context.Background()
+	f := apitest.New()
+	c := newTestClient(t, f, false)
+
+	task, err := c.CreateTask(ctx, &api.CreateTaskRequest{Content: "Buy milk"})
+	if err != nil {
+		t.Fatalf("CreateTask() error = %v", err)
+	}
+	if _, ok := f.Task(task.ID); !ok {
+		t.Error("task was not created upstream")
+	}
+
+	// API errors are reported, not queued.
+	if err := c.CloseTask(ctx, "missing"); !api.IsNotFoundError(err) {
+		t.Errorf("CloseTask(missing) error = %v, want not found", err)
+	}
+	if entries, _ := c.Journal().Entries(); len(entries) != 0 {
+		t.Errorf("journal has %d entries, want none", len(entries))
+	}
+	if c.Offline() {
+		t.Error("Offline() = true")
+	}
+	if _, ok := c.FromSnapshot(); ok {
+		t.Error("FromSnapshot() = true for API reads")
+	}
+}
+
+func TestClient_forced(t *testing.T) {
+	ctx := context.Background()
+	f := apitest.New()
+	c := newTestClient(t, f, true)
+
+	_, err := c.CreateTask(ctx, &api.CreateTaskRequest{Content: "Buy milk"})
+	q, ok := AsQueued(err)
+	if !ok || q.Cause != nil {
+		t.Fatalf("CreateTask() error = %v, want queued without a cause", err)
+	}
+	if _, err := c.GetProjects(ctx); err != nil {
+		t.Fatalf("GetProjects() error = %v", err)
+	}
+	if calls := f.Calls(); len(calls) != 0 {
+		t.Errorf("API calls = %q, want none", calls)
+	}
+
+	// Back online, changes to the queued task wait behind its add.
+	online := New(f, c.Journal(), c.snapshotPath, false)
+	if err := online.CloseTask(ctx, q.TaskID()); !isQueued(err) {
+		t.Errorf("CloseTask(temp ID) error = %v, want queued", err)
+	}
+	if calls := f.Calls(); len(calls) != 0 {
+		t.Errorf("API calls = %q, want none", calls)
+	}
+}
+
+func TestClient_noSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	c := New(unreachableAPI(t), OpenJournal(filepath.Join(dir, "journal.jsonl")), filepath.Join(dir, "sync.json"), false)
+
+	_, err := c.GetProjects(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "todoist sync") {
+		t.Errorf("GetProjects() error = %v, want a hint to sync", err)
+	}
+}
+
+func TestJournal_Replay(t *testing.T) {
+	ctx := context.Background()
+	f := apitest.New()
+	keep := f.AddTask(api.Task{Content: "Write report"})
+
+	j := OpenJournal(filepath.Join(t.TempDir(), "journal.jsonl"))
+	add := api.AddTaskCommand(&api.CreateTaskRequest{Content: "Buy milk"})
+	content := "Buy oat milk"
+	entries := []Entry{
+		{Command: add},
+		{Command: api.UpdateTaskCommand(add.TempID, &api.UpdateTaskRequest{Content: &content})},
+		{Command: api.CloseTaskCommand(keep.ID)},
+		{Command: api.CloseTaskCommand("deleted-upstream")},
+		{Command: api.DeleteTaskCommand("also-gone")},
+	}
+	if err := j.Append(entries...); err != nil {
+		t.Fatal(err)
+	}
+
+	outcomes, err := j.Replay(ctx, f)
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	var statuses []string
+	for _, o := range outcomes {
+		statuses = append(statuses, o.Status)
+	}
+	want := []string{StatusSynced, StatusSynced, StatusSynced, StatusConflict, StatusSynced}
+	if strings.Join(statuses, " ") != strings.Join(want, " ") {
+		t.Errorf("statuses = %q, want %q", statuses, want)
+	}
+	if err := outcomes[3].Err; err == nil || !strings.Contains(err.Error(), "deleted upstream") {
+		t.Errorf("conflict error = %v", err)
+	}
+
+	added, ok := f.Task(outcomes[0].TaskID)
+	if !ok || added.Content != "Buy oat milk" {
+		t.Errorf("added task = %+v, %v, want the edited content under its real ID", added, ok)
+	}
+	if _, err := os.Stat(j.Path()); !os.IsNotExist(err) {
+		t.Errorf("journal still exists after a full replay: %v", err)
+	}
+}
+
+func TestJournal_ReplaySendsTaskFields(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t, unreachableAPI(t), false)
+
+	_, err := c.CreateTask(ctx, &api.CreateTaskRequest{Content: "Write report", DueDate: "2026-03-12",
+		DeadlineDate: "2026-03-13", AssigneeID: "u1"})
+	q, ok := AsQueued(err)
+	if !ok {
+		t.Fatalf("CreateTask() error = %v, want queued", err)
+	}
+	amount, unit, deadline := 90, "minute", "2026-03-20"
+	_, err = c.UpdateTask(ctx, q.TaskID(), &api.UpdateTaskRequest{Duration: &amount, DurationUnit: &unit, DeadlineDate: &deadline})
+	if !isQueued(err) {
+		t.Fatalf("UpdateTask() error = %v, want queued", err)
+	}
+
+	// Replay over HTTP, with the journal read back from disk.
+	srv := fakeserver.New(t)
+	outcomes, err := OpenJournal(c.Journal().Path()).Replay(ctx, srv.Client(t))
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	for _, o := range outcomes {
+		if o.Status != StatusSynced {
+			t.Fatalf("%s: %s %v", o.Entry, o.Status, o.Err)
+		}
+	}
+
+	task, ok := srv.Fake.Task(outcomes[0].TaskID)
+	if !ok {
+		t.Fatalf("task %s not on the server", outcomes[0].TaskID)
+	}
+	if task.Due == nil || task.Due.Date != "2026-03-12" {
+		t.Errorf("due = %+v, want 2026-03-12", task.Due)
+	}
+	if task.Deadline == nil || task.Deadline.Date != "2026-03-20" {
+		t.Errorf("deadline = %+v, want the edited 2026-03-20", task.Deadline)
+	}
+	if task.Duration == nil || *task.Duration != (api.Duration{Amount: 90, Unit: "minute"}) {
+		t.Errorf("duration = %+v, want 90 minutes", task.Duration)
+	}
+	if task.AssigneeID != "u1" {
+		t.Errorf("assignee = %q, want u1", task.AssigneeID)
+	}
+}
+
+func TestJournal_ReplayKeepsUnsent(t *testing.T) {
+	j := OpenJournal(filepath.Join(t.TempDir(), "journal.jsonl"))
+	if err := j.Append(Entry{Command: api.CloseTaskCommand("t1")}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := j.Replay(context.Background(), unreachableAPI(t)); err == nil {
+		t.Fatal("Replay() error = nil, want the connection error")
+	}
+	entries, err := j.Entries()
+	if err != nil || len(entries) != 1 {
+		t.Errorf("journal = %d entries, %v, want the change kept", len(entries), err)
+	}
+}
+
+func isQueued(err error) bool {
+	_, ok := AsQueued(err)
+	return ok
+}
@@ -0,0 +1,287 @@
+package offline
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+	"github.com/joeyhipolito/todoist-cli/internal/filter"
+)
+
+// snapshot loads the last synced state with the queued changes applied on
+// top, and notes that the current command is being answered from it.
+func (c *Client) snapshot() (*api.SyncState, error) {
+	s, err := api.LoadSyncState(c.snapshotPath)
+	if err != nil {
+		return nil, err
+	}
+	if s.SyncToken == "" {
+		c.mu.Lock()
+		cause := c.cause
+		c.mu.Unlock()
+		if cause != nil {
+			return nil, fmt.Errorf("no offline snapshot yet, run 'todoist sync' once online: %w", cause)
+		}
+		return nil, fmt.Errorf("no offline snapshot yet, run 'todoist sync' once online")
+	}
+
+	entries, err := c.journal.Entries()
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		apply(s, e)
+	}
+
+	c.mu.Lock()
+	c.syncedAt = &s.SyncedAt
+	c.mu.Unlock()
+	return s, nil
+}
+
+// apply makes the change in e to the snapshot, as well as it can be
+// predicted locally. Reopens are skipped: the snapshot has no record of
+// completed tasks to bring back.
+func apply(s *api.SyncState, e Entry) {
+	cmd := e.Command
+	id := e.TaskID()
+
+	switch cmd.Type {
+	case api.CommandItemAdd:
+		ta, err := api.ParseTaskArgs(cmd.Args)
+		if err != nil {
+			return
+		}
+		t := &api.Task{
+			ID:        id,
+			ProjectID: inboxID(s),
+			Labels:    []string{},
+			Priority:  1,
+			Order:     ta.Order,
+			CreatedAt: e.QueuedAt.Format(time.RFC3339),
+		}
+		update(t, &ta.Update, e.QueuedAt)
+		place(s, t, ta.ProjectID, ta.SectionID, ta.ParentID)
+		if t.Order == 0 {
+			t.Order = lastOrder(s, t.ProjectID) + 1
+		}
+		s.Items[id] = t
+
+	case api.CommandItemUpdate:
+		t, ok := s.Items[id]
+		if !ok {
+			return
+		}
+		ta, err := api.ParseTaskArgs(cmd.Args)
+		if err != nil {
+			return
+		}
+		update(t, &ta.Update, e.QueuedAt)
+
+	case api.CommandItemMove:
+		t, ok := s.Items[id]
+		if !ok {
+			return
+		}
+		var req api.MoveTaskRequest
+		if decodeArgs(cmd.Args, &req) != nil {
+			return
+		}
+		place(s, t, req.ProjectID, req.SectionID, req.ParentID)
+
+	case api.CommandItemClose:
+		delete(s.Items, id)
+
+	case api.CommandItemDelete:
+		// Deleting a task deletes its subtasks too.
+		gone := map[string]bool{id: true}
+		for changed := true; changed; {
+			changed = false
+			for _, t := range s.Items {
+				if !gone[t.ID] && gone[t.ParentID] {
+					gone[t.ID], changed = true, true
+				}
+			}
+		}
+		for id := range gone {
+			delete(s.Items, id)
+		}
+	}
+}
+
+// update sets the fields of t that req sets. Empty strings and a zero
+// duration clear their field, as null does in a sync command.
+func update(t *api.Task, req *api.UpdateTaskRequest, queuedAt time.Time) {
+	if req.Content != nil {
+		t.Content = *req.Content
+	}
+	if req.Description != nil {
+		t.Description = *req.Description
+	}
+	if req.Labels != nil {
+		t.Labels = append([]string{}, (*req.Labels)...)
+	}
+	if req.Priority != nil {
+		t.Priority = max(*req.Priority, 1)
+	}
+	if req.DueString != nil || req.DueDate != nil || req.DueDatetime != nil {
+		t.Due = predictDue(deref(req.DueString), deref(req.DueDate), deref(req.DueDatetime), deref(req.DueLang), queuedAt)
+	}
+	if req.AssigneeID != nil {
+		t.AssigneeID = *req.AssigneeID
+	}
+	if req.DeadlineDate != nil {
+		t.Deadline = nil
+		if *req.DeadlineDate != "" {
+			t.Deadline = &api.Deadline{Date: *req.DeadlineDate}
+		}
+	}
+	if req.Duration != nil {
+		t.Duration = nil
+		if *req.Duration > 0 {
+			t.Duration = &api.Duration{Amount: *req.Duration, Unit: deref(req.DurationUnit)}
+		}
+	}
+}
+
+// place puts t under the given parent, section or project, the same way
+// the API does: a parent or section implies its project.
+func place(s *api.SyncState, t *api.Task, projectID, sectionID, parentID string) {
+	switch {
+	case parentID != "":
+		t.ParentID = parentID
+		if p, ok := s.Items[parentID]; ok {
+			t.ProjectID, t.SectionID = p.ProjectID, p.SectionID
+		}
+	case sectionID != "":
+		t.SectionID, t.ParentID = sectionID, ""
+		if sec, ok := s.Sections[sectionID]; ok {
+			t.ProjectID = sec.ProjectID
+		}
+	case projectID != "":
+		t.ProjectID, t.SectionID, t.ParentID = projectID, "", ""
+	}
+}
+
+// predictDue works out the due date the API will give a task where that
+// can be done locally: explicit dates, "today", "tomorrow" and "no date".
+// Any other due string is kept as written until the next sync resolves it.
+func predictDue(str, date, datetime, lang string, queuedAt time.Time) *api.Due {
+	switch {
+	case datetime != "":
+		due := &api.Due{String: datetime, Datetime: datetime, Lang: lang}
+		if t, err := time.Parse(time.RFC3339, datetime); err == nil {
+			due.Date = t.Format("2006-01-02")
+		}
+		return due
+	case date != "":
+		return &api.Due{String: date, Date: date, Lang: lang}
+	case str != "":
+		due := &api.Due{String: str, Lang: lang}
+		day := queuedAt.Local()
+		switch strings.ToLower(strings.TrimSpace(str)) {
+		case "no date", "no due date":
+			return nil
+		case "today":
+			due.Date = day.Format("2006-01-02")
+		case "tomorrow":
+			due.Date = day.AddDate(0, 0, 1).Format("2006-01-02")
+		default:
+			if _, err := time.Parse("2006-01-02", str); err == nil {
+				due.Date = str
+			}
+		}
+		return due
+	}
+	return nil
+}
+
+// snapshotTasks returns the snapshot's tasks in project and child order,
+// narrowed to a project or section and to those matching query. Query
+// terms only the API understands, like "next monday", are an error.
+func snapshotTasks(s *api.SyncState, query, projectID, sectionID string, now time.Time) ([]*api.Task, error) {
+	var f *filter.Filter
+	if query != "" {
+		var err error
+		if f, err = filter.Parse(query); err != nil {
+			return nil, err
+		}
+		if err := f.Local(); err != nil {
+			return nil, fmt.Errorf("cannot filter offline: %w", err)
+		}
+	}
+	env := &filter.Env{
+		Now:           now,
+		Projects:      s.ProjectList(),
+		Sections:      s.SectionList(),
+		Collaborators: s.CollaboratorList(),
+		UserID:        s.UserID(),
+	}
+
+	tasks := make([]*api.Task, 0, len(s.Items))
+	for _, t := range s.Tasks() {
+		if (projectID != "" && t.ProjectID != projectID) || (sectionID != "" && t.SectionID != sectionID) {
+			continue
+		}
+		if f == nil || f.Match(t, env) {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks, nil
+}
+
+// snapshotSections returns the snapshot's sections, narrowed to a project.
+func snapshotSections(s *api.SyncState, projectID string) []*api.Section {
+	sections := make([]*api.Section, 0, len(s.Sections))
+	for _, sec := range s.SectionList() {
+		if projectID == "" || sec.ProjectID == projectID {
+			sections = append(sections, sec)
+		}
+	}
+	return sections
+}
+
+// inboxID returns the ID of the inbox project, where tasks go by default.
+func inboxID(s *api.SyncState) string {
+	for _, p := range s.Projects {
+		if p.IsInboxProject {
+			return p.ID
+		}
+	}
+	return ""
+}
+
+// lastOrder returns the highest child order among the project's tasks.
+func lastOrder(s *api.SyncState, projectID string) int {
+	order := 0
+	for _, t := range s.Items {
+		if t.ProjectID == projectID {
+			order = max(order, t.Order)
+		}
+	}
+	return order
+}
+
+// decodeArgs converts command args back to the request they came from.
+func decodeArgs(args map[string]any, v any) error {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// notFound is the error returned for tasks missing from the snapshot.
+func notFound(taskID string) error {
+	return &api.TodoistError{StatusCode: http.StatusNotFound, Message: "task " + taskID + " not found in offline snapshot"}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
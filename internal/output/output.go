@@ -0,0 +1,503 @@
+// Package output renders command results in the format chosen with the
+// global --format flag.
+//
+// Structured formats (json, jsonl, yaml) encode results in full, field for
+// field as the API returns them. Tabular formats (table, csv, tsv,
+// markdown) show the columns a command picks for its lists, or every
+// top-level field of a single result. The text format is each command's
+// own human-readable output, so commands handle it themselves and only
+// call into this package for the others. A template format renders each
+// result with a text/template instead.
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// Format is an output format: one of the named formats below, or a
+// template made with TemplateFormat. Formats are comparable with ==.
+type Format struct {
+	name string
+	tmpl *template.Template
+}
+
+// Output formats.
+var (
+	Text     = Format{name: "text"}
+	Table    = Format{name: "table"}
+	CSV      = Format{name: "csv"}
+	TSV      = Format{name: "tsv"}
+	YAML     = Format{name: "yaml"}
+	JSONL    = Format{name: "jsonl"}
+	Markdown = Format{name: "markdown"}
+	JSON     = Format{name: "json"}
+)
+
+// Formats lists every format, in the order help text shows them.
+var Formats = []Format{Text, Table, CSV, TSV, YAML, JSONL, Markdown, JSON}
+
+// ParseFormat returns the format named s, case-insensitively. "md" is
+// accepted for markdown.
+func ParseFormat(s string) (Format, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	if name == "md" {
+		return Markdown, nil
+	}
+	for _, f := range Formats {
+		if f.name == name {
+			return f, nil
+		}
+	}
+	names := make([]string, len(Formats))
+	for i, f := range Formats {
+		names[i] = f.name
+	}
+	return Format{}, fmt.Errorf("unknown format %q (want %s)", s, strings.Join(names, ", "))
+}
+
+// TemplateFormat returns a format that executes t once per result, each
+// followed by a newline unless the output already ends with one.
+func TemplateFormat(t *template.Template) Format {
+	return Format{name: "template", tmpl: t}
+}
+
+// String returns the format's name.
+func (f Format) String() string {
+	return f.name
+}
+
+// tabular reports whether f renders rows and columns.
+func (f Format) tabular() bool {
+	return f == Table || f == CSV || f == TSV || f == Markdown
+}
+
+// Column is one column of tabular output.
+type Column[T any] struct {
+	Header string
+	Value  func(T) string
+}
+
+// Pager is a source of results fetched a page at a time, such as
+// *api.Iterator.
+type Pager[T any] interface {
+	Next() bool
+	Page() []T
+	Err() error
+}
+
+// Pages writes every result from p. JSON, JSONL, YAML, CSV, TSV and
+// Markdown are written as pages arrive; a table needs every row to size
+// its columns. If p fails part way, what was written so far is left well
+// formed where the format allows it.
+func Pages[T any](w io.Writer, format Format, p Pager[T], cols []Column[T]) error {
+	var enc encoder[T]
+	switch format {
+	case JSON:
+		enc = &jsonArray[T]{w: w}
+	case JSONL:
+		enc = &jsonLines[T]{w: w}
+	case YAML:
+		enc = &yamlList[T]{w: w}
+	case CSV, TSV, Markdown:
+		enc = newRowWriter(w, format, cols)
+	case Table:
+		enc = &tableWriter[T]{w: w, cols: cols}
+	default:
+		if format.tmpl != nil {
+			enc = &templateWriter[T]{w: w, tmpl: format.tmpl}
+			break
+		}
+		return fmt.Errorf("format %q is not supported here", format)
+	}
+
+	count := 0
+	for p.Next() {
+		for _, item := range p.Page() {
+			if err := enc.item(item); err != nil {
+				return err
+			}
+			count++
+		}
+	}
+	if err := p.Err(); err != nil {
+		if count > 0 {
+			enc.close() // so partial JSON is still valid
+		}
+		return err
+	}
+	return enc.close()
+}
+
+// List writes items like Pages.
+func List[T any](w io.Writer, format Format, items []T, cols []Column[T]) error {
+	return Pages(w, format, &slicePager[T]{items: items}, cols)
+}
+
+// Value writes a single result: an object, a list, or a scalar. Tabular
+// formats show one row per element of a list (one row for an object) with
+// a column for each top-level field.
+func Value(w io.Writer, format Format, v any) error {
+	switch format {
+	case JSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	case JSONL:
+		return writeJSONLines(w, v)
+	case YAML:
+		return writeYAML(w, v)
+	}
+	if format.tmpl != nil {
+		return writeTemplate(w, format.tmpl, v)
+	}
+	if !format.tabular() {
+		return fmt.Errorf("format %q is not supported here", format)
+	}
+
+	header, rows, err := fields(v)
+	if err != nil {
+		return err
+	}
+	cols := make([]Column[[]string], len(header))
+	for i, h := range header {
+		cols[i] = Column[[]string]{Header: h, Value: func(row []string) string { return row[i] }}
+	}
+	return List(w, format, rows, cols)
+}
+
+// --- encoders ---
+
+type encoder[T any] interface {
+	item(v T) error
+	close() error
+}
+
+type slicePager[T any] struct {
+	items []T
+	done  bool
+}
+
+func (p *slicePager[T]) Next() bool {
+	if p.done {
+		return false
+	}
+	p.done = true
+	return true
+}
+
+func (p *slicePager[T]) Page() []T  { return p.items }
+func (p *slicePager[T]) Err() error { return nil }
+
+// jsonArray writes an indented JSON array one element at a time. The
+// output is identical to encoding the whole slice with json.Encoder and
+// SetIndent("", "  ").
+type jsonArray[T any] struct {
+	w     io.Writer
+	count int
+}
+
+func (e *jsonArray[T]) item(v T) error {
+	data, err := json.MarshalIndent(v, "  ", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	sep := ",\n  "
+	if e.count == 0 {
+		sep = "[\n  "
+	}
+	e.count++
+	_, err = fmt.Fprintf(e.w, "%s%s", sep, data)
+	return err
+}
+
+func (e *jsonArray[T]) close() error {
+	if e.count == 0 {
+		_, err := fmt.Fprint(e.w, "[]\n")
+		return err
+	}
+	_, err := fmt.Fprint(e.w, "\n]\n")
+	return err
+}
+
+// jsonLines writes one compact JSON document per line.
+type jsonLines[T any] struct {
+	w io.Writer
+}
+
+func (e *jsonLines[T]) item(v T) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	_, err = fmt.Fprintf(e.w, "%s\n", data)
+	return err
+}
+
+func (e *jsonLines[T]) close() error { return nil }
+
+// writeJSONLines writes each element of a list on its own line, or any
+// other value as a single line.
+func writeJSONLines(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	var list []json.RawMessage
+	if json.Unmarshal(data, &list) != nil {
+		_, err := fmt.Fprintf(w, "%s\n", data)
+		return err
+	}
+	enc := &jsonLines[json.RawMessage]{w: w}
+	for _, item := range list {
+		if err := enc.item(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// yamlList writes a YAML sequence one element at a time.
+type yamlList[T any] struct {
+	w     io.Writer
+	count int
+}
+
+func (e *yamlList[T]) item(v T) error {
+	e.count++
+	return writeYAML(e.w, []T{v})
+}
+
+func (e *yamlList[T]) close() error {
+	if e.count == 0 {
+		_, err := fmt.Fprint(e.w, "[]\n")
+		return err
+	}
+	return nil
+}
+
+// rowWriter streams CSV, TSV or Markdown rows, starting with the header.
+type rowWriter[T any] struct {
+	w      io.Writer
+	format Format
+	cols   []Column[T]
+	csv    *csv.Writer
+	header bool
+}
+
+func newRowWriter[T any](w io.Writer, format Format, cols []Column[T]) *rowWriter[T] {
+	rw := &rowWriter[T]{w: w, format: format, cols: cols}
+	if format != Markdown {
+		rw.csv = csv.NewWriter(w)
+		if format == TSV {
+			rw.csv.Comma = '\t'
+		}
+	}
+	return rw
+}
+
+func (rw *rowWriter[T]) writeRow(cells []string) error {
+	if rw.csv != nil {
+		if err := rw.csv.Write(cells); err != nil {
+			return fmt.Errorf("failed to write %s: %w", rw.format, err)
+		}
+		rw.csv.Flush()
+		return rw.csv.Error()
+	}
+	escaped := make([]string, len(cells))
+	for i, c := range cells {
+		escaped[i] = strings.NewReplacer("|", `\|`, "\n", " ").Replace(c)
+	}
+	_, err := fmt.Fprintf(rw.w, "| %s |\n", strings.Join(escaped, " | "))
+	return err
+}
+
+func (rw *rowWriter[T]) writeHeader() error {
+	if rw.header {
+		return nil
+	}
+	rw.header = true
+	headers := make([]string, len(rw.cols))
+	for i, c := range rw.cols {
+		headers[i] = c.Header
+	}
+	if err := rw.writeRow(headers); err != nil {
+		return err
+	}
+	if rw.format == Markdown {
+		rule := make([]string, len(headers))
+		for i := range rule {
+			rule[i] = "---"
+		}
+		return rw.writeRow(rule)
+	}
+	return nil
+}
+
+func (rw *rowWriter[T]) item(v T) error {
+	if err := rw.writeHeader(); err != nil {
+		return err
+	}
+	cells := make([]string, len(rw.cols))
+	for i, c := range rw.cols {
+		cells[i] = c.Value(v)
+	}
+	return rw.writeRow(cells)
+}
+
+func (rw *rowWriter[T]) close() error {
+	return rw.writeHeader()
+}
+
+// tableWriter collects rows and writes them as an aligned table.
+type tableWriter[T any] struct {
+	w    io.Writer
+	cols []Column[T]
+	rows [][]string
+}
+
+func (t *tableWriter[T]) item(v T) error {
+	row := make([]string, len(t.cols))
+	for i, c := range t.cols {
+		row[i] = c.Value(v)
+	}
+	t.rows = append(t.rows, row)
+	return nil
+}
+
+func (t *tableWriter[T]) close() error {
+	headers := make([]string, len(t.cols))
+	for i, c := range t.cols {
+		headers[i] = c.Header
+	}
+	return writeTable(t.w, headers, t.rows, Width(t.w))
+}
+
+// templateWriter executes a template for each result.
+type templateWriter[T any] struct {
+	w    io.Writer
+	tmpl *template.Template
+}
+
+func (e *templateWriter[T]) item(v T) error {
+	return execute(e.w, e.tmpl, v)
+}
+
+func (e *templateWriter[T]) close() error { return nil }
+
+// writeTemplate executes tmpl for each element of a list, or once for any
+// other value.
+func writeTemplate(w io.Writer, tmpl *template.Template, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return execute(w, tmpl, v)
+	}
+	for i := 0; i < rv.Len(); i++ {
+		if err := execute(w, tmpl, rv.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func execute(w io.Writer, tmpl *template.Template, v any) error {
+	var b strings.Builder
+	if err := tmpl.Execute(&b, v); err != nil {
+		return fmt.Errorf("failed to render template: %w", err)
+	}
+	s := b.String()
+	if !strings.HasSuffix(s, "\n") {
+		s += "\n"
+	}
+	_, err := io.WriteString(w, s)
+	return err
+}
+
+// --- generic fields ---
+
+// fields flattens v into a header and rows: one row per element of a
+// list, one row for anything else. Objects contribute a column per key,
+// in the order keys first appear; nested values are shown as compact JSON.
+func fields(v any) ([]string, [][]string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode value: %w", err)
+	}
+
+	var elems []json.RawMessage
+	if json.Unmarshal(data, &elems) != nil {
+		elems = []json.RawMessage{data}
+	}
+
+	var header []string
+	index := map[string]int{}
+	var objects [][]kv
+	for _, elem := range elems {
+		obj, ok := objectFields(elem)
+		if !ok {
+			obj = []kv{{key: "value", value: elem}}
+		}
+		for _, f := range obj {
+			if _, seen := index[f.key]; !seen {
+				index[f.key] = len(header)
+				header = append(header, f.key)
+			}
+		}
+		objects = append(objects, obj)
+	}
+
+	rows := make([][]string, len(objects))
+	for i, obj := range objects {
+		row := make([]string, len(header))
+		for _, f := range obj {
+			row[index[f.key]] = cell(f.value)
+		}
+		rows[i] = row
+	}
+	return header, rows, nil
+}
+
+type kv struct {
+	key   string
+	value json.RawMessage
+}
+
+// objectFields returns the fields of a JSON object in document order.
+func objectFields(data json.RawMessage) ([]kv, bool) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, false
+	}
+	var fields []kv
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, false
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, false
+		}
+		fields = append(fields, kv{key: tok.(string), value: value})
+	}
+	return fields, true
+}
+
+// cell renders a JSON value for a table cell: strings without quotes,
+// null as empty, and anything else as compact JSON.
+func cell(value json.RawMessage) string {
+	var s string
+	if json.Unmarshal(value, &s) == nil {
+		return s
+	}
+	if string(value) == "null" {
+		return ""
+	}
+	return string(value)
+}
@@ -0,0 +1,169 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+type item struct {
+	ID   string   `json:"id"`
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+	Note *string  `json:"note,omitempty"`
+}
+
+var itemColumns = []Column[item]{
+	{Header: "ID", Value: func(i item) string { return i.ID }},
+	{Header: "NAME", Value: func(i item) string { return i.Name }},
+}
+
+var items = []item{
+	{ID: "1", Name: "Buy milk", Tags: []string{"errands"}},
+	{ID: "22", Name: `Say "hi", then | leave`},
+}
+
+func TestParseFormat(t *testing.T) {
+	for in, want := range map[string]Format{"table": Table, "CSV": CSV, " md ": Markdown, "jsonl": JSONL} {
+		if got, err := ParseFormat(in); err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseFormat("xml"); err == nil || !strings.Contains(err.Error(), "table, csv") {
+		t.Errorf("ParseFormat(xml) error = %v", err)
+	}
+}
+
+func TestList(t *testing.T) {
+	t.Setenv("COLUMNS", "")
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{Table, "ID  NAME\n1   Buy milk\n22  Say \"hi\", then | leave\n"},
+		{CSV, "ID,NAME\n1,Buy milk\n22,\"Say \"\"hi\"\", then | leave\"\n"},
+		{TSV, "ID\tNAME\n1\tBuy milk\n22\t\"Say \"\"hi\"\", then | leave\"\n"},
+		{Markdown, "| ID | NAME |\n| --- | --- |\n| 1 | Buy milk |\n| 22 | Say \"hi\", then \\| leave |\n"},
+		{JSONL, "{\"id\":\"1\",\"name\":\"Buy milk\",\"tags\":[\"errands\"]}\n{\"id\":\"22\",\"name\":\"Say \\\"hi\\\", then | leave\",\"tags\":null}\n"},
+		{YAML, "- id: \"1\"\n  name: Buy milk\n  tags:\n  - errands\n- id: \"22\"\n  name: Say \"hi\", then | leave\n  tags: null\n"},
+		{JSON, "[\n  {\n    \"id\": \"1\",\n    \"name\": \"Buy milk\",\n    \"tags\": [\n      \"errands\"\n    ]\n  },\n  {\n    \"id\": \"22\",\n    \"name\": \"Say \\\"hi\\\", then | leave\",\n    \"tags\": null\n  }\n]\n"},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		if err := List(&out, tt.format, items, itemColumns); err != nil {
+			t.Errorf("List(%s) error = %v", tt.format, err)
+			continue
+		}
+		if out.String() != tt.want {
+			t.Errorf("List(%s) =\n%s\nwant:\n%s", tt.format, out.String(), tt.want)
+		}
+	}
+}
+
+func TestList_empty(t *testing.T) {
+	for format, want := range map[Format]string{JSON: "[]\n", YAML: "[]\n", JSONL: "", CSV: "ID,NAME\n", Table: "ID  NAME\n"} {
+		var out bytes.Buffer
+		if err := List(&out, format, nil, itemColumns); err != nil || out.String() != want {
+			t.Errorf("List(%s, nil) = %q, %v, want %q", format, out.String(), err, want)
+		}
+	}
+}
+
+type failingPager struct{ calls int }
+
+func (p *failingPager) Next() bool   { p.calls++; return p.calls == 1 }
+func (p *failingPager) Page() []item { return items[:1] }
+func (p *failingPager) Err() error   { return errors.New("boom") }
+
+func TestPages_closesJSONOnError(t *testing.T) {
+	var out bytes.Buffer
+	if err := Pages(&out, JSON, &failingPager{}, itemColumns); err == nil {
+		t.Fatal("Pages() error = nil")
+	}
+	if !strings.HasSuffix(out.String(), "\n]\n") {
+		t.Errorf("partial output %q is not a closed array", out.String())
+	}
+}
+
+func TestValue(t *testing.T) {
+	note := "fragile: handle with care"
+	v := map[string]any{"status": "ok", "item": item{ID: "1", Name: "Eggs", Note: &note}}
+
+	var out bytes.Buffer
+	if err := Value(&out, YAML, v); err != nil {
+		t.Fatal(err)
+	}
+	want := "item:\n  id: \"1\"\n  name: Eggs\n  tags: null\n  note: \"fragile: handle with care\"\nstatus: ok\n"
+	if out.String() != want {
+		t.Errorf("Value(yaml) =\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	out.Reset()
+	if err := Value(&out, CSV, v); err != nil {
+		t.Fatal(err)
+	}
+	want = "item,status\n\"{\"\"id\"\":\"\"1\"\",\"\"name\"\":\"\"Eggs\"\",\"\"tags\"\":null,\"\"note\"\":\"\"fragile: handle with care\"\"}\",ok\n"
+	if out.String() != want {
+		t.Errorf("Value(csv) =\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	// Lists get a row per element and a column per field seen.
+	out.Reset()
+	if err := Value(&out, Markdown, []item{{ID: "1", Name: "Eggs"}, {ID: "2", Note: &note}}); err != nil {
+		t.Fatal(err)
+	}
+	want = "| id | name | tags | note |\n| --- | --- | --- | --- |\n| 1 | Eggs |  |  |\n| 2 |  |  | fragile: handle with care |\n"
+	if out.String() != want {
+		t.Errorf("Value(markdown) =\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	out.Reset()
+	if err := Value(&out, JSONL, items); err != nil || strings.Count(out.String(), "\n") != 2 {
+		t.Errorf("Value(jsonl) = %q, %v, want a line per item", out.String(), err)
+	}
+}
+
+func TestWriteTable_truncates(t *testing.T) {
+	rows := [][]string{
+		{"1", "A very long task name that will not fit", "today"},
+		{"2", "Short", "2026-03-12"},
+	}
+	var out bytes.Buffer
+	if err := writeTable(&out, []string{"ID", "CONTENT", "DUE"}, rows, 30); err != nil {
+		t.Fatal(err)
+	}
+	want := "ID  CONTENT         DUE\n" +
+		"1   A very long t…  today\n" +
+		"2   Short           2026-03-12\n"
+	if out.String() != want {
+		t.Errorf("writeTable() =\n%s\nwant:\n%s", out.String(), want)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n") {
+		if n := len([]rune(line)); n > 30 {
+			t.Errorf("line %q is %d wide, want at most 30", line, n)
+		}
+	}
+}
+
+func TestTemplateFormat(t *testing.T) {
+	format := TemplateFormat(template.Must(template.New("t").Parse("{{.ID}}: {{.Name}}")))
+
+	var out bytes.Buffer
+	if err := List(&out, format, items, itemColumns); err != nil {
+		t.Fatal(err)
+	}
+	if want := "1: Buy milk\n22: Say \"hi\", then | leave\n"; out.String() != want {
+		t.Errorf("List(template) = %q, want %q", out.String(), want)
+	}
+
+	out.Reset()
+	if err := Value(&out, format, items[0]); err != nil || out.String() != "1: Buy milk\n" {
+		t.Errorf("Value(template) = %q, %v", out.String(), err)
+	}
+
+	if err := Value(&out, format, struct{ Other int }{}); err == nil || !strings.Contains(err.Error(), "failed to render template") {
+		t.Errorf("Value(template) with a missing field error = %v", err)
+	}
+}
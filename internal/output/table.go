@@ -0,0 +1,110 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// minColumn is the narrowest a column is truncated to.
+const minColumn = 6
+
+// Width returns the width table output to w should fit: $COLUMNS if set,
+// otherwise the terminal's width if w is a terminal, otherwise 0 for no
+// limit.
+func Width(w io.Writer) int {
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	if f, ok := w.(*os.File); ok {
+		return terminalWidth(f)
+	}
+	return 0
+}
+
+// writeTable writes rows under header with columns aligned, separated by
+// two spaces. If width is positive, the widest columns are truncated with
+// "…" until each line fits.
+func writeTable(w io.Writer, header []string, rows [][]string, width int) error {
+	widths := make([]int, len(header))
+	measure := func(row []string) {
+		for i, c := range row {
+			if n := utf8.RuneCountInString(c); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+	for i, row := range rows {
+		for j, c := range row {
+			rows[i][j] = strings.ReplaceAll(c, "\n", " ")
+		}
+		measure(rows[i])
+	}
+	measure(header)
+	if width > 0 {
+		fit(widths, width)
+	}
+
+	writeRow := func(row []string) error {
+		cells := make([]string, len(row))
+		for i, c := range row {
+			c = truncate(c, widths[i])
+			if i < len(row)-1 {
+				c += strings.Repeat(" ", widths[i]-utf8.RuneCountInString(c))
+			}
+			cells[i] = c
+		}
+		_, err := fmt.Fprintln(w, strings.Join(cells, "  "))
+		return err
+	}
+	if err := writeRow(header); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := writeRow(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fit narrows the widest column, one character at a time, until the
+// columns and their separators fit in width or every column is at
+// minColumn.
+func fit(widths []int, width int) {
+	total := 2 * (len(widths) - 1)
+	for _, n := range widths {
+		total += n
+	}
+	for total > width {
+		widest := 0
+		for i, n := range widths {
+			if n > widths[widest] {
+				widest = i
+			}
+		}
+		if widths[widest] <= minColumn {
+			return
+		}
+		widths[widest]--
+		total--
+	}
+}
+
+// truncate shortens s to n characters, ending in "…" if anything was cut.
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	r := []rune(s)
+	return string(r[:n-1]) + "…"
+}
+
+// IsTerminal reports whether w is a terminal.
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && terminalWidth(f) > 0
+}
@@ -0,0 +1,11 @@
+//go:build !linux && !darwin
+
+package output
+
+import "os"
+
+// terminalWidth returns 0: terminal size isn't detected on this platform,
+// so tables are only truncated when $COLUMNS is set.
+func terminalWidth(f *os.File) int {
+	return 0
+}
@@ -0,0 +1,177 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// writeYAML writes v as a YAML document. v is encoded to JSON first, so
+// field names and omitted fields follow the json struct tags, and object
+// keys keep the order the JSON encoder gives them.
+func writeYAML(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode value: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	root, err := decodeNode(dec)
+	if err != nil {
+		return fmt.Errorf("failed to encode YAML: %w", err)
+	}
+
+	var b strings.Builder
+	root.write(&b, 0, false)
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// yamlNode is a JSON value with object keys kept in order.
+type yamlNode struct {
+	scalar string // already rendered; set when object and array are nil
+	object []yamlField
+	array  []*yamlNode
+	isObj  bool
+	isArr  bool
+}
+
+type yamlField struct {
+	key   string
+	value *yamlNode
+}
+
+func decodeNode(dec *json.Decoder) (*yamlNode, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			n := &yamlNode{isObj: true}
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				value, err := decodeNode(dec)
+				if err != nil {
+					return nil, err
+				}
+				n.object = append(n.object, yamlField{key: key.(string), value: value})
+			}
+			_, err := dec.Token() // '}'
+			return n, err
+		case '[':
+			n := &yamlNode{isArr: true}
+			for dec.More() {
+				value, err := decodeNode(dec)
+				if err != nil {
+					return nil, err
+				}
+				n.array = append(n.array, value)
+			}
+			_, err := dec.Token() // ']'
+			return n, err
+		}
+		return nil, fmt.Errorf("unexpected %v", t)
+	case string:
+		return &yamlNode{scalar: yamlString(t)}, nil
+	case json.Number:
+		return &yamlNode{scalar: t.String()}, nil
+	case bool:
+		return &yamlNode{scalar: strconv.FormatBool(t)}, nil
+	default: // nil
+		return &yamlNode{scalar: "null"}, nil
+	}
+}
+
+// empty reports whether n is a collection written inline as {} or [].
+func (n *yamlNode) empty() bool {
+	return (n.isObj && len(n.object) == 0) || (n.isArr && len(n.array) == 0)
+}
+
+func (n *yamlNode) inline() string {
+	if n.isObj {
+		return "{}"
+	}
+	if n.isArr {
+		return "[]"
+	}
+	return n.scalar
+}
+
+// write writes n at the given indent. inList is true when n follows a
+// "- " marker, so its first line is already indented.
+func (n *yamlNode) write(b *strings.Builder, indent int, inList bool) {
+	pad := strings.Repeat("  ", indent)
+	switch {
+	case n.empty() || (!n.isObj && !n.isArr):
+		b.WriteString(n.inline())
+		b.WriteByte('\n')
+	case n.isObj:
+		for i, f := range n.object {
+			if i > 0 || !inList {
+				b.WriteString(pad)
+			}
+			b.WriteString(yamlString(f.key))
+			b.WriteByte(':')
+			if f.value.empty() || (!f.value.isObj && !f.value.isArr) {
+				b.WriteByte(' ')
+				f.value.write(b, indent+1, false)
+				continue
+			}
+			b.WriteByte('\n')
+			if f.value.isArr {
+				f.value.write(b, indent, false) // sequences sit at the key's indent
+			} else {
+				f.value.write(b, indent+1, false)
+			}
+		}
+	case n.isArr:
+		for i, item := range n.array {
+			if i > 0 || !inList {
+				b.WriteString(pad)
+			}
+			b.WriteString("- ")
+			item.write(b, indent+1, true)
+		}
+	}
+}
+
+// yamlString quotes s when a plain scalar would be read back as something
+// else: a number, a boolean, null, or YAML syntax.
+func yamlString(s string) string {
+	if s == "" || needsQuotes(s) {
+		data, _ := json.Marshal(s) // JSON strings are valid YAML double-quoted scalars
+		return string(data)
+	}
+	return s
+}
+
+func needsQuotes(s string) bool {
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "null", "~", "y", "n":
+		return true
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return true
+	}
+	if strings.TrimSpace(s) != s || strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`") {
+		return true
+	}
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") {
+		return true
+	}
+	for _, r := range s {
+		if r < ' ' {
+			return true
+		}
+	}
+	return false
+}
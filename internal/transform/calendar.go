@@ -0,0 +1,148 @@
+package transform
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+// calendarCell is the inner width of a calendar grid cell.
+const calendarCell = 7
+
+// CalendarDay is one cell of a calendar grid: the tasks due that day and
+// the tasks whose deadline falls on it.
+type CalendarDay struct {
+	Date      time.Time
+	Tasks     []*api.Task
+	Deadlines []*api.Task
+}
+
+// Calendar draws days as a grid, one row per week starting on Monday.
+// Each cell shows the day of the month, the number of tasks due (colored
+// by their highest priority, or as overdue for past days) and a ◆ for
+// deadlines.
+type Calendar struct {
+	// Theme colors the counts and deadline markers; nil renders plain text.
+	Theme *Theme
+
+	// Today is midnight of the day bracketed as "[10]".
+	Today time.Time
+}
+
+// Grid renders consecutive days. Cells before the first day's weekday and
+// after the last day's are left blank.
+func (c *Calendar) Grid(days []CalendarDay) string {
+	if len(days) == 0 {
+		return ""
+	}
+	cells := make([]*CalendarDay, (int(days[0].Date.Weekday())+6)%7)
+	for i := range days {
+		cells = append(cells, &days[i])
+	}
+	for len(cells)%7 != 0 {
+		cells = append(cells, nil)
+	}
+
+	var b strings.Builder
+	border := func(left, middle, right string) {
+		b.WriteString(left)
+		for i := 0; i < 7; i++ {
+			if i > 0 {
+				b.WriteString(middle)
+			}
+			b.WriteString(strings.Repeat("─", calendarCell))
+		}
+		b.WriteString(right + "\n")
+	}
+	row := func(week []*CalendarDay, cell func(*CalendarDay) string) {
+		b.WriteString("│")
+		for _, d := range week {
+			b.WriteString(cell(d) + "│")
+		}
+		b.WriteString("\n")
+	}
+
+	var header []string
+	for _, name := range []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"} {
+		header = append(header, fmt.Sprintf(" %-*s", calendarCell, name))
+	}
+	b.WriteString(strings.TrimRight(strings.Join(header, ""), " ") + "\n")
+
+	border("┌", "┬", "┐")
+	for i := 0; i < len(cells); i += 7 {
+		if i > 0 {
+			border("├", "┼", "┤")
+		}
+		row(cells[i:i+7], c.dayNumber)
+		row(cells[i:i+7], c.summary)
+	}
+	border("└", "┴", "┘")
+	return b.String()
+}
+
+// dayNumber renders the first line of a cell: the day of the month.
+func (c *Calendar) dayNumber(d *CalendarDay) string {
+	if d == nil {
+		return pad("", "")
+	}
+	s := fmt.Sprintf(" %d", d.Date.Day())
+	if d.Date.Equal(c.Today) {
+		s = fmt.Sprintf("[%d]", d.Date.Day())
+	}
+	return pad(s, s)
+}
+
+// summary renders the second line of a cell: the count of tasks due and
+// the deadline marker, "◆" or "◆2" for more than one.
+func (c *Calendar) summary(d *CalendarDay) string {
+	if d == nil || (len(d.Tasks) == 0 && len(d.Deadlines) == 0) {
+		return pad("", "")
+	}
+	th := c.Theme
+	if th == nil {
+		th = &Theme{}
+	}
+	past := d.Date.Before(c.Today)
+
+	plain, painted := "", ""
+	if n := len(d.Tasks); n > 0 {
+		top := 0
+		for _, t := range d.Tasks {
+			if t.Priority > top {
+				top = t.Priority
+			}
+		}
+		color := th.priority(top)
+		if past {
+			color = th.Overdue
+		}
+		count := strconv.Itoa(n)
+		plain += " " + count
+		painted += " " + th.paint(color, count)
+	}
+	if n := len(d.Deadlines); n > 0 {
+		marker := "◆"
+		if n > 1 {
+			marker += strconv.Itoa(n)
+		}
+		color := th.Deadline
+		if past {
+			color = th.Overdue
+		}
+		plain += " " + marker
+		painted += " " + th.paint(color, marker)
+	}
+	return pad(plain, painted)
+}
+
+// pad fills painted, the colored form of plain, out to the cell width.
+func pad(plain, painted string) string {
+	if n := calendarCell - utf8.RuneCountInString(plain); n > 0 {
+		return painted + strings.Repeat(" ", n)
+	}
+	return painted
+}
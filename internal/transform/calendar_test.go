@@ -0,0 +1,32 @@
+package transform
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+func TestCalendar_GridColor(t *testing.T) {
+	today := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	c := &Calendar{Theme: &Theme{P1: "1", Overdue: "2", Deadline: "3"}, Today: today}
+
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	urgent := &api.Task{Priority: 4}
+	normal := &api.Task{Priority: 1}
+	days := []CalendarDay{
+		{Date: day(9), Tasks: []*api.Task{normal}},
+		{Date: day(10), Tasks: []*api.Task{normal, urgent}, Deadlines: []*api.Task{normal, urgent}},
+	}
+
+	grid := c.Grid(days)
+	for _, want := range []string{
+		"│ 9     │[10]   │       │",
+		"│ \x1b[2m1\x1b[0m     │ \x1b[1m2\x1b[0m \x1b[3m◆2\x1b[0m  │       │", // overdue, then P1
+	} {
+		if !strings.Contains(grid, want) {
+			t.Errorf("Grid() missing %q in:\n%s", want, grid)
+		}
+	}
+}
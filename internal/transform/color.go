@@ -0,0 +1,91 @@
+package transform
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Theme colors parts of a task line. Each field holds ANSI SGR parameters,
+// such as "1;31" for bold red; an empty field leaves that part plain.
+type Theme struct {
+	P1, P2, P3 string // the [P1]..[P3] priority tags
+	Overdue    string // due dates and deadlines that have passed
+	Label      string
+	Project    string
+	Deadline   string
+}
+
+// Themes are the built-in themes, selected with the theme config key.
+var Themes = map[string]Theme{
+	"default": {P1: "31", P2: "33", P3: "34", Overdue: "31", Label: "36", Project: "2", Deadline: "35"},
+	"vivid":   {P1: "1;91", P2: "1;93", P3: "1;94", Overdue: "1;91", Label: "96", Project: "95", Deadline: "1;95"},
+	"mono":    {P1: "1", P2: "1", P3: "", Overdue: "1;4", Label: "", Project: "2", Deadline: "4"},
+}
+
+// themeRoles maps color.<role> config keys to theme fields.
+var themeRoles = map[string]func(*Theme) *string{
+	"p1":       func(t *Theme) *string { return &t.P1 },
+	"p2":       func(t *Theme) *string { return &t.P2 },
+	"p3":       func(t *Theme) *string { return &t.P3 },
+	"overdue":  func(t *Theme) *string { return &t.Overdue },
+	"label":    func(t *Theme) *string { return &t.Label },
+	"project":  func(t *Theme) *string { return &t.Project },
+	"deadline": func(t *Theme) *string { return &t.Deadline },
+}
+
+// LookupTheme returns the named theme with overrides applied. overrides
+// maps roles (p1, p2, p3, overdue, label, project, deadline) to SGR
+// parameters. An empty name means "default"; "none" returns nil, for no
+// color.
+func LookupTheme(name string, overrides map[string]string) (*Theme, error) {
+	if name == "" {
+		name = "default"
+	}
+	if name == "none" {
+		return nil, nil
+	}
+	base, ok := Themes[name]
+	if !ok {
+		names := []string{"none"}
+		for n := range Themes {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown theme %q (want %s)", name, strings.Join(names, ", "))
+	}
+
+	theme := base
+	for role, sgr := range overrides {
+		field, ok := themeRoles[role]
+		if !ok {
+			return nil, fmt.Errorf("unknown color role %q", role)
+		}
+		if strings.Trim(sgr, "0123456789;") != "" {
+			return nil, fmt.Errorf("invalid color for %s: %q (want SGR codes such as 1;31)", role, sgr)
+		}
+		*field(&theme) = sgr
+	}
+	return &theme, nil
+}
+
+// paint wraps s in the escape codes for sgr.
+func (t *Theme) paint(sgr, s string) string {
+	if sgr == "" || s == "" {
+		return s
+	}
+	return "\x1b[" + sgr + "m" + s + "\x1b[0m"
+}
+
+// priority returns the color for an API priority.
+func (t *Theme) priority(apiPriority int) string {
+	switch apiPriority {
+	case 4:
+		return t.P1
+	case 3:
+		return t.P2
+	case 2:
+		return t.P3
+	}
+	return ""
+}
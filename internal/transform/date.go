@@ -2,6 +2,8 @@ package transform
 
 import (
 	"time"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
 )
 
 // FormatDueDate converts a Todoist due date string to a human-friendly display.
@@ -14,10 +16,16 @@ import (
 //	FormatDueDate("today's date", "")         // "Today"
 //	FormatDueDate("tomorrow's date", "")      // "Tomorrow"
 func FormatDueDate(date, datetime string) string {
+	return FormatDueDateAt(date, datetime, time.Now())
+}
+
+// FormatDueDateAt is FormatDueDate with "today" taken from now, and times
+// shown in now's location.
+func FormatDueDateAt(date, datetime string, now time.Time) string {
 	if datetime != "" {
 		t, err := time.Parse(time.RFC3339, datetime)
 		if err == nil {
-			return t.Local().Format("2006-01-02 15:04")
+			return t.In(now.Location()).Format("2006-01-02 15:04")
 		}
 	}
 
@@ -30,7 +38,6 @@ func FormatDueDate(date, datetime string) string {
 		return date // Return as-is if unparseable
 	}
 
-	now := time.Now()
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 	d := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, now.Location())
 
@@ -50,6 +57,11 @@ func FormatDueDate(date, datetime string) string {
 
 // IsOverdue returns true if the given date string is before today.
 func IsOverdue(date string) bool {
+	return IsOverdueAt(date, time.Now())
+}
+
+// IsOverdueAt is IsOverdue with "today" taken from now.
+func IsOverdueAt(date string, now time.Time) bool {
 	if date == "" {
 		return false
 	}
@@ -57,8 +69,41 @@ func IsOverdue(date string) bool {
 	if err != nil {
 		return false
 	}
-	now := time.Now()
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 	d := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, now.Location())
 	return d.Before(today)
 }
+
+// DueDay returns midnight, in loc, of the day d falls on: the local day of
+// a due time, or the date itself for all-day and floating due dates.
+func DueDay(d *api.Due, loc *time.Location) (time.Time, bool) {
+	if d == nil {
+		return time.Time{}, false
+	}
+	if t, ok := DueTime(d, loc); ok {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc), true
+	}
+	day, err := time.ParseInLocation("2006-01-02", d.Date, loc)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return day, true
+}
+
+// DueTime returns the moment a timed due date falls at, in loc. ok is
+// false for all-day due dates. Floating times ("2006-01-02T15:04:05"
+// without a zone) are read as times in loc.
+func DueTime(d *api.Due, loc *time.Location) (time.Time, bool) {
+	if d == nil {
+		return time.Time{}, false
+	}
+	if d.Datetime != "" {
+		if t, err := time.Parse(time.RFC3339, d.Datetime); err == nil {
+			return t.In(loc), true
+		}
+	}
+	if t, err := time.ParseInLocation("2006-01-02T15:04:05", d.Date, loc); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
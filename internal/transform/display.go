@@ -3,27 +3,101 @@ package transform
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/joeyhipolito/todoist-cli/internal/api"
 )
 
 // FormatTaskLine renders a single task as a human-readable one-liner.
 //
-// Format: "  <id> [P1] Task content (due-date) @label1 @label2"
+// Format: "  <id> [P1] Task content (due-date) @label1 @label2 (deadline YYYY-MM-DD)"
 func FormatTaskLine(t *api.Task) string {
-	priority := FormatPriority(t.Priority)
+	return (&TaskLine{}).Format(t)
+}
+
+// TaskLine renders tasks like FormatTaskLine, optionally in color and with
+// project names. The zero value renders plain lines relative to the
+// current time.
+type TaskLine struct {
+	// Theme colors priorities, labels, overdue dates and the rest; nil
+	// renders plain text.
+	Theme *Theme
+
+	// Projects maps project IDs to names. When set, each line ends with
+	// the task's "#Project".
+	Projects map[string]string
+
+	// Now returns the time due dates are relative to. Nil means time.Now.
+	Now func() time.Time
+
+	// NoDue leaves out the due date, for lines under a heading that
+	// already gives it.
+	NoDue bool
+}
 
-	due := ""
-	if t.Due != nil {
-		d := FormatDueDate(t.Due.Date, t.Due.Datetime)
-		if d != "" {
-			due = " (" + d + ")"
+// Format renders t.
+//
+// Format: "  <id> [P1] Task content (due-date) @label1 @label2 #Project (deadline YYYY-MM-DD)"
+func (l *TaskLine) Format(t *api.Task) string {
+	now := time.Now()
+	if l.Now != nil {
+		now = l.Now()
+	}
+	th := l.Theme
+	if th == nil {
+		th = &Theme{} // no colors
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "  %s %s %s", t.ID, th.paint(th.priority(t.Priority), "["+FormatPriority(t.Priority)+"]"), t.Content)
+
+	if t.Due != nil && !l.NoDue {
+		due := FormatDueDateAt(t.Due.Date, t.Due.Datetime, now)
+		if due == "" {
+			// A task queued offline keeps its due string until the API parses it.
+			due = t.Due.String
+		}
+		if due != "" {
+			color := ""
+			if isOverdue(t.Due, now) {
+				color = th.Overdue
+			}
+			b.WriteString(" " + th.paint(color, "("+due+")"))
 		}
 	}
 
-	labels := FormatLabels(t.Labels)
+	if len(t.Labels) > 0 {
+		b.WriteString(" " + th.paint(th.Label, strings.TrimSpace(FormatLabels(t.Labels))))
+	}
+
+	if name, ok := l.Projects[t.ProjectID]; ok {
+		b.WriteString(" " + th.paint(th.Project, "#"+name))
+	}
+
+	if t.Deadline != nil && t.Deadline.Date != "" {
+		color := th.Deadline
+		if IsOverdueAt(t.Deadline.Date, now) {
+			color = th.Overdue
+		}
+		b.WriteString(" " + th.paint(color, "(deadline "+t.Deadline.Date+")"))
+	}
 
-	return fmt.Sprintf("  %s [%s] %s%s%s", t.ID, priority, t.Content, due, labels)
+	return b.String()
+}
+
+// isOverdue reports whether a due date is before today, or a due time
+// before now.
+func isOverdue(due *api.Due, now time.Time) bool {
+	if due.Datetime != "" {
+		if t, err := time.Parse(time.RFC3339, due.Datetime); err == nil {
+			return t.Before(now)
+		}
+	}
+	date := due.Date
+	if len(date) > len("2006-01-02") {
+		date = date[:len("2006-01-02")] // floating time, "2006-01-02T15:04:05"
+	}
+	return IsOverdueAt(date, now)
 }
 
 // FormatLabels returns labels as a space-separated "@label" string.
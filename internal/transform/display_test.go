@@ -0,0 +1,83 @@
+package transform
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+func TestTaskLine_Format(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	plain := &TaskLine{Projects: map[string]string{"work": "Work"}, Now: func() time.Time { return now }}
+
+	tests := []struct {
+		task *api.Task
+		want string
+	}{
+		{&api.Task{ID: "1", Content: "Buy milk", Priority: 1}, "  1 [P4] Buy milk"},
+		{&api.Task{ID: "2", Content: "Report", Priority: 4, ProjectID: "work", Due: &api.Due{Date: "2026-03-10"}, Labels: []string{"deep", "q1"}},
+			"  2 [P1] Report (Today) @deep @q1 #Work"},
+		{&api.Task{ID: "3", Content: "Call", Due: &api.Due{Date: "2026-03-11"}, Deadline: &api.Deadline{Date: "2026-03-20"}},
+			"  3 [P4] Call (Tomorrow) (deadline 2026-03-20)"},
+		{&api.Task{ID: "4", Content: "Late", Due: &api.Due{Date: "2026-03-01"}}, "  4 [P4] Late (2026-03-01 (overdue))"},
+		{&api.Task{ID: "5", Content: "Queued", Due: &api.Due{String: "next friday"}}, "  5 [P4] Queued (next friday)"},
+		{&api.Task{ID: "6", Content: "Standup", Due: &api.Due{Date: "2026-03-10", Datetime: "2026-03-10T09:30:00Z"}}, "  6 [P4] Standup (2026-03-10 09:30)"},
+	}
+	for _, tt := range tests {
+		if got := plain.Format(tt.task); got != tt.want {
+			t.Errorf("Format(%s) = %q, want %q", tt.task.Content, got, tt.want)
+		}
+	}
+}
+
+func TestTaskLine_FormatColor(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	theme, err := LookupTheme("default", map[string]string{"label": "1;32"})
+	if err != nil {
+		t.Fatalf("LookupTheme() error = %v", err)
+	}
+	line := &TaskLine{Theme: theme, Projects: map[string]string{"work": "Work"}, Now: func() time.Time { return now }}
+
+	got := line.Format(&api.Task{ID: "1", Content: "Report", Priority: 4, ProjectID: "work",
+		Due: &api.Due{Date: "2026-03-10", Datetime: "2026-03-10T09:00:00Z"}, Labels: []string{"deep"},
+		Deadline: &api.Deadline{Date: "2026-03-09"}})
+	want := "  1 \x1b[31m[P1]\x1b[0m Report \x1b[31m(2026-03-10 09:00)\x1b[0m \x1b[1;32m@deep\x1b[0m \x1b[2m#Work\x1b[0m \x1b[31m(deadline 2026-03-09)\x1b[0m"
+	if got != want {
+		t.Errorf("Format() =\n%q\nwant:\n%q", got, want)
+	}
+
+	// P4 and future dates stay plain.
+	got = line.Format(&api.Task{ID: "2", Content: "Later", Priority: 1, Due: &api.Due{Date: "2026-03-12"}})
+	if strings.Contains(got, "\x1b") {
+		t.Errorf("Format() = %q, want no color", got)
+	}
+}
+
+func TestLookupTheme(t *testing.T) {
+	if theme, err := LookupTheme("none", nil); theme != nil || err != nil {
+		t.Errorf("LookupTheme(none) = %v, %v, want nil, nil", theme, err)
+	}
+	if theme, err := LookupTheme("", nil); err != nil || theme.P1 != Themes["default"].P1 {
+		t.Errorf("LookupTheme(\"\") = %+v, %v, want the default theme", theme, err)
+	}
+	for _, tt := range []struct {
+		name      string
+		overrides map[string]string
+		wantErr   string
+	}{
+		{"neon", nil, "unknown theme"},
+		{"mono", map[string]string{"title": "1"}, "unknown color role"},
+		{"mono", map[string]string{"p1": "red"}, "invalid color"},
+	} {
+		if _, err := LookupTheme(tt.name, tt.overrides); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("LookupTheme(%q, %v) error = %v, want %q", tt.name, tt.overrides, err, tt.wantErr)
+		}
+	}
+
+	// Overrides don't leak into the built-in themes.
+	if _, err := LookupTheme("default", map[string]string{"p1": "7"}); err != nil || Themes["default"].P1 != "31" {
+		t.Errorf("Themes[default].P1 = %q after an override", Themes["default"].P1)
+	}
+}
@@ -0,0 +1,78 @@
+package transform
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseDuration converts a user-facing duration such as "30m", "90min",
+// "2h", "1h30m" or "2d" into the amount and unit the API expects ("minute"
+// or "day"). Hours are converted to minutes, so it accepts everything
+// FormatDuration prints. A bare number is treated as minutes.
+func ParseDuration(input string) (int, string, error) {
+	invalid := fmt.Errorf("duration must be a positive number of minutes, hours or days (e.g. 30m, 1h30m, 2d): %s", input)
+	s := strings.ToLower(strings.TrimSpace(input))
+
+	// Read number-unit pairs: "1h30m" is 1 "h" then 30 "m".
+	total, unit := 0, ""
+	for s != "" {
+		digits := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+		if digits == -1 {
+			digits = len(s)
+		}
+		n, err := strconv.Atoi(s[:digits])
+		if err != nil {
+			return 0, "", invalid
+		}
+		s = s[digits:]
+		word := strings.IndexFunc(s, func(r rune) bool { return r >= '0' && r <= '9' })
+		if word == -1 {
+			word = len(s)
+		}
+		suffix := strings.TrimSpace(s[:word])
+		s = s[word:]
+
+		partUnit, scale := "minute", 1
+		switch suffix {
+		case "", "m", "min", "mins", "minute", "minutes":
+		case "h", "hr", "hrs", "hour", "hours":
+			scale = 60
+		case "d", "day", "days":
+			partUnit = "day"
+		default:
+			return 0, "", invalid
+		}
+		// Days can't be mixed with hours or minutes.
+		if unit != "" && unit != partUnit {
+			return 0, "", invalid
+		}
+		unit = partUnit
+		total += n * scale
+	}
+
+	if total <= 0 {
+		return 0, "", invalid
+	}
+	return total, unit, nil
+}
+
+// FormatDuration renders an API duration as a compact string like "30m",
+// "1h30m" or "2d". Returns empty string for a zero amount.
+func FormatDuration(amount int, unit string) string {
+	if amount <= 0 {
+		return ""
+	}
+	if unit == "day" {
+		return fmt.Sprintf("%dd", amount)
+	}
+	h, m := amount/60, amount%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dh%dm", h, m)
+	}
+}
@@ -0,0 +1,61 @@
+package transform
+
+import "testing"
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input      string
+		wantAmount int
+		wantUnit   string
+		wantErr    bool
+	}{
+		{"45", 45, "minute", false},
+		{"30m", 30, "minute", false},
+		{"90min", 90, "minute", false},
+		{"15 minutes", 15, "minute", false},
+		{"2h", 120, "minute", false},
+		{"1h30m", 90, "minute", false},
+		{"1h 30m", 90, "minute", false},
+		{"1H15", 75, "minute", false},
+		{"3 hours", 180, "minute", false},
+		{"2d", 2, "day", false},
+		{" 1 day ", 1, "day", false},
+		{"", 0, "", true},
+		{"0m", 0, "", true},
+		{"-5m", 0, "", true},
+		{"1.5h", 0, "", true},
+		{"1d2h", 0, "", true},
+		{"2w", 0, "", true},
+		{"h", 0, "", true},
+	}
+
+	for _, tt := range tests {
+		amount, unit, err := ParseDuration(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseDuration(%q) = %d %s, want error", tt.input, amount, unit)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseDuration(%q) error = %v", tt.input, err)
+			continue
+		}
+		if amount != tt.wantAmount || unit != tt.wantUnit {
+			t.Errorf("ParseDuration(%q) = %d %s, want %d %s", tt.input, amount, unit, tt.wantAmount, tt.wantUnit)
+		}
+	}
+}
+
+func TestParseDuration_readsFormatDuration(t *testing.T) {
+	for _, d := range []struct {
+		amount int
+		unit   string
+	}{{30, "minute"}, {60, "minute"}, {90, "minute"}, {125, "minute"}, {3, "day"}} {
+		s := FormatDuration(d.amount, d.unit)
+		amount, unit, err := ParseDuration(s)
+		if err != nil || amount != d.amount || unit != d.unit {
+			t.Errorf("ParseDuration(%q) = %d %s, %v; want %d %s", s, amount, unit, err, d.amount, d.unit)
+		}
+	}
+}
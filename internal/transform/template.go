@@ -0,0 +1,89 @@
+package transform
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+// TemplateFuncs returns the helpers available to --template output:
+//
+//	priority   "P1".."P4" for a task or an API priority number
+//	due        the due date as FormatDueDate shows it, "" if none
+//	labels     "@a @b" for a task, a label or a list of label names
+//	overdue    whether a task or a YYYY-MM-DD date is before today
+//
+// Each accepts the value being rendered, so {{due .}} works for tasks and
+// returns "" for results without a due date, such as projects.
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"priority": templatePriority,
+		"due":      templateDue,
+		"labels":   templateLabels,
+		"overdue":  templateOverdue,
+	}
+}
+
+func templatePriority(v any) string {
+	switch v := v.(type) {
+	case int:
+		return FormatPriority(v)
+	case *api.Task:
+		return FormatPriority(v.Priority)
+	case api.Task:
+		return FormatPriority(v.Priority)
+	}
+	return ""
+}
+
+func templateDue(v any) string {
+	if due := dueOf(v); due != nil {
+		return FormatDueDate(due.Date, due.Datetime)
+	}
+	return ""
+}
+
+func templateLabels(v any) string {
+	switch v := v.(type) {
+	case []string:
+		return strings.TrimSpace(FormatLabels(v))
+	case *api.Task:
+		return strings.TrimSpace(FormatLabels(v.Labels))
+	case api.Task:
+		return strings.TrimSpace(FormatLabels(v.Labels))
+	case *api.Label:
+		return "@" + v.Name
+	case api.Label:
+		return "@" + v.Name
+	}
+	return ""
+}
+
+func templateOverdue(v any) bool {
+	if date, ok := v.(string); ok {
+		return IsOverdue(date)
+	}
+	due := dueOf(v)
+	if due == nil {
+		return false
+	}
+	date := due.Date
+	if len(date) > len("2006-01-02") {
+		date = date[:len("2006-01-02")] // floating time, "2006-01-02T15:04:05"
+	}
+	return IsOverdue(date)
+}
+
+// dueOf returns the due date of a task or a due date, or nil.
+func dueOf(v any) *api.Due {
+	switch v := v.(type) {
+	case *api.Task:
+		return v.Due
+	case api.Task:
+		return v.Due
+	case *api.Due:
+		return v
+	}
+	return nil
+}
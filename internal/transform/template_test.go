@@ -0,0 +1,50 @@
+package transform
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	tmpl := template.Must(template.New("t").Funcs(TemplateFuncs()).Parse(
+		`{{priority .}} {{.Content}} [{{due .}}] {{labels .}}{{if overdue .}} overdue{{end}}`))
+
+	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	tests := []struct {
+		task *api.Task
+		want string
+	}{
+		{&api.Task{Content: "Buy milk", Priority: 4, Labels: []string{"errands", "home"}, Due: &api.Due{Date: "2030-01-15"}}, "P1 Buy milk [2030-01-15] @errands @home"},
+		{&api.Task{Content: "Call", Priority: 1, Due: &api.Due{Date: yesterday}}, "P4 Call [Yesterday] "},
+		{&api.Task{Content: "Someday", Priority: 2}, "P3 Someday [] "},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		if err := tmpl.Execute(&b, tt.task); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		want := tt.want
+		if overdue := tt.task.Due != nil && tt.task.Due.Date == yesterday; overdue {
+			want += " overdue"
+		}
+		if b.String() != want {
+			t.Errorf("Execute(%s) = %q, want %q", tt.task.Content, b.String(), want)
+		}
+	}
+
+	// Helpers accept other results too.
+	funcs := TemplateFuncs()
+	if got := funcs["labels"].(func(any) string)(&api.Label{Name: "work"}); got != "@work" {
+		t.Errorf("labels(label) = %q, want @work", got)
+	}
+	if got := funcs["due"].(func(any) string)(api.CompletedTask{}); got != "" {
+		t.Errorf("due(completed task) = %q, want empty", got)
+	}
+	if got := funcs["priority"].(func(any) string)(3); got != "P2" {
+		t.Errorf("priority(3) = %q, want P2", got)
+	}
+}
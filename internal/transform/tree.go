@@ -0,0 +1,100 @@
+package transform
+
+import (
+	"sort"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+// TaskNode is a task together with its subtasks.
+type TaskNode struct {
+	Task     *api.Task
+	Children []*TaskNode
+}
+
+// BuildTaskTree nests tasks under their parents. Siblings are sorted by
+// Order; tasks whose parent is not in the list, or whose parent chain
+// loops back to them, become roots and keep their original relative order.
+func BuildTaskTree(tasks []*api.Task) []*TaskNode {
+	nodes := make(map[string]*TaskNode, len(tasks))
+	for _, t := range tasks {
+		nodes[t.ID] = &TaskNode{Task: t}
+	}
+
+	// inCycle reports whether following parents up from t leads back to t.
+	// Without the check every task in a cycle would be nested under another
+	// and none would be reachable from a root.
+	inCycle := func(t *api.Task) bool {
+		seen := map[string]bool{}
+		for id := t.ParentID; id != "" && !seen[id]; {
+			if id == t.ID {
+				return true
+			}
+			seen[id] = true
+			parent, ok := nodes[id]
+			if !ok {
+				return false
+			}
+			id = parent.Task.ParentID
+		}
+		return false
+	}
+
+	var roots []*TaskNode
+	for _, t := range tasks {
+		node := nodes[t.ID]
+		if parent, ok := nodes[t.ParentID]; ok && t.ParentID != "" && !inCycle(t) {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+
+	for _, node := range nodes {
+		sort.SliceStable(node.Children, func(i, j int) bool {
+			return node.Children[i].Task.Order < node.Children[j].Task.Order
+		})
+	}
+
+	return roots
+}
+
+// WalkTaskTree calls fn for every node depth-first, parents before children.
+// depth is 0 for roots.
+func WalkTaskTree(nodes []*TaskNode, fn func(node *TaskNode, depth int)) {
+	var walk func(nodes []*TaskNode, depth int)
+	walk = func(nodes []*TaskNode, depth int) {
+		for _, n := range nodes {
+			fn(n, depth)
+			walk(n.Children, depth+1)
+		}
+	}
+	walk(nodes, 0)
+}
+
+// Descendants returns the IDs of every subtask below taskID, deepest first,
+// so closing them in order never closes a parent before its children.
+func Descendants(tasks []*api.Task, taskID string) []string {
+	children := make(map[string][]string)
+	for _, t := range tasks {
+		if t.ParentID != "" {
+			children[t.ParentID] = append(children[t.ParentID], t.ID)
+		}
+	}
+
+	var ids []string
+	seen := map[string]bool{taskID: true}
+	var visit func(id string)
+	visit = func(id string) {
+		for _, child := range children[id] {
+			if seen[child] {
+				continue
+			}
+			seen[child] = true
+			visit(child)
+			ids = append(ids, child)
+		}
+	}
+	visit(taskID)
+	return ids
+}
@@ -0,0 +1,72 @@
+package transform
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/joeyhipolito/todoist-cli/internal/api"
+)
+
+func TestBuildTaskTree(t *testing.T) {
+	tasks := []*api.Task{
+		{ID: "b2", ParentID: "b", Order: 2},
+		{ID: "a", Order: 1},
+		{ID: "b", Order: 2},
+		{ID: "b1", ParentID: "b", Order: 1},
+		{ID: "b1x", ParentID: "b1", Order: 1},
+		{ID: "orphan", ParentID: "missing", Order: 1},
+	}
+
+	var got []string
+	WalkTaskTree(BuildTaskTree(tasks), func(n *TaskNode, depth int) {
+		got = append(got, string(rune('0'+depth))+":"+n.Task.ID)
+	})
+
+	want := []string{"0:a", "0:b", "1:b1", "2:b1x", "1:b2", "0:orphan"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("walk order = %v, want %v", got, want)
+	}
+}
+
+func TestBuildTaskTree_cycles(t *testing.T) {
+	tasks := []*api.Task{
+		{ID: "a", ParentID: "b", Order: 1},
+		{ID: "b", ParentID: "a", Order: 2},
+		{ID: "a1", ParentID: "a", Order: 1},
+		{ID: "self", ParentID: "self", Order: 3},
+		{ID: "x", ParentID: "z", Order: 4},
+		{ID: "y", ParentID: "x", Order: 1},
+		{ID: "z", ParentID: "y", Order: 1},
+	}
+
+	var got []string
+	WalkTaskTree(BuildTaskTree(tasks), func(n *TaskNode, depth int) {
+		got = append(got, string(rune('0'+depth))+":"+n.Task.ID)
+	})
+
+	// Every task in a cycle becomes a root; tasks hanging off one stay nested.
+	want := []string{"0:a", "1:a1", "0:b", "0:self", "0:x", "0:y", "0:z"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("walk order = %v, want %v", got, want)
+	}
+}
+
+func TestDescendants(t *testing.T) {
+	tasks := []*api.Task{
+		{ID: "root"},
+		{ID: "c1", ParentID: "root"},
+		{ID: "c2", ParentID: "root"},
+		{ID: "g1", ParentID: "c1"},
+		{ID: "other"},
+	}
+
+	got := Descendants(tasks, "root")
+	want := []string{"g1", "c1", "c2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Descendants() = %v, want %v", got, want)
+	}
+
+	if got := Descendants(tasks, "other"); len(got) != 0 {
+		t.Errorf("Descendants(other) = %v, want none", got)
+	}
+}